@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	config "github.com/darki73/ptm/pkg/configuration"
+	"github.com/darki73/ptm/pkg/qemu"
+	ci "github.com/darki73/ptm/pkg/qemu/cloud-init"
+	nc "github.com/darki73/ptm/pkg/qemu/network-config"
+	"github.com/darki73/ptm/pkg/utils"
+	"github.com/spf13/cobra"
+	"strings"
+)
+
+var (
+	// cloudInitDataNames is the map of cloud-init data types to the names of the files they are written to.
+	cloudInitDataNames = map[string]string{
+		ci.SnippetTypeUser:    "user-data",
+		ci.SnippetTypeNetwork: "network-config",
+		ci.SnippetTypeMeta:    "meta-data",
+		ci.SnippetTypeVendor:  "vendor-data",
+	}
+)
+
+// cloudInitCommand represents the cloudinit command.
+var cloudInitCommand = &cobra.Command{
+	Use:   "cloudinit",
+	Short: "Inspects cloud-init data of templates",
+	Long:  "Provides commands to inspect the cloud-init data templates and their clones receive.",
+}
+
+// cloudInitPreviewCommand represents the cloudinit preview command.
+var cloudInitPreviewCommand = &cobra.Command{
+	Use:   "preview",
+	Short: "Renders the effective cloud-init data",
+	Long:  "Renders the user-data, network-config and meta-data a clone will receive based on the configuration file (merged with custom snippets) and optionally compares it with an existing template.",
+	Run: func(cmd *cobra.Command, args []string) {
+		initializeConfiguration()
+		configuration := getConfiguration()
+
+		if !configuration.GetCloudInit().GetEnabled() {
+			printAndErrorOut("cloud-init is not enabled in the configuration file")
+		}
+
+		if previewType != "" && !utils.SliceContains(ci.GetPreviewTypes(), previewType) {
+			printAndErrorOut(fmt.Sprintf("invalid cloud-init data type `%s`, supported types: %s", previewType, strings.Join(ci.GetPreviewTypes(), ", ")))
+		}
+
+		preview, err := createCloudInitPreview(configuration)
+		if err != nil {
+			printAndErrorOut(err.Error())
+		}
+
+		if previewCompare != 0 {
+			ensureRoot()
+			if !ensurePackageAvailable("proxmox-ve") {
+				printAndErrorOut("comparing with an existing template is only supported on Proxmox VE")
+			}
+
+			if err := compareCloudInitPreview(preview, previewCompare); err != nil {
+				printAndErrorOut(err.Error())
+			}
+			return
+		}
+
+		for _, dataType := range ci.GetPreviewTypes() {
+			content, ok := preview[dataType]
+			if !ok || !isPreviewTypeSelected(dataType) {
+				continue
+			}
+
+			fmt.Printf("### %s ###\n", cloudInitDataNames[dataType])
+			fmt.Println(strings.TrimRight(content, "\n"))
+			fmt.Println()
+		}
+	},
+}
+
+// createCloudInitPreview renders the cloud-init data from the configuration file.
+func createCloudInitPreview(configuration *config.Configuration) (map[string]string, error) {
+	cloudInitConfiguration, err := createCloudInitConfigurationFromConfigurationFile(configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := cloudInitConfiguration.IsConfigurationValid(); err != nil {
+		return nil, err
+	}
+
+	network := configuration.GetCloudInit().GetNetwork()
+	if cloudInitConfiguration.GetCustomSnippet(ci.SnippetTypeNetwork) == "" && network != nil && network.HasAdvancedConfiguration() {
+		content, err := nc.BuildNetworkConfig(network)
+		if err != nil {
+			return nil, err
+		}
+		cloudInitConfiguration.SetCustomSnippet(ci.SnippetTypeNetwork, content)
+	}
+
+	if err := cloudInitConfiguration.ApplyUsersVendorData(); err != nil {
+		return nil, err
+	}
+
+	hostname := previewName
+	if hostname == "" {
+		hostname = configuration.GetQemu().GetName()
+	}
+	if hostname == "" && previewCompare != 0 {
+		// Proxmox VE uses `VM<identifier>` as hostname for virtual machines without a name.
+		hostname = fmt.Sprintf("VM%d", previewCompare)
+	}
+	if hostname == "" {
+		return nil, fmt.Errorf("hostname of the clone is not set, pass --name or set `qemu.name` in the configuration file")
+	}
+
+	return cloudInitConfiguration.Preview(hostname)
+}
+
+// compareCloudInitPreview compares the rendered cloud-init data with the data Proxmox VE generates for an existing template.
+func compareCloudInitPreview(preview map[string]string, identifier int) error {
+	differences := 0
+
+	// NOTE: `qm cloudinit dump` does not support vendor-data, so it is not compared.
+	for _, dataType := range []string{ci.SnippetTypeUser, ci.SnippetTypeNetwork, ci.SnippetTypeMeta} {
+		if !isPreviewTypeSelected(dataType) {
+			continue
+		}
+
+		dumped, err := qemu.DumpCloudInit(identifier, dataType)
+		if err != nil {
+			return err
+		}
+
+		diff := ci.DiffData(preview[dataType], dumped)
+		if diff == "" {
+			fmt.Printf("### %s: no differences ###\n", cloudInitDataNames[dataType])
+			continue
+		}
+
+		differences++
+		fmt.Printf("### %s (-template %d +preview) ###\n", cloudInitDataNames[dataType], identifier)
+		fmt.Println(diff)
+	}
+
+	if differences > 0 {
+		return fmt.Errorf("cloud-init data differs from template %d in %d file(s)", identifier, differences)
+	}
+
+	return nil
+}
+
+// isPreviewTypeSelected returns true if the data type should be printed or compared.
+func isPreviewTypeSelected(dataType string) bool {
+	return previewType == "" || previewType == dataType
+}
+
+var (
+	// previewName is a string that is used as the hostname of the clone.
+	previewName string
+	// previewCompare is an integer that is used as the identifier of the template to compare the preview with.
+	previewCompare int
+	// previewType is a string that is used to limit the preview to a single data type.
+	previewType string
+)
+
+// init initializes the cloudinit command.
+func init() {
+	rootCmd.AddCommand(cloudInitCommand)
+	cloudInitCommand.AddCommand(cloudInitPreviewCommand)
+
+	cloudInitPreviewCommand.Flags().StringVar(&previewName, "name", "", "Hostname of the clone (name of the template from the configuration file if empty)")
+	cloudInitPreviewCommand.Flags().IntVar(&previewCompare, "compare", 0, "Identifier of an existing template to compare the preview with (qm cloudinit dump)")
+	cloudInitPreviewCommand.Flags().StringVar(&previewType, "type", "", "Render only the given data type (user / network / meta / vendor)")
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"github.com/darki73/ptm/pkg/customizer"
 	"github.com/darki73/ptm/pkg/downloader"
+	"github.com/darki73/ptm/pkg/qemu"
 	"github.com/spf13/cobra"
 )
 
@@ -29,14 +30,27 @@ var customizeCommand = &cobra.Command{
 			printAndErrorOut(err.Error())
 		}
 
-		handler := customizer.NewCustomizer(getConfiguration())
+		if customizeWatchdogModel != "" {
+			if err := qemu.ValidateWatchdogModel(customizeWatchdogModel); err != nil {
+				printAndErrorOut(err.Error())
+			}
+		}
+
+		handler := customizer.NewCustomizer(getConfiguration()).SetWatchdogModel(customizeWatchdogModel)
 		if err := handler.Run(); err != nil {
 			printAndErrorOut(err.Error())
 		}
 	},
 }
 
+var (
+	// customizeWatchdogModel is a string that is used to enable the watchdog daemon for the watchdog device model.
+	customizeWatchdogModel string
+)
+
 // init initializes the customize command.
 func init() {
 	rootCmd.AddCommand(customizeCommand)
+
+	customizeCommand.Flags().StringVar(&customizeWatchdogModel, "watchdog-model", "", "Enable the watchdog daemon for the watchdog device model (i6300esb / ib700)")
 }
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	"github.com/darki73/ptm/pkg/distributions"
+	"github.com/spf13/cobra"
+)
+
+// imagesCommand represents the images command.
+var imagesCommand = &cobra.Command{
+	Use:   "images",
+	Short: "Inspects base images published by distributions",
+	Long:  "Provides commands to inspect the base images distributions publish.",
+}
+
+// imagesSerialsCommand represents the images serials command.
+var imagesSerialsCommand = &cobra.Command{
+	Use:   "serials <distribution> <release>",
+	Short: "Lists the dated serials of a release",
+	Long:  "Lists the dated serials published for the release of the distribution (oldest first), any of them can be pinned with `base_image.serial`. Releases added to built-in distributions in the configuration file can be listed as well.",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		configuration := getConfiguration()
+
+		baseImage := bi.InitializeWithDefaults()
+		baseImage.Distribution = args[0]
+		baseImage.Release = args[1]
+		baseImage.Minimal = serialsMinimal
+		baseImage.Variant = serialsVariant
+		baseImage.Catalog = configuration.GetBaseImage().GetCatalog()
+
+		distros, err := distributions.NewDistributions(baseImage, configuration.GetDistributions())
+		if err != nil {
+			printAndErrorOut(err.Error())
+		}
+
+		serials, err := distributions.FetchSerials(distros.GetActiveDistribution())
+		if err != nil {
+			printAndErrorOut(fmt.Sprintf("failed to list serials of `%s %s`: %s", args[0], args[1], err))
+		}
+
+		for index, serial := range serials {
+			if index == len(serials)-1 {
+				fmt.Printf("%s (latest)\n", serial)
+				continue
+			}
+			fmt.Println(serial)
+		}
+	},
+}
+
+var (
+	// serialsMinimal is a flag that indicates whether the serials of the minimal images should be listed.
+	serialsMinimal bool
+	// serialsVariant is a string that is used to select the variant of the images the serials should be listed for.
+	serialsVariant string
+)
+
+// init initializes the images command.
+func init() {
+	rootCmd.AddCommand(imagesCommand)
+	imagesCommand.AddCommand(imagesSerialsCommand)
+
+	imagesSerialsCommand.Flags().BoolVar(&serialsMinimal, "minimal", false, "List the serials of the minimal images")
+	imagesSerialsCommand.Flags().StringVar(&serialsVariant, "variant", "", "List the serials of the images of the given variant")
+}
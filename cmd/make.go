@@ -66,6 +66,33 @@ func createQemuConfigurationFromFlags() (*qemu.Qemu, error) {
 	}
 
 	qemuConfiguration.SetCpuType(cpuType)
+	qemuConfiguration.SetSockets(sockets)
+	qemuConfiguration.SetVCpus(vcpus)
+	qemuConfiguration.SetNuma(numa)
+	qemuConfiguration.SetCpuFlags(cpuFlags)
+	qemuConfiguration.SetCpuLimit(cpuLimit)
+	qemuConfiguration.SetCpuUnits(cpuUnits)
+	qemuConfiguration.SetAffinity(affinity)
+	qemuConfiguration.SetBallooning(ballooning)
+	if minimumMemory != "" {
+		minimumMemoryValue, err := utils.ConvertToMegabytes(minimumMemory)
+		if err != nil {
+			return nil, err
+		}
+		qemuConfiguration.SetMinimumMemory(int(minimumMemoryValue))
+	}
+	qemuConfiguration.SetHugepages(hugepages)
+	qemuConfiguration.SetHotplug(hotplug)
+	qemuConfiguration.SetRngSource(rngSource)
+	qemuConfiguration.SetRngMaxBytes(rngMaxBytes)
+	qemuConfiguration.SetRngPeriod(rngPeriod)
+	qemuConfiguration.SetWatchdogModel(watchdogModel)
+	qemuConfiguration.SetWatchdogAction(watchdogAction)
+	qemuConfiguration.SetArchitecture(architecture)
+	qemuConfiguration.SetGuestAgentEnabled(guestAgent)
+	qemuConfiguration.SetGuestAgentTrim(guestAgentFstrim)
+	qemuConfiguration.SetGuestAgentFreezeFs(guestAgentFreezeFs)
+	qemuConfiguration.SetGuestAgentType(guestAgentType)
 	qemuConfiguration.SetNetworkDriver(networkDriver)
 	qemuConfiguration.SetNetworkBridge(networkBridge)
 	qemuConfiguration.SetStorage(storage)
@@ -73,20 +100,34 @@ func createQemuConfigurationFromFlags() (*qemu.Qemu, error) {
 	qemuConfiguration.SetNewImageSizeAsString(imageNewSize)
 	qemuConfiguration.SetConfigurationSource(qemu.ConfigurationSourceFlags)
 
+	password, err := ci.ResolvePassword(ciPassword, ciPasswordFile, ciPasswordEnv)
+	if err != nil {
+		return nil, err
+	}
+
 	cloudInitConfiguration := ci.NewCloudInitConfiguration()
 	cloudInitConfiguration.SetUsername(ciUsername)
-	cloudInitConfiguration.SetPassword(ciPassword)
+	if err := cloudInitConfiguration.SetPassword(password); err != nil {
+		return nil, err
+	}
 	cloudInitConfiguration.SetKeys(ciSSHKeys)
-	cloudInitConfiguration.SetIPv4(ciIPv4Address)
-	cloudInitConfiguration.SetIPv4Gateway(ciIPv4Gateway)
-	cloudInitConfiguration.SetIPv6(ciIPv6Address)
-	cloudInitConfiguration.SetIPv6Gateway(ciIPv6Gateway)
-	if ciIPv4Auto {
-		cloudInitConfiguration.AutoConfigureIPv4()
+	if err := cloudInitConfiguration.ConfigureIPv4(ciIPv4Mode, ciIPv4Address, ciIPv4Gateway, ciIPv4Auto); err != nil {
+		return nil, err
 	}
-	if ciIPv6Auto {
-		cloudInitConfiguration.AutoConfigureIPv6()
+	if err := cloudInitConfiguration.ConfigureIPv6(ciIPv6Mode, ciIPv6Address, ciIPv6Gateway, ciIPv6Auto); err != nil {
+		return nil, err
 	}
+	cloudInitConfiguration.SetNameservers(ciNameservers)
+	cloudInitConfiguration.SetSearchDomains(ciSearchDomains)
+	cloudInitConfiguration.SetCustomSnippet(ci.SnippetTypeUser, ciUserData)
+	cloudInitConfiguration.SetCustomSnippet(ci.SnippetTypeVendor, ciVendorData)
+	cloudInitConfiguration.SetCustomSnippet(ci.SnippetTypeNetwork, ciNetworkData)
+	cloudInitConfiguration.SetCustomSnippet(ci.SnippetTypeMeta, ciMetaData)
+	cloudInitConfiguration.SetSnippetsStorage(ciSnippetsStorage)
+	cloudInitConfiguration.SetDriveType(ciDriveType)
+	cloudInitConfiguration.SetDrive(ciDrive)
+	cloudInitConfiguration.SetUpgrade(ciUpgrade)
+	cloudInitConfiguration.SetRegenerate(ciRegenerate)
 	cloudInitConfiguration.SetConfigurationSource(ci.ConfigurationSourceFlags)
 
 	qemuConfiguration.SetCloudInit(cloudInitConfiguration)
@@ -121,42 +162,58 @@ func createQemuConfigurationFromConfigurationFile(configuration *config.Configur
 	qemuConfiguration.SetNewImageSizeAsString(qc.GetStorage().GetResize())
 	qemuConfiguration.SetMemory(int(memory))
 	qemuConfiguration.SetCpuType(resources.GetCpuType())
+	qemuConfiguration.SetSockets(resources.GetSockets())
+	qemuConfiguration.SetVCpus(resources.GetVCpus())
+	qemuConfiguration.SetNuma(resources.GetNuma())
+	qemuConfiguration.SetCpuFlags(resources.GetCpuFlags())
+	qemuConfiguration.SetCpuLimit(resources.GetCpuLimit())
+	qemuConfiguration.SetCpuUnits(resources.GetCpuUnits())
+	qemuConfiguration.SetAffinity(resources.GetAffinity())
+	qemuConfiguration.SetBallooning(resources.GetBallooning())
+	minimumMemory, err := resources.GetMinimumMemory()
+	if err != nil {
+		return nil, err
+	}
+	qemuConfiguration.SetMinimumMemory(int(minimumMemory))
+	qemuConfiguration.SetHugepages(resources.GetHugepages())
+	qemuConfiguration.SetHotplug(resources.GetHotplug())
+
+	rng := qc.GetRng()
+	if rng != nil && rng.GetEnabled() {
+		qemuConfiguration.SetRngSource(rng.GetSource())
+		qemuConfiguration.SetRngMaxBytes(rng.GetMaxBytes())
+		qemuConfiguration.SetRngPeriod(rng.GetPeriod())
+	}
+
+	guestAgentConfiguration := qc.GetGuestAgent()
+	if guestAgentConfiguration != nil {
+		qemuConfiguration.SetGuestAgentEnabled(guestAgentConfiguration.GetEnabled())
+		qemuConfiguration.SetGuestAgentTrim(guestAgentConfiguration.GetFstrimClonedDisks())
+		qemuConfiguration.SetGuestAgentFreezeFs(guestAgentConfiguration.GetFreezeFsOnBackup())
+		qemuConfiguration.SetGuestAgentType(guestAgentConfiguration.GetType())
+	}
+
+	watchdog := qc.GetWatchdog()
+	if watchdog != nil && watchdog.GetEnabled() {
+		qemuConfiguration.SetWatchdogModel(watchdog.GetModel())
+		qemuConfiguration.SetWatchdogAction(watchdog.GetAction())
+	}
+
+	if qc.GetArchitecture() != "" {
+		qemuConfiguration.SetArchitecture(qc.GetArchitecture())
+	} else {
+		qemuConfiguration.SetArchitecture(configuration.GetBaseImage().GetArchitecture())
+	}
+
 	qemuConfiguration.SetNetworkDriver(qc.GetNetwork().GetDriver())
 	qemuConfiguration.SetNetworkBridge(qc.GetNetwork().GetBridge())
 	qemuConfiguration.SetConfigurationSource(qemu.ConfigurationSourceConfigurationFile)
 
-	cic := configuration.GetCloudInit()
-
-	if cic.GetEnabled() {
-		cloudInitConfiguration := ci.NewCloudInitConfiguration()
-		cloudInitConfiguration.SetUsername(cic.GetUsername())
-		cloudInitConfiguration.SetPassword(cic.GetPassword())
-		cloudInitConfiguration.SetKeys(cic.GetKeys())
-
-		cicNetwork := cic.GetNetwork()
-		if cicNetwork != nil {
-			ipv4 := cicNetwork.GetIPv4()
-			if ipv4 != nil {
-				if ipv4.GetAutoConfigure() {
-					cloudInitConfiguration.AutoConfigureIPv4()
-				} else {
-					cloudInitConfiguration.SetIPv4(ipv4.GetAddress())
-					cloudInitConfiguration.SetIPv4Gateway(ipv4.GetGateway())
-				}
-			}
-
-			ipv6 := cicNetwork.GetIPv6()
-			if ipv6 != nil {
-				if ipv6.GetAutoConfigure() {
-					cloudInitConfiguration.AutoConfigureIPv6()
-				} else {
-					cloudInitConfiguration.SetIPv6(ipv6.GetAddress())
-					cloudInitConfiguration.SetIPv6Gateway(ipv6.GetGateway())
-				}
-			}
+	if configuration.GetCloudInit().GetEnabled() {
+		cloudInitConfiguration, err := createCloudInitConfigurationFromConfigurationFile(configuration)
+		if err != nil {
+			return nil, err
 		}
-
-		cloudInitConfiguration.SetConfigurationSource(ci.ConfigurationSourceConfigurationFile)
 		qemuConfiguration.SetCloudInit(cloudInitConfiguration)
 	}
 
@@ -171,6 +228,61 @@ func createQemuConfigurationFromConfigurationFile(configuration *config.Configur
 	return qemuConfiguration, nil
 }
 
+// createCloudInitConfigurationFromConfigurationFile creates a cloud-init configuration from the configuration file.
+func createCloudInitConfigurationFromConfigurationFile(configuration *config.Configuration) (*ci.CloudInit, error) {
+	cic := configuration.GetCloudInit()
+
+	password, err := ci.ResolvePassword(cic.GetPassword(), cic.GetPasswordFile(), cic.GetPasswordEnv())
+	if err != nil {
+		return nil, err
+	}
+
+	cloudInitConfiguration := ci.NewCloudInitConfiguration()
+	cloudInitConfiguration.SetUsername(cic.GetUsername())
+	if err := cloudInitConfiguration.SetPassword(password); err != nil {
+		return nil, err
+	}
+	cloudInitConfiguration.SetKeys(cic.GetKeys())
+	cloudInitConfiguration.SetUsers(ci.NewUsersFromConfiguration(cic.GetUsers()))
+
+	cicNetwork := cic.GetNetwork()
+	if cicNetwork != nil {
+		ipv4 := cicNetwork.GetIPv4()
+		if ipv4 != nil {
+			if err := cloudInitConfiguration.ConfigureIPv4(ipv4.GetMode(), ipv4.GetAddress(), ipv4.GetGateway(), ipv4.GetAutoConfigure()); err != nil {
+				return nil, err
+			}
+		}
+
+		ipv6 := cicNetwork.GetIPv6()
+		if ipv6 != nil {
+			if err := cloudInitConfiguration.ConfigureIPv6(ipv6.GetMode(), ipv6.GetAddress(), ipv6.GetGateway(), ipv6.GetAutoConfigure()); err != nil {
+				return nil, err
+			}
+		}
+
+		dns := cicNetwork.GetDns()
+		if dns != nil {
+			cloudInitConfiguration.SetNameservers(dns.GetNameservers())
+			cloudInitConfiguration.SetSearchDomains(dns.GetSearchDomains())
+		}
+	}
+
+	cloudInitConfiguration.SetCustomSnippet(ci.SnippetTypeUser, cic.GetUserData())
+	cloudInitConfiguration.SetCustomSnippet(ci.SnippetTypeVendor, cic.GetVendorData())
+	cloudInitConfiguration.SetCustomSnippet(ci.SnippetTypeNetwork, cic.GetNetworkData())
+	cloudInitConfiguration.SetCustomSnippet(ci.SnippetTypeMeta, cic.GetMetaData())
+	cloudInitConfiguration.SetSnippetsStorage(cic.GetSnippetsStorage())
+	cloudInitConfiguration.SetDriveType(cic.GetDriveType())
+	cloudInitConfiguration.SetDrive(cic.GetDrive())
+	cloudInitConfiguration.SetUpgrade(cic.GetUpgrade())
+	cloudInitConfiguration.SetRegenerate(cic.GetRegenerate())
+
+	cloudInitConfiguration.SetConfigurationSource(ci.ConfigurationSourceConfigurationFile)
+
+	return cloudInitConfiguration, nil
+}
+
 var (
 	// identifier is an integer that is used as an identifier for the virtual machine template.
 	identifier int
@@ -182,6 +294,48 @@ var (
 	cpuType string
 	// memory is an integer that is used as the amount of memory to allocate to the virtual machine template.
 	memory string
+	// sockets is an integer that is used as the number of cpu sockets to allocate to the virtual machine template.
+	sockets int
+	// vcpus is an integer that is used as the number of hotplugged vcpus the virtual machine template starts with.
+	vcpus int
+	// numa is a flag that indicates whether NUMA should be enabled for the virtual machine template.
+	numa bool
+	// cpuFlags is a list of cpu flags (+aes / -pcid / etc) for the virtual machine template.
+	cpuFlags []string
+	// cpuLimit is a number that is used to limit the cpu usage of the virtual machine template.
+	cpuLimit float64
+	// cpuUnits is an integer that is used as the cpu weight of the virtual machine template.
+	cpuUnits int
+	// affinity is a string that is used to define the host cpus the virtual machine template is allowed to run on.
+	affinity string
+	// ballooning is a flag that indicates whether the memory balloon device should be enabled.
+	ballooning bool
+	// minimumMemory is a string that is used as the minimum amount of memory for the balloon device.
+	minimumMemory string
+	// hugepages is a string that is used to define the hugepages size for the virtual machine template.
+	hugepages string
+	// hotplug is a list of devices that can be hotplugged into the virtual machine template.
+	hotplug []string
+	// rngSource is a string that is used to define the entropy source for the VirtIO RNG device.
+	rngSource string
+	// rngMaxBytes is an integer that is used as the maximum bytes of entropy injected into the guest every period.
+	rngMaxBytes int
+	// rngPeriod is an integer that is used as the period (in milliseconds) for the rng max bytes limit.
+	rngPeriod int
+	// watchdogModel is a string that is used to define the watchdog device model.
+	watchdogModel string
+	// watchdogAction is a string that is used to define the action performed when the watchdog fires.
+	watchdogAction string
+	// architecture is a string that is used to define the architecture of the virtual machine template.
+	architecture string
+	// guestAgent is a flag that indicates whether the QEMU guest agent should be enabled.
+	guestAgent bool
+	// guestAgentFstrim is a flag that indicates whether fstrim should be run on cloned disks.
+	guestAgentFstrim bool
+	// guestAgentFreezeFs is a flag that indicates whether guest filesystems should be frozen during backups.
+	guestAgentFreezeFs bool
+	// guestAgentType is a string that is used to define the guest agent communication channel type.
+	guestAgentType string
 	// storage is a string that is used to define the storage used for the virtual machine template.
 	storage string
 	// image is a string that is used to define the path to the image used for the virtual machine template creation.
@@ -196,8 +350,16 @@ var (
 	ciUsername string
 	// ciPassword is a string that is used to define the cloud-init password for the virtual machine template.
 	ciPassword string
+	// ciPasswordFile is a string that is used to define the path to the file with the cloud-init password.
+	ciPasswordFile string
+	// ciPasswordEnv is a string that is used to define the environment variable with the cloud-init password.
+	ciPasswordEnv string
 	// ciSSHKeys is a string that is used to define the cloud-init SSH keys for the virtual machine template.
 	ciSSHKeys []string
+	// ciIPv4Mode is a string that contains the IPv4 address mode.
+	ciIPv4Mode string
+	// ciIPv6Mode is a string that contains the IPv6 address mode.
+	ciIPv6Mode string
 	// ciIPv4Auto is a flag that indicates whether IPv4 autoconfiguration is enabled.
 	ciIPv4Auto bool
 	// ciIPv6Auto is a flag that indicates whether IPv6 autoconfiguration is enabled.
@@ -210,6 +372,28 @@ var (
 	ciIPv4Gateway string
 	// ciIPv6Gateway is a string that contains the IPv6 gateway.
 	ciIPv6Gateway string
+	// ciNameservers is a list of DNS servers for cloud-init.
+	ciNameservers []string
+	// ciSearchDomains is a list of DNS search domains for cloud-init.
+	ciSearchDomains []string
+	// ciUserData is a string that contains the inline content or file path of the custom user-data snippet.
+	ciUserData string
+	// ciVendorData is a string that contains the inline content or file path of the custom vendor-data snippet.
+	ciVendorData string
+	// ciNetworkData is a string that contains the inline content or file path of the custom network-config snippet.
+	ciNetworkData string
+	// ciMetaData is a string that contains the inline content or file path of the custom meta-data snippet.
+	ciMetaData string
+	// ciSnippetsStorage is a string that contains the name of the storage custom snippets are uploaded to.
+	ciSnippetsStorage string
+	// ciDriveType is a string that contains the type of the cloud-init drive.
+	ciDriveType string
+	// ciDrive is a string that contains the bus and slot the cloud-init drive is attached to.
+	ciDrive string
+	// ciUpgrade is a flag that indicates whether cloud-init should upgrade packages on the first boot.
+	ciUpgrade bool
+	// ciRegenerate is a flag that indicates whether the cloud-init drive should be regenerated after changes.
+	ciRegenerate bool
 )
 
 // init initializes the make command.
@@ -221,18 +405,54 @@ func init() {
 	makeCommand.Flags().IntVar(&cores, "cores", 0, "Number of cpu cores")
 	makeCommand.Flags().StringVar(&cpuType, "cpu-type", "", "Desired cpu type (host / kvm64 / etc)")
 	makeCommand.Flags().StringVar(&memory, "memory", "", "Amount of memory (example: 1024 / 1024M / 1G)")
+	makeCommand.Flags().IntVar(&sockets, "sockets", 0, "Number of cpu sockets")
+	makeCommand.Flags().IntVar(&vcpus, "vcpus", 0, "Number of hotplugged vcpus to start with")
+	makeCommand.Flags().BoolVar(&numa, "numa", false, "Enable NUMA")
+	makeCommand.Flags().StringSliceVar(&cpuFlags, "cpu-flags", []string{}, "List of cpu flags, separated by semicolons or commas (example: +aes;+pdpe1gb)")
+	makeCommand.Flags().Float64Var(&cpuLimit, "cpu-limit", 0, "Limit of cpu usage (example: 1.5)")
+	makeCommand.Flags().IntVar(&cpuUnits, "cpu-units", 0, "Cpu weight of the virtual machine")
+	makeCommand.Flags().StringVar(&affinity, "affinity", "", "Host cpus the virtual machine is allowed to run on (example: 0-3,8)")
+	makeCommand.Flags().BoolVar(&ballooning, "ballooning", true, "Enable memory balloon device")
+	makeCommand.Flags().StringVar(&minimumMemory, "minimum-memory", "", "Minimum amount of memory for the balloon device (example: 1G)")
+	makeCommand.Flags().StringVar(&hugepages, "hugepages", "", "Hugepages size (any / 2 / 1024)")
+	makeCommand.Flags().StringSliceVar(&hotplug, "hotplug", []string{}, "Comma-separated list of hotpluggable devices (disk / network / usb / memory / cpu / cloudinit)")
+	makeCommand.Flags().StringVar(&rngSource, "rng-source", "", "Entropy source for the VirtIO RNG device (/dev/urandom / /dev/random / /dev/hwrng)")
+	makeCommand.Flags().IntVar(&rngMaxBytes, "rng-max-bytes", 1024, "Maximum bytes of entropy injected into the guest every period")
+	makeCommand.Flags().IntVar(&rngPeriod, "rng-period", 1000, "Period (in milliseconds) for the rng max bytes limit")
+	makeCommand.Flags().StringVar(&watchdogModel, "watchdog-model", "", "Watchdog device model (i6300esb / ib700), the watchdog daemon is enabled in the image by customize")
+	makeCommand.Flags().StringVar(&watchdogAction, "watchdog-action", "reset", "Watchdog action (reset / shutdown / poweroff / pause / debug / none)")
+	makeCommand.Flags().StringVar(&architecture, "architecture", "", "Architecture of the virtual machine (amd64 / arm64), detected from the image when empty")
+	makeCommand.Flags().BoolVar(&guestAgent, "guest-agent", true, "Enable QEMU guest agent")
+	makeCommand.Flags().BoolVar(&guestAgentFstrim, "guest-agent-fstrim", true, "Run fstrim after moving a disk or migrating the virtual machine")
+	makeCommand.Flags().BoolVar(&guestAgentFreezeFs, "guest-agent-freeze-fs", true, "Freeze guest filesystems during backups")
+	makeCommand.Flags().StringVar(&guestAgentType, "guest-agent-type", "virtio", "Guest agent communication channel type (virtio / isa)")
 	makeCommand.Flags().StringVar(&storage, "storage", "", "Disk storage (local-lvm / local / etc)")
 	makeCommand.Flags().StringVar(&image, "image", "", "Path to the image (/etc/ptm/images/image.qcow2)")
 	makeCommand.Flags().StringVar(&imageNewSize, "image-new-size", "", "Size to which the image should be resized (example: 4G)")
 	makeCommand.Flags().StringVar(&networkDriver, "network-driver", "", "Network driver (virtio / e1000 / etc)")
 	makeCommand.Flags().StringVar(&networkBridge, "network-bridge", "", "Network bridge (vmbr0 / vmbr1 / etc)")
 	makeCommand.Flags().StringVar(&ciUsername, "ci-username", "", "Username for cloud-init")
-	makeCommand.Flags().StringVar(&ciPassword, "ci-password", "", "Password for cloud-init")
+	makeCommand.Flags().StringVar(&ciPassword, "ci-password", "", "Password (plain text or $6$ hash) for cloud-init, prefer --ci-password-file or --ci-password-env")
+	makeCommand.Flags().StringVar(&ciPasswordFile, "ci-password-file", "", "Path to the file with the password for cloud-init")
+	makeCommand.Flags().StringVar(&ciPasswordEnv, "ci-password-env", "", "Name of the environment variable with the password for cloud-init")
 	makeCommand.Flags().StringArrayVar(&ciSSHKeys, "ci-ssh-keys", []string{}, "Comma-separated list of SSH keys for cloud-init")
-	makeCommand.Flags().BoolVar(&ciIPv4Auto, "ci-ipv4-auto", true, "Automatically configure IPv4 for cloud-init")
-	makeCommand.Flags().BoolVar(&ciIPv6Auto, "ci-ipv6-auto", true, "Automatically configure IPv6 for cloud-init")
+	makeCommand.Flags().StringVar(&ciIPv4Mode, "ci-ipv4-mode", "", "IPv4 address mode for cloud-init (static / dhcp / none), takes precedence over --ci-ipv4-auto")
+	makeCommand.Flags().StringVar(&ciIPv6Mode, "ci-ipv6-mode", "", "IPv6 address mode for cloud-init (static / dhcpv6 / slaac / none), takes precedence over --ci-ipv6-auto")
+	makeCommand.Flags().BoolVar(&ciIPv4Auto, "ci-ipv4-auto", true, "Automatically configure IPv4 for cloud-init using DHCP (ignored when an IPv4 address is set)")
+	makeCommand.Flags().BoolVar(&ciIPv6Auto, "ci-ipv6-auto", true, "Automatically configure IPv6 for cloud-init using SLAAC (ignored when an IPv6 address is set)")
 	makeCommand.Flags().StringVar(&ciIPv4Address, "ci-ipv4-address", "", "Manually set IPv4 address for cloud-init (example: 10.10.10.10/24)")
 	makeCommand.Flags().StringVar(&ciIPv6Address, "ci-ipv6-address", "", "Manually set IPv6 address for cloud-init (example: 2001:db8::1/64)")
 	makeCommand.Flags().StringVar(&ciIPv4Gateway, "ci-ipv4-gateway", "", "Manually set IPv4 gateway for cloud-init (example: 10.10.10.1)")
 	makeCommand.Flags().StringVar(&ciIPv6Gateway, "ci-ipv6-gateway", "", "Manually set IPv6 gateway for cloud-init (example: 2001:db8::1)")
+	makeCommand.Flags().StringSliceVar(&ciNameservers, "ci-nameservers", []string{}, "Comma-separated list of DNS servers for cloud-init (example: 1.1.1.1,2606:4700:4700::1111)")
+	makeCommand.Flags().StringSliceVar(&ciSearchDomains, "ci-search-domains", []string{}, "Comma-separated list of DNS search domains for cloud-init (example: example.com)")
+	makeCommand.Flags().StringVar(&ciUserData, "ci-user-data", "", "Custom cloud-init user-data (inline YAML or path to a file)")
+	makeCommand.Flags().StringVar(&ciVendorData, "ci-vendor-data", "", "Custom cloud-init vendor-data (inline YAML or path to a file)")
+	makeCommand.Flags().StringVar(&ciNetworkData, "ci-network-data", "", "Custom cloud-init network-config (inline YAML or path to a file)")
+	makeCommand.Flags().StringVar(&ciMetaData, "ci-meta-data", "", "Custom cloud-init meta-data (inline YAML or path to a file)")
+	makeCommand.Flags().StringVar(&ciSnippetsStorage, "ci-snippets-storage", "", "Storage with snippets content for custom cloud-init data (first available if empty)")
+	makeCommand.Flags().StringVar(&ciDriveType, "ci-drive-type", "", "Cloud-init drive type (nocloud / configdrive2), Proxmox VE default if empty")
+	makeCommand.Flags().StringVar(&ciDrive, "ci-drive", "", "Bus and slot of the cloud-init drive (example: ide0 / sata0 / scsi1), depends on architecture if empty")
+	makeCommand.Flags().BoolVar(&ciUpgrade, "ci-upgrade", true, "Upgrade packages on the first boot")
+	makeCommand.Flags().BoolVar(&ciRegenerate, "ci-regenerate", true, "Regenerate the cloud-init drive (qm cloudinit update) after changes")
 }
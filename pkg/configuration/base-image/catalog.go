@@ -0,0 +1,49 @@
+package base_image
+
+import (
+	"fmt"
+	"time"
+)
+
+// Catalog is a struct that represents the release catalog discovered from the indexes of the distributions.
+type Catalog struct {
+	// Enabled is a boolean value that indicates if releases are discovered from the indexes of the distributions.
+	Enabled bool `json:"enabled" yaml:"enabled" toml:"enabled" mapstructure:"enabled"`
+	// CacheDirectory is the directory where fetched indexes are cached.
+	CacheDirectory string `json:"cache_directory" yaml:"cache_directory" toml:"cache_directory" mapstructure:"cache_directory"`
+	// CacheTtl is the duration fetched indexes are cached for (for example, 24h).
+	CacheTtl string `json:"cache_ttl" yaml:"cache_ttl" toml:"cache_ttl" mapstructure:"cache_ttl"`
+}
+
+// InitializeCatalogWithDefaults initializes a Catalog struct with default values.
+func InitializeCatalogWithDefaults() *Catalog {
+	return &Catalog{
+		Enabled:        true,
+		CacheDirectory: "/var/cache/ptm/catalogs",
+		CacheTtl:       "24h",
+	}
+}
+
+// GetEnabled returns the Enabled field value.
+func (catalog *Catalog) GetEnabled() bool {
+	return catalog.Enabled
+}
+
+// GetCacheDirectory returns the CacheDirectory field value.
+func (catalog *Catalog) GetCacheDirectory() string {
+	return catalog.CacheDirectory
+}
+
+// GetCacheTtl returns the CacheTtl field value as a duration.
+func (catalog *Catalog) GetCacheTtl() (time.Duration, error) {
+	return time.ParseDuration(catalog.CacheTtl)
+}
+
+// Validate returns an error if the release catalog configuration is invalid.
+func (catalog *Catalog) Validate() error {
+	if _, err := catalog.GetCacheTtl(); err != nil {
+		return fmt.Errorf("invalid `base_image.catalog.cache_ttl` value `%s`: %v", catalog.CacheTtl, err)
+	}
+
+	return nil
+}
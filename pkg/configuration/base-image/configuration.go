@@ -12,6 +12,12 @@ type Configuration struct {
 	Architecture string `json:"architecture" yaml:"architecture" toml:"architecture" mapstructure:"architecture"`
 	// Format is the name of the image format.
 	Format string `json:"format" yaml:"format" toml:"format" mapstructure:"format"`
+	// Variant is the name of the image variant (for example, minimal / genericcloud / nocloud), empty means the default variant.
+	Variant string `json:"variant" yaml:"variant" toml:"variant" mapstructure:"variant"`
+	// Serial is the dated serial of the image (for example, 20261001), empty means the latest serial.
+	Serial string `json:"serial" yaml:"serial" toml:"serial" mapstructure:"serial"`
+	// Catalog is a reference to the release catalog configuration.
+	Catalog *Catalog `json:"catalog" yaml:"catalog" toml:"catalog" mapstructure:"catalog"`
 }
 
 // InitializeWithDefaults initializes the base image configuration with default values.
@@ -22,6 +28,9 @@ func InitializeWithDefaults() *Configuration {
 		Minimal:      true,
 		Architecture: "amd64",
 		Format:       "img",
+		Variant:      "",
+		Serial:       "",
+		Catalog:      InitializeCatalogWithDefaults(),
 	}
 }
 
@@ -49,3 +58,27 @@ func (configuration *Configuration) GetArchitecture() string {
 func (configuration *Configuration) GetFormat() string {
 	return configuration.Format
 }
+
+// GetVariant returns the name of the image variant.
+func (configuration *Configuration) GetVariant() string {
+	return configuration.Variant
+}
+
+// GetSerial returns the dated serial of the image.
+func (configuration *Configuration) GetSerial() string {
+	return configuration.Serial
+}
+
+// GetCatalog returns the release catalog configuration.
+func (configuration *Configuration) GetCatalog() *Catalog {
+	return configuration.Catalog
+}
+
+// Validate returns an error if the base image configuration is invalid.
+func (configuration *Configuration) Validate() error {
+	if configuration.Catalog == nil {
+		return nil
+	}
+
+	return configuration.Catalog.Validate()
+}
@@ -2,6 +2,7 @@ package base_image
 
 import (
 	"testing"
+	"time"
 )
 
 // TestInitializeWithDefaults tests the initialization of the base image configuration with default values.
@@ -29,6 +30,9 @@ func TestInitializeWithDefaults(t *testing.T) {
 	if config.Format != expectedFormat {
 		t.Errorf("Expected Format %s, got %s", expectedFormat, config.Format)
 	}
+	if config.Catalog == nil || !config.Catalog.Enabled {
+		t.Errorf("Expected Catalog to be enabled, got %v", config.Catalog)
+	}
 }
 
 // TestConfigurationGetters tests the getters of the base image configuration.
@@ -39,6 +43,8 @@ func TestConfigurationGetters(t *testing.T) {
 		Minimal:      false,
 		Architecture: "arm",
 		Format:       "qcow2",
+		Variant:      "nocloud",
+		Serial:       "20240507-1740",
 	}
 
 	if config.GetDistribution() != config.Distribution {
@@ -56,4 +62,62 @@ func TestConfigurationGetters(t *testing.T) {
 	if config.GetFormat() != config.Format {
 		t.Errorf("GetFormat() = %s; want %s", config.GetFormat(), config.Format)
 	}
+	if config.GetVariant() != config.Variant {
+		t.Errorf("GetVariant() = %s; want %s", config.GetVariant(), config.Variant)
+	}
+	if config.GetSerial() != config.Serial {
+		t.Errorf("GetSerial() = %s; want %s", config.GetSerial(), config.Serial)
+	}
+}
+
+// TestCatalogGetters tests the getters of the release catalog configuration.
+func TestCatalogGetters(t *testing.T) {
+	catalog := InitializeCatalogWithDefaults()
+
+	if !catalog.GetEnabled() {
+		t.Errorf("GetEnabled() = %v; want true", catalog.GetEnabled())
+	}
+	if catalog.GetCacheDirectory() != "/var/cache/ptm/catalogs" {
+		t.Errorf("GetCacheDirectory() = %s; want /var/cache/ptm/catalogs", catalog.GetCacheDirectory())
+	}
+
+	ttl, err := catalog.GetCacheTtl()
+	if err != nil || ttl != 24*time.Hour {
+		t.Errorf("GetCacheTtl() = %v, %v; want 24h, nil", ttl, err)
+	}
+
+	catalog.CacheTtl = "forever"
+	if _, err := catalog.GetCacheTtl(); err == nil {
+		t.Error("GetCacheTtl() returned no error for an invalid duration")
+	}
+}
+
+// TestValidate tests the validation of the base image configuration.
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cacheTtl    string
+		catalog     bool
+		expectError bool
+	}{
+		{"default ttl", "24h", true, false},
+		{"minutes ttl", "90m", true, false},
+		{"invalid ttl", "forever", true, true},
+		{"empty ttl", "", true, true},
+		{"no catalog", "", false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := InitializeWithDefaults()
+			config.Catalog.CacheTtl = test.cacheTtl
+			if !test.catalog {
+				config.Catalog = nil
+			}
+
+			if err := config.Validate(); (err != nil) != test.expectError {
+				t.Errorf("Validate() = %v; want error: %v", err, test.expectError)
+			}
+		})
+	}
 }
@@ -6,22 +6,64 @@ type Configuration struct {
 	Enabled bool `json:"enabled" yaml:"enabled" toml:"enabled" mapstructure:"enabled"`
 	// Username is the username of the user that will be created by cloud-init.
 	Username string `json:"username" yaml:"username" toml:"username" mapstructure:"username"`
-	// Password is the password of the user that will be created by cloud-init.
+	// Password is the password (plain text or SHA-512 crypt hash) of the user that will be created by cloud-init.
 	Password string `json:"password" yaml:"password" toml:"password" mapstructure:"password"`
+	// PasswordFile is the path to the file with the password of the user that will be created by cloud-init.
+	PasswordFile string `json:"password_file" yaml:"password_file" toml:"password_file" mapstructure:"password_file"`
+	// PasswordEnv is the name of the environment variable with the password of the user that will be created by cloud-init.
+	PasswordEnv string `json:"password_env" yaml:"password_env" toml:"password_env" mapstructure:"password_env"`
 	// Keys is a list of SSH keys that will be added to the user that will be created by cloud-init.
 	Keys []string `json:"ssh_authorized_keys" yaml:"ssh_authorized_keys" toml:"ssh_authorized_keys" mapstructure:"ssh_authorized_keys"`
+	// Users is a list of additional users that will be created by cloud-init (rendered into custom vendor-data).
+	Users []*CloudInitUser `json:"users" yaml:"users" toml:"users" mapstructure:"users"`
+	// SshKeySources is a reference to the sources of SSH keys offered for the user.
+	SshKeySources *CloudInitSshKeySources `json:"ssh_key_sources" yaml:"ssh_key_sources" toml:"ssh_key_sources" mapstructure:"ssh_key_sources"`
 	// Network is a reference to the network configuration that will be created by cloud-init.
 	Network *CloudInitNetwork `json:"network" yaml:"network" toml:"network" mapstructure:"network"`
+	// UserData is the inline content or file path of the custom user-data snippet.
+	UserData string `json:"user_data" yaml:"user_data" toml:"user_data" mapstructure:"user_data"`
+	// VendorData is the inline content or file path of the custom vendor-data snippet.
+	VendorData string `json:"vendor_data" yaml:"vendor_data" toml:"vendor_data" mapstructure:"vendor_data"`
+	// NetworkData is the inline content or file path of the custom network-config snippet.
+	NetworkData string `json:"network_data" yaml:"network_data" toml:"network_data" mapstructure:"network_data"`
+	// MetaData is the inline content or file path of the custom meta-data snippet.
+	MetaData string `json:"meta_data" yaml:"meta_data" toml:"meta_data" mapstructure:"meta_data"`
+	// SnippetsStorage is the name of the storage custom snippets are uploaded to (first snippets storage if empty).
+	SnippetsStorage string `json:"snippets_storage" yaml:"snippets_storage" toml:"snippets_storage" mapstructure:"snippets_storage"`
+	// DriveType is the type of the cloud-init drive (nocloud / configdrive2, Proxmox VE default if empty).
+	DriveType string `json:"drive_type" yaml:"drive_type" toml:"drive_type" mapstructure:"drive_type"`
+	// Drive is the bus and slot the cloud-init drive is attached to (ide2 / scsi1 depending on architecture if empty).
+	Drive string `json:"drive" yaml:"drive" toml:"drive" mapstructure:"drive"`
+	// Upgrade is a flag that indicates whether cloud-init should upgrade packages on the first boot.
+	Upgrade bool `json:"upgrade" yaml:"upgrade" toml:"upgrade" mapstructure:"upgrade"`
+	// Regenerate is a flag that indicates whether the cloud-init drive should be regenerated after changes.
+	Regenerate bool `json:"regenerate" yaml:"regenerate" toml:"regenerate" mapstructure:"regenerate"`
+	// Image is a reference to the cloud-init tuning baked into the image by the customize command.
+	Image *CloudInitImage `json:"image" yaml:"image" toml:"image" mapstructure:"image"`
 }
 
 // InitializeWithDefaults initializes the configuration with default values.
 func InitializeWithDefaults() *Configuration {
 	return &Configuration{
-		Enabled:  false,
-		Username: "",
-		Password: "",
-		Keys:     []string{},
-		Network:  InitializeCloudInitNetworkWithDefaults(),
+		Enabled:         false,
+		Username:        "",
+		Password:        "",
+		PasswordFile:    "",
+		PasswordEnv:     "",
+		Keys:            []string{},
+		Users:           []*CloudInitUser{},
+		SshKeySources:   InitializeCloudInitSshKeySourcesWithDefaults(),
+		Network:         InitializeCloudInitNetworkWithDefaults(),
+		UserData:        "",
+		VendorData:      "",
+		NetworkData:     "",
+		MetaData:        "",
+		SnippetsStorage: "",
+		DriveType:       "",
+		Drive:           "",
+		Upgrade:         true,
+		Regenerate:      true,
+		Image:           InitializeCloudInitImageWithDefaults(),
 	}
 }
 
@@ -40,16 +82,91 @@ func (configuration *Configuration) GetPassword() string {
 	return configuration.Password
 }
 
+// GetPasswordFile returns the PasswordFile field value.
+func (configuration *Configuration) GetPasswordFile() string {
+	return configuration.PasswordFile
+}
+
+// GetPasswordEnv returns the PasswordEnv field value.
+func (configuration *Configuration) GetPasswordEnv() string {
+	return configuration.PasswordEnv
+}
+
+// HasPassword returns true if the password is set directly, through a file or through an environment variable.
+func (configuration *Configuration) HasPassword() bool {
+	return configuration.Password != "" || configuration.PasswordFile != "" || configuration.PasswordEnv != ""
+}
+
 // GetKeys returns the Keys field value.
 func (configuration *Configuration) GetKeys() []string {
 	return configuration.Keys
 }
 
+// GetUsers returns the Users field value.
+func (configuration *Configuration) GetUsers() []*CloudInitUser {
+	return configuration.Users
+}
+
+// GetSshKeySources returns the SshKeySources field value.
+func (configuration *Configuration) GetSshKeySources() *CloudInitSshKeySources {
+	return configuration.SshKeySources
+}
+
 // GetNetwork returns the Network field value.
 func (configuration *Configuration) GetNetwork() *CloudInitNetwork {
 	return configuration.Network
 }
 
+// GetUserData returns the UserData field value.
+func (configuration *Configuration) GetUserData() string {
+	return configuration.UserData
+}
+
+// GetVendorData returns the VendorData field value.
+func (configuration *Configuration) GetVendorData() string {
+	return configuration.VendorData
+}
+
+// GetNetworkData returns the NetworkData field value.
+func (configuration *Configuration) GetNetworkData() string {
+	return configuration.NetworkData
+}
+
+// GetMetaData returns the MetaData field value.
+func (configuration *Configuration) GetMetaData() string {
+	return configuration.MetaData
+}
+
+// GetSnippetsStorage returns the SnippetsStorage field value.
+func (configuration *Configuration) GetSnippetsStorage() string {
+	return configuration.SnippetsStorage
+}
+
+// GetDriveType returns the DriveType field value.
+func (configuration *Configuration) GetDriveType() string {
+	return configuration.DriveType
+}
+
+// GetDrive returns the Drive field value.
+func (configuration *Configuration) GetDrive() string {
+	return configuration.Drive
+}
+
+// GetUpgrade returns the Upgrade field value.
+func (configuration *Configuration) GetUpgrade() bool {
+	return configuration.Upgrade
+}
+
+// GetRegenerate returns the Regenerate field value.
+func (configuration *Configuration) GetRegenerate() bool {
+	return configuration.Regenerate
+}
+
+// GetImage returns the Image field value.
+func (configuration *Configuration) GetImage() *CloudInitImage {
+	return configuration.Image
+}
+
 // IsConfigured returns true if the configuration is configured.
 func (configuration *Configuration) IsConfigured() bool {
 	if !configuration.Enabled {
@@ -60,7 +177,7 @@ func (configuration *Configuration) IsConfigured() bool {
 		return false
 	}
 
-	if configuration.Password == "" {
+	if !configuration.HasPassword() {
 		return false
 	}
 
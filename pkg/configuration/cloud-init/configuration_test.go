@@ -24,19 +24,63 @@ func TestInitializeWithDefaults(t *testing.T) {
 	if config.Network == nil {
 		t.Error("Expected Network configuration to be initialized, but got nil")
 	}
+	if len(config.Users) != 0 {
+		t.Errorf("Expected Users to be empty, got %v", config.Users)
+	}
+	if config.SshKeySources == nil {
+		t.Error("Expected SshKeySources configuration to be initialized, but got nil")
+	}
+	if config.Image == nil {
+		t.Error("Expected Image configuration to be initialized, but got nil")
+	}
+	if config.UserData != "" || config.VendorData != "" || config.NetworkData != "" || config.MetaData != "" {
+		t.Errorf("Expected custom snippets to be empty")
+	}
+	if config.PasswordFile != "" || config.PasswordEnv != "" {
+		t.Errorf("Expected PasswordFile and PasswordEnv to be empty")
+	}
+	if config.HasPassword() {
+		t.Errorf("Expected HasPassword to be false")
+	}
+	if config.SnippetsStorage != "" {
+		t.Errorf("Expected SnippetsStorage to be empty, got %s", config.SnippetsStorage)
+	}
+	if config.DriveType != "" || config.Drive != "" {
+		t.Errorf("Expected DriveType and Drive to be empty")
+	}
+	if !config.Upgrade || !config.Regenerate {
+		t.Errorf("Expected Upgrade and Regenerate to be true")
+	}
 }
 
 // TestConfigurationGetters tests the getters of the Configuration.
 func TestConfigurationGetters(t *testing.T) {
 	keys := []string{"key1", "key2"}
 	networkConfig := InitializeCloudInitNetworkWithDefaults()
+	sshKeySources := InitializeCloudInitSshKeySourcesWithDefaults()
+	image := InitializeCloudInitImageWithDefaults()
+	users := []*CloudInitUser{{Name: "ops", Groups: []string{"sudo"}}}
 
 	config := &Configuration{
-		Enabled:  true,
-		Username: "testuser",
-		Password: "testpass",
-		Keys:     keys,
-		Network:  networkConfig,
+		Enabled:         true,
+		Username:        "testuser",
+		Password:        "testpass",
+		PasswordFile:    "/root/.ptm-password",
+		PasswordEnv:     "PTM_CI_PASSWORD",
+		Keys:            keys,
+		Users:           users,
+		SshKeySources:   sshKeySources,
+		Network:         networkConfig,
+		UserData:        "#cloud-config\n",
+		VendorData:      "/root/vendor.yaml",
+		NetworkData:     "/root/network.yaml",
+		MetaData:        "/root/meta.yaml",
+		SnippetsStorage: "local",
+		DriveType:       "configdrive2",
+		Drive:           "sata0",
+		Upgrade:         false,
+		Regenerate:      true,
+		Image:           image,
 	}
 
 	if config.GetEnabled() != config.Enabled {
@@ -51,7 +95,52 @@ func TestConfigurationGetters(t *testing.T) {
 	if !reflect.DeepEqual(config.GetKeys(), config.Keys) {
 		t.Errorf("GetKeys() = %v; want %v", config.GetKeys(), config.Keys)
 	}
+	if !reflect.DeepEqual(config.GetUsers(), users) {
+		t.Errorf("GetUsers() = %v; want %v", config.GetUsers(), users)
+	}
+	if config.GetSshKeySources() != sshKeySources {
+		t.Error("GetSshKeySources() did not return the expected SshKeySources configuration")
+	}
 	if config.GetNetwork() != config.Network {
 		t.Error("GetNetwork() did not return the expected Network configuration")
 	}
+	if config.GetPasswordFile() != config.PasswordFile {
+		t.Errorf("GetPasswordFile() = %s; want %s", config.GetPasswordFile(), config.PasswordFile)
+	}
+	if config.GetPasswordEnv() != config.PasswordEnv {
+		t.Errorf("GetPasswordEnv() = %s; want %s", config.GetPasswordEnv(), config.PasswordEnv)
+	}
+	if !config.HasPassword() {
+		t.Errorf("HasPassword() = false; want true")
+	}
+	if config.GetUserData() != config.UserData {
+		t.Errorf("GetUserData() = %s; want %s", config.GetUserData(), config.UserData)
+	}
+	if config.GetVendorData() != config.VendorData {
+		t.Errorf("GetVendorData() = %s; want %s", config.GetVendorData(), config.VendorData)
+	}
+	if config.GetNetworkData() != config.NetworkData {
+		t.Errorf("GetNetworkData() = %s; want %s", config.GetNetworkData(), config.NetworkData)
+	}
+	if config.GetMetaData() != config.MetaData {
+		t.Errorf("GetMetaData() = %s; want %s", config.GetMetaData(), config.MetaData)
+	}
+	if config.GetSnippetsStorage() != config.SnippetsStorage {
+		t.Errorf("GetSnippetsStorage() = %s; want %s", config.GetSnippetsStorage(), config.SnippetsStorage)
+	}
+	if config.GetDriveType() != config.DriveType {
+		t.Errorf("GetDriveType() = %s; want %s", config.GetDriveType(), config.DriveType)
+	}
+	if config.GetDrive() != config.Drive {
+		t.Errorf("GetDrive() = %s; want %s", config.GetDrive(), config.Drive)
+	}
+	if config.GetUpgrade() != config.Upgrade {
+		t.Errorf("GetUpgrade() = %v; want %v", config.GetUpgrade(), config.Upgrade)
+	}
+	if config.GetRegenerate() != config.Regenerate {
+		t.Errorf("GetRegenerate() = %v; want %v", config.GetRegenerate(), config.Regenerate)
+	}
+	if config.GetImage() != image {
+		t.Error("GetImage() did not return the expected Image configuration")
+	}
 }
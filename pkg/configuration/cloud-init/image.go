@@ -0,0 +1,51 @@
+package cloud_init
+
+// CloudInitImage is a struct that represents the cloud-init tuning baked into the image by the customize command.
+type CloudInitImage struct {
+	// Enabled is a flag that indicates whether the cloud-init tuning should be baked into the image.
+	Enabled bool `json:"enabled" yaml:"enabled" toml:"enabled" mapstructure:"enabled"`
+	// DatasourceList is a list of datasources cloud-init is allowed to probe.
+	DatasourceList []string `json:"datasource_list" yaml:"datasource_list" toml:"datasource_list" mapstructure:"datasource_list"`
+	// PreserveHostname is a flag that indicates whether cloud-init should leave the hostname of the image untouched.
+	PreserveHostname bool `json:"preserve_hostname" yaml:"preserve_hostname" toml:"preserve_hostname" mapstructure:"preserve_hostname"`
+	// DisabledModules is a list of cloud-init modules that should be removed from the image configuration.
+	DisabledModules []string `json:"disabled_modules" yaml:"disabled_modules" toml:"disabled_modules" mapstructure:"disabled_modules"`
+	// CleanState is a flag that indicates whether the cloud-init state and logs should be cleaned after customization.
+	CleanState bool `json:"clean_state" yaml:"clean_state" toml:"clean_state" mapstructure:"clean_state"`
+}
+
+// InitializeCloudInitImageWithDefaults initializes a CloudInitImage struct with default values.
+func InitializeCloudInitImageWithDefaults() *CloudInitImage {
+	return &CloudInitImage{
+		Enabled:          false,
+		DatasourceList:   []string{"NoCloud", "ConfigDrive"},
+		PreserveHostname: false,
+		DisabledModules:  []string{},
+		CleanState:       true,
+	}
+}
+
+// GetEnabled returns the Enabled field value.
+func (cii *CloudInitImage) GetEnabled() bool {
+	return cii.Enabled
+}
+
+// GetDatasourceList returns the DatasourceList field value.
+func (cii *CloudInitImage) GetDatasourceList() []string {
+	return cii.DatasourceList
+}
+
+// GetPreserveHostname returns the PreserveHostname field value.
+func (cii *CloudInitImage) GetPreserveHostname() bool {
+	return cii.PreserveHostname
+}
+
+// GetDisabledModules returns the DisabledModules field value.
+func (cii *CloudInitImage) GetDisabledModules() []string {
+	return cii.DisabledModules
+}
+
+// GetCleanState returns the CleanState field value.
+func (cii *CloudInitImage) GetCleanState() bool {
+	return cii.CleanState
+}
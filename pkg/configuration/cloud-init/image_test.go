@@ -0,0 +1,54 @@
+package cloud_init
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestInitializeCloudInitImageWithDefaults tests the initialization of the CloudInitImage with default values.
+func TestInitializeCloudInitImageWithDefaults(t *testing.T) {
+	config := InitializeCloudInitImageWithDefaults()
+
+	if config.Enabled {
+		t.Errorf("Expected Enabled to be false, got %v", config.Enabled)
+	}
+	if !reflect.DeepEqual(config.DatasourceList, []string{"NoCloud", "ConfigDrive"}) {
+		t.Errorf("Expected DatasourceList to be [NoCloud ConfigDrive], got %v", config.DatasourceList)
+	}
+	if config.PreserveHostname {
+		t.Errorf("Expected PreserveHostname to be false, got %v", config.PreserveHostname)
+	}
+	if len(config.DisabledModules) != 0 {
+		t.Errorf("Expected DisabledModules to be empty, got %v", config.DisabledModules)
+	}
+	if !config.CleanState {
+		t.Errorf("Expected CleanState to be true, got %v", config.CleanState)
+	}
+}
+
+// TestCloudInitImageGetters tests the getters of the CloudInitImage configuration.
+func TestCloudInitImageGetters(t *testing.T) {
+	config := &CloudInitImage{
+		Enabled:          true,
+		DatasourceList:   []string{"NoCloud"},
+		PreserveHostname: true,
+		DisabledModules:  []string{"snap", "landscape"},
+		CleanState:       false,
+	}
+
+	if config.GetEnabled() != config.Enabled {
+		t.Errorf("GetEnabled() = %v; want %v", config.GetEnabled(), config.Enabled)
+	}
+	if !reflect.DeepEqual(config.GetDatasourceList(), config.DatasourceList) {
+		t.Errorf("GetDatasourceList() = %v; want %v", config.GetDatasourceList(), config.DatasourceList)
+	}
+	if config.GetPreserveHostname() != config.PreserveHostname {
+		t.Errorf("GetPreserveHostname() = %v; want %v", config.GetPreserveHostname(), config.PreserveHostname)
+	}
+	if !reflect.DeepEqual(config.GetDisabledModules(), config.DisabledModules) {
+		t.Errorf("GetDisabledModules() = %v; want %v", config.GetDisabledModules(), config.DisabledModules)
+	}
+	if config.GetCleanState() != config.CleanState {
+		t.Errorf("GetCleanState() = %v; want %v", config.GetCleanState(), config.CleanState)
+	}
+}
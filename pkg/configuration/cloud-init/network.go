@@ -6,13 +6,25 @@ type CloudInitNetwork struct {
 	IPv4 *CloudInitNetworkIPv4 `json:"ipv4" yaml:"ipv4" toml:"ipv4" mapstructure:"ipv4"`
 	// IPv6 is a reference to the IPv6 configuration.
 	IPv6 *CloudInitNetworkIPv6 `json:"ipv6" yaml:"ipv6" toml:"ipv6" mapstructure:"ipv6"`
+	// Dns is a reference to the DNS configuration.
+	Dns *CloudInitNetworkDns `json:"dns" yaml:"dns" toml:"dns" mapstructure:"dns"`
+	// Interfaces is a list of physical interfaces rendered into network-config v2.
+	Interfaces []*CloudInitNetworkInterface `json:"interfaces" yaml:"interfaces" toml:"interfaces" mapstructure:"interfaces"`
+	// Bonds is a list of bonds rendered into network-config v2.
+	Bonds []*CloudInitNetworkBond `json:"bonds" yaml:"bonds" toml:"bonds" mapstructure:"bonds"`
+	// Vlans is a list of VLAN subinterfaces rendered into network-config v2.
+	Vlans []*CloudInitNetworkVlan `json:"vlans" yaml:"vlans" toml:"vlans" mapstructure:"vlans"`
 }
 
 // InitializeCloudInitNetworkWithDefaults initializes CloudInitNetwork with default values.
 func InitializeCloudInitNetworkWithDefaults() *CloudInitNetwork {
 	return &CloudInitNetwork{
-		IPv4: InitializeCloudInitNetworkIPv4WithDefaults(),
-		IPv6: InitializeCloudInitNetworkIPv6WithDefaults(),
+		IPv4:       InitializeCloudInitNetworkIPv4WithDefaults(),
+		IPv6:       InitializeCloudInitNetworkIPv6WithDefaults(),
+		Dns:        InitializeCloudInitNetworkDnsWithDefaults(),
+		Interfaces: []*CloudInitNetworkInterface{},
+		Bonds:      []*CloudInitNetworkBond{},
+		Vlans:      []*CloudInitNetworkVlan{},
 	}
 }
 
@@ -26,7 +38,32 @@ func (cin *CloudInitNetwork) GetIPv6() *CloudInitNetworkIPv6 {
 	return cin.IPv6
 }
 
+// GetDns returns the DNS configuration.
+func (cin *CloudInitNetwork) GetDns() *CloudInitNetworkDns {
+	return cin.Dns
+}
+
+// GetInterfaces returns the list of physical interfaces.
+func (cin *CloudInitNetwork) GetInterfaces() []*CloudInitNetworkInterface {
+	return cin.Interfaces
+}
+
+// GetBonds returns the list of bonds.
+func (cin *CloudInitNetwork) GetBonds() []*CloudInitNetworkBond {
+	return cin.Bonds
+}
+
+// GetVlans returns the list of VLAN subinterfaces.
+func (cin *CloudInitNetwork) GetVlans() []*CloudInitNetworkVlan {
+	return cin.Vlans
+}
+
+// HasAdvancedConfiguration returns true if interfaces, bonds or VLANs are configured.
+func (cin *CloudInitNetwork) HasAdvancedConfiguration() bool {
+	return len(cin.Interfaces) > 0 || len(cin.Bonds) > 0 || len(cin.Vlans) > 0
+}
+
 // IsConfigured returns true if the configuration is configured.
 func (cin *CloudInitNetwork) IsConfigured() bool {
-	return cin.IPv4.IsConfigured() || cin.IPv6.IsConfigured()
+	return cin.IPv4.IsConfigured() || cin.IPv6.IsConfigured() || cin.HasAdvancedConfiguration()
 }
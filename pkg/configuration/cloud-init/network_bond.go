@@ -0,0 +1,49 @@
+package cloud_init
+
+// CloudInitNetworkBond is a struct that represents a bond of network interfaces (netplan `bonds`).
+type CloudInitNetworkBond struct {
+	// Name is the name of the bond (for example, bond0).
+	Name string `json:"name" yaml:"name" toml:"name" mapstructure:"name"`
+	// Interfaces is a list of names of the interfaces that are members of the bond.
+	Interfaces []string `json:"interfaces" yaml:"interfaces" toml:"interfaces" mapstructure:"interfaces"`
+	// Mode is the bonding mode (balance-rr / active-backup / balance-xor / broadcast / 802.3ad / balance-tlb / balance-alb).
+	Mode string `json:"mode" yaml:"mode" toml:"mode" mapstructure:"mode"`
+	// MiiMonitorInterval is the MII link monitoring interval in milliseconds (0 means not set).
+	MiiMonitorInterval int `json:"mii_monitor_interval" yaml:"mii_monitor_interval" toml:"mii_monitor_interval" mapstructure:"mii_monitor_interval"`
+	// LacpRate is the LACP rate for the 802.3ad mode (slow / fast).
+	LacpRate string `json:"lacp_rate" yaml:"lacp_rate" toml:"lacp_rate" mapstructure:"lacp_rate"`
+	// TransmitHashPolicy is the transmit hash policy (layer2 / layer3+4 / layer2+3 / encap2+3 / encap3+4).
+	TransmitHashPolicy string `json:"transmit_hash_policy" yaml:"transmit_hash_policy" toml:"transmit_hash_policy" mapstructure:"transmit_hash_policy"`
+	// CloudInitNetworkDevice is the addressing configuration of the bond.
+	CloudInitNetworkDevice `json:",inline" yaml:",inline" toml:",inline" mapstructure:",squash"`
+}
+
+// GetName returns the Name field value.
+func (cinb *CloudInitNetworkBond) GetName() string {
+	return cinb.Name
+}
+
+// GetInterfaces returns the Interfaces field value.
+func (cinb *CloudInitNetworkBond) GetInterfaces() []string {
+	return cinb.Interfaces
+}
+
+// GetMode returns the Mode field value.
+func (cinb *CloudInitNetworkBond) GetMode() string {
+	return cinb.Mode
+}
+
+// GetMiiMonitorInterval returns the MiiMonitorInterval field value.
+func (cinb *CloudInitNetworkBond) GetMiiMonitorInterval() int {
+	return cinb.MiiMonitorInterval
+}
+
+// GetLacpRate returns the LacpRate field value.
+func (cinb *CloudInitNetworkBond) GetLacpRate() string {
+	return cinb.LacpRate
+}
+
+// GetTransmitHashPolicy returns the TransmitHashPolicy field value.
+func (cinb *CloudInitNetworkBond) GetTransmitHashPolicy() string {
+	return cinb.TransmitHashPolicy
+}
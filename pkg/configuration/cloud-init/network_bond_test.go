@@ -0,0 +1,43 @@
+package cloud_init
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCloudInitNetworkBondGetters tests the getters of the CloudInitNetworkBond configuration.
+func TestCloudInitNetworkBondGetters(t *testing.T) {
+	config := &CloudInitNetworkBond{
+		Name:               "bond0",
+		Interfaces:         []string{"eth0", "eth1"},
+		Mode:               "802.3ad",
+		MiiMonitorInterval: 100,
+		LacpRate:           "fast",
+		TransmitHashPolicy: "layer3+4",
+		CloudInitNetworkDevice: CloudInitNetworkDevice{
+			Mtu: 9000,
+		},
+	}
+
+	if config.GetName() != config.Name {
+		t.Errorf("GetName() = %s; want %s", config.GetName(), config.Name)
+	}
+	if !reflect.DeepEqual(config.GetInterfaces(), config.Interfaces) {
+		t.Errorf("GetInterfaces() = %v; want %v", config.GetInterfaces(), config.Interfaces)
+	}
+	if config.GetMode() != config.Mode {
+		t.Errorf("GetMode() = %s; want %s", config.GetMode(), config.Mode)
+	}
+	if config.GetMiiMonitorInterval() != config.MiiMonitorInterval {
+		t.Errorf("GetMiiMonitorInterval() = %d; want %d", config.GetMiiMonitorInterval(), config.MiiMonitorInterval)
+	}
+	if config.GetLacpRate() != config.LacpRate {
+		t.Errorf("GetLacpRate() = %s; want %s", config.GetLacpRate(), config.LacpRate)
+	}
+	if config.GetTransmitHashPolicy() != config.TransmitHashPolicy {
+		t.Errorf("GetTransmitHashPolicy() = %s; want %s", config.GetTransmitHashPolicy(), config.TransmitHashPolicy)
+	}
+	if config.GetMtu() != 9000 {
+		t.Errorf("GetMtu() = %d; want %d", config.GetMtu(), 9000)
+	}
+}
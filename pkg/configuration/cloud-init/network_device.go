@@ -0,0 +1,73 @@
+package cloud_init
+
+// CloudInitNetworkDevice is a struct that represents the addressing configuration shared by interfaces, bonds and VLANs.
+type CloudInitNetworkDevice struct {
+	// Dhcp4 is a flag that indicates whether DHCP should be used for IPv4.
+	Dhcp4 bool `json:"dhcp4" yaml:"dhcp4" toml:"dhcp4" mapstructure:"dhcp4"`
+	// Dhcp6 is a flag that indicates whether DHCP should be used for IPv6.
+	Dhcp6 bool `json:"dhcp6" yaml:"dhcp6" toml:"dhcp6" mapstructure:"dhcp6"`
+	// Addresses is a list of static addresses (with subnet) of the device.
+	Addresses []string `json:"addresses" yaml:"addresses" toml:"addresses" mapstructure:"addresses"`
+	// Gateway4 is the IPv4 default gateway of the device.
+	Gateway4 string `json:"gateway4" yaml:"gateway4" toml:"gateway4" mapstructure:"gateway4"`
+	// Gateway6 is the IPv6 default gateway of the device.
+	Gateway6 string `json:"gateway6" yaml:"gateway6" toml:"gateway6" mapstructure:"gateway6"`
+	// Mtu is the MTU of the device (0 means not set).
+	Mtu int `json:"mtu" yaml:"mtu" toml:"mtu" mapstructure:"mtu"`
+	// Routes is a list of static routes of the device.
+	Routes []*CloudInitNetworkRoute `json:"routes" yaml:"routes" toml:"routes" mapstructure:"routes"`
+	// Nameservers is a list of DNS servers of the device (falls back to `network.dns` for devices with static addresses).
+	Nameservers []string `json:"nameservers" yaml:"nameservers" toml:"nameservers" mapstructure:"nameservers"`
+	// SearchDomains is a list of DNS search domains of the device.
+	SearchDomains []string `json:"search_domains" yaml:"search_domains" toml:"search_domains" mapstructure:"search_domains"`
+}
+
+// GetDhcp4 returns the Dhcp4 field value.
+func (cind *CloudInitNetworkDevice) GetDhcp4() bool {
+	return cind.Dhcp4
+}
+
+// GetDhcp6 returns the Dhcp6 field value.
+func (cind *CloudInitNetworkDevice) GetDhcp6() bool {
+	return cind.Dhcp6
+}
+
+// GetAddresses returns the Addresses field value.
+func (cind *CloudInitNetworkDevice) GetAddresses() []string {
+	return cind.Addresses
+}
+
+// GetGateway4 returns the Gateway4 field value.
+func (cind *CloudInitNetworkDevice) GetGateway4() string {
+	return cind.Gateway4
+}
+
+// GetGateway6 returns the Gateway6 field value.
+func (cind *CloudInitNetworkDevice) GetGateway6() string {
+	return cind.Gateway6
+}
+
+// GetMtu returns the Mtu field value.
+func (cind *CloudInitNetworkDevice) GetMtu() int {
+	return cind.Mtu
+}
+
+// GetRoutes returns the Routes field value.
+func (cind *CloudInitNetworkDevice) GetRoutes() []*CloudInitNetworkRoute {
+	return cind.Routes
+}
+
+// GetNameservers returns the Nameservers field value.
+func (cind *CloudInitNetworkDevice) GetNameservers() []string {
+	return cind.Nameservers
+}
+
+// GetSearchDomains returns the SearchDomains field value.
+func (cind *CloudInitNetworkDevice) GetSearchDomains() []string {
+	return cind.SearchDomains
+}
+
+// HasDns returns true if the device has its own DNS configuration.
+func (cind *CloudInitNetworkDevice) HasDns() bool {
+	return len(cind.Nameservers) > 0 || len(cind.SearchDomains) > 0
+}
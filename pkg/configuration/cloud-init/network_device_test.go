@@ -0,0 +1,54 @@
+package cloud_init
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCloudInitNetworkDeviceGetters tests the getters of the CloudInitNetworkDevice configuration.
+func TestCloudInitNetworkDeviceGetters(t *testing.T) {
+	routes := []*CloudInitNetworkRoute{{To: "10.20.0.0/16", Via: "10.10.0.254"}}
+
+	config := &CloudInitNetworkDevice{
+		Dhcp4:         false,
+		Dhcp6:         true,
+		Addresses:     []string{"10.10.0.10/24"},
+		Gateway4:      "10.10.0.1",
+		Gateway6:      "2001:db8::1",
+		Mtu:           9000,
+		Routes:        routes,
+		Nameservers:   []string{"10.10.0.53"},
+		SearchDomains: []string{"example.com"},
+	}
+
+	if config.GetDhcp4() != config.Dhcp4 {
+		t.Errorf("GetDhcp4() = %v; want %v", config.GetDhcp4(), config.Dhcp4)
+	}
+	if config.GetDhcp6() != config.Dhcp6 {
+		t.Errorf("GetDhcp6() = %v; want %v", config.GetDhcp6(), config.Dhcp6)
+	}
+	if !reflect.DeepEqual(config.GetAddresses(), config.Addresses) {
+		t.Errorf("GetAddresses() = %v; want %v", config.GetAddresses(), config.Addresses)
+	}
+	if config.GetGateway4() != config.Gateway4 {
+		t.Errorf("GetGateway4() = %s; want %s", config.GetGateway4(), config.Gateway4)
+	}
+	if config.GetGateway6() != config.Gateway6 {
+		t.Errorf("GetGateway6() = %s; want %s", config.GetGateway6(), config.Gateway6)
+	}
+	if config.GetMtu() != config.Mtu {
+		t.Errorf("GetMtu() = %d; want %d", config.GetMtu(), config.Mtu)
+	}
+	if !reflect.DeepEqual(config.GetRoutes(), routes) {
+		t.Errorf("GetRoutes() = %v; want %v", config.GetRoutes(), routes)
+	}
+	if !reflect.DeepEqual(config.GetNameservers(), config.Nameservers) {
+		t.Errorf("GetNameservers() = %v; want %v", config.GetNameservers(), config.Nameservers)
+	}
+	if !reflect.DeepEqual(config.GetSearchDomains(), config.SearchDomains) {
+		t.Errorf("GetSearchDomains() = %v; want %v", config.GetSearchDomains(), config.SearchDomains)
+	}
+	if !config.HasDns() {
+		t.Errorf("HasDns() = false; want true")
+	}
+}
@@ -0,0 +1,32 @@
+package cloud_init
+
+// CloudInitNetworkDns is a struct that represents the DNS configuration of a cloud-init configuration.
+type CloudInitNetworkDns struct {
+	// Nameservers is a list of IPv4 and IPv6 addresses of the DNS servers.
+	Nameservers []string `json:"nameservers" yaml:"nameservers" toml:"nameservers" mapstructure:"nameservers"`
+	// SearchDomains is a list of DNS search domains.
+	SearchDomains []string `json:"search_domains" yaml:"search_domains" toml:"search_domains" mapstructure:"search_domains"`
+}
+
+// InitializeCloudInitNetworkDnsWithDefaults initializes a CloudInitNetworkDns struct with default values.
+func InitializeCloudInitNetworkDnsWithDefaults() *CloudInitNetworkDns {
+	return &CloudInitNetworkDns{
+		Nameservers:   []string{},
+		SearchDomains: []string{},
+	}
+}
+
+// GetNameservers returns the Nameservers field value.
+func (cindns *CloudInitNetworkDns) GetNameservers() []string {
+	return cindns.Nameservers
+}
+
+// GetSearchDomains returns the SearchDomains field value.
+func (cindns *CloudInitNetworkDns) GetSearchDomains() []string {
+	return cindns.SearchDomains
+}
+
+// IsConfigured returns true if the configuration is configured.
+func (cindns *CloudInitNetworkDns) IsConfigured() bool {
+	return len(cindns.Nameservers) > 0 || len(cindns.SearchDomains) > 0
+}
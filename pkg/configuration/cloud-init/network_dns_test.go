@@ -0,0 +1,39 @@
+package cloud_init
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestInitializeCloudInitNetworkDnsWithDefaults tests the initialization of the CloudInitNetworkDns with default values.
+func TestInitializeCloudInitNetworkDnsWithDefaults(t *testing.T) {
+	dnsConfig := InitializeCloudInitNetworkDnsWithDefaults()
+
+	if len(dnsConfig.Nameservers) != 0 {
+		t.Errorf("Expected Nameservers to be empty, got %v", dnsConfig.Nameservers)
+	}
+	if len(dnsConfig.SearchDomains) != 0 {
+		t.Errorf("Expected SearchDomains to be empty, got %v", dnsConfig.SearchDomains)
+	}
+	if dnsConfig.IsConfigured() {
+		t.Error("Expected IsConfigured to be false for default configuration")
+	}
+}
+
+// TestCloudInitNetworkDnsGetters tests the getters of the CloudInitNetworkDns configuration.
+func TestCloudInitNetworkDnsGetters(t *testing.T) {
+	dnsConfig := &CloudInitNetworkDns{
+		Nameservers:   []string{"1.1.1.1", "2606:4700:4700::1111"},
+		SearchDomains: []string{"example.com"},
+	}
+
+	if !reflect.DeepEqual(dnsConfig.GetNameservers(), dnsConfig.Nameservers) {
+		t.Errorf("GetNameservers() = %v; want %v", dnsConfig.GetNameservers(), dnsConfig.Nameservers)
+	}
+	if !reflect.DeepEqual(dnsConfig.GetSearchDomains(), dnsConfig.SearchDomains) {
+		t.Errorf("GetSearchDomains() = %v; want %v", dnsConfig.GetSearchDomains(), dnsConfig.SearchDomains)
+	}
+	if !dnsConfig.IsConfigured() {
+		t.Error("Expected IsConfigured to be true")
+	}
+}
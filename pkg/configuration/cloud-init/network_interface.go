@@ -0,0 +1,21 @@
+package cloud_init
+
+// CloudInitNetworkInterface is a struct that represents a physical network interface (netplan `ethernets`).
+type CloudInitNetworkInterface struct {
+	// Name is the name of the interface (for example, eth0).
+	Name string `json:"name" yaml:"name" toml:"name" mapstructure:"name"`
+	// Match is the optional interface name glob used to match the interface (for example, en*).
+	Match string `json:"match" yaml:"match" toml:"match" mapstructure:"match"`
+	// CloudInitNetworkDevice is the addressing configuration of the interface.
+	CloudInitNetworkDevice `json:",inline" yaml:",inline" toml:",inline" mapstructure:",squash"`
+}
+
+// GetName returns the Name field value.
+func (cini *CloudInitNetworkInterface) GetName() string {
+	return cini.Name
+}
+
+// GetMatch returns the Match field value.
+func (cini *CloudInitNetworkInterface) GetMatch() string {
+	return cini.Match
+}
@@ -0,0 +1,26 @@
+package cloud_init
+
+import (
+	"testing"
+)
+
+// TestCloudInitNetworkInterfaceGetters tests the getters of the CloudInitNetworkInterface configuration.
+func TestCloudInitNetworkInterfaceGetters(t *testing.T) {
+	config := &CloudInitNetworkInterface{
+		Name:  "eth0",
+		Match: "en*",
+		CloudInitNetworkDevice: CloudInitNetworkDevice{
+			Dhcp4: true,
+		},
+	}
+
+	if config.GetName() != config.Name {
+		t.Errorf("GetName() = %s; want %s", config.GetName(), config.Name)
+	}
+	if config.GetMatch() != config.Match {
+		t.Errorf("GetMatch() = %s; want %s", config.GetMatch(), config.Match)
+	}
+	if !config.GetDhcp4() {
+		t.Errorf("GetDhcp4() = false; want true")
+	}
+}
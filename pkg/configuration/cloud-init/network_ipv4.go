@@ -2,6 +2,8 @@ package cloud_init
 
 // CloudInitNetworkIPv4 is a struct that represents the IPv4 network configuration of a cloud-init configuration.
 type CloudInitNetworkIPv4 struct {
+	// Mode is the addressing mode of the network interface (static / dhcp / none), takes precedence over AutoConfigure.
+	Mode string `json:"mode" yaml:"mode" toml:"mode" mapstructure:"mode"`
 	// AutoConfigure is a boolean value that indicates whether the network configuration should be automatically configured.
 	AutoConfigure bool `json:"auto_configure" yaml:"auto_configure" toml:"auto_configure" mapstructure:"auto_configure"`
 	// Address is the IPv4 address of the network interface.
@@ -13,12 +15,18 @@ type CloudInitNetworkIPv4 struct {
 // InitializeCloudInitNetworkIPv4WithDefaults initializes a CloudInitNetworkIPv4 struct with default values.
 func InitializeCloudInitNetworkIPv4WithDefaults() *CloudInitNetworkIPv4 {
 	return &CloudInitNetworkIPv4{
+		Mode:          "",
 		AutoConfigure: true,
 		Address:       "",
 		Gateway:       "",
 	}
 }
 
+// GetMode returns the Mode field value.
+func (cinipv4 *CloudInitNetworkIPv4) GetMode() string {
+	return cinipv4.Mode
+}
+
 // GetAutoConfigure returns the AutoConfigure field value.
 func (cinipv4 *CloudInitNetworkIPv4) GetAutoConfigure() bool {
 	return cinipv4.AutoConfigure
@@ -36,6 +44,9 @@ func (cinipv4 *CloudInitNetworkIPv4) GetGateway() string {
 
 // IsConfigured returns true if the configuration is configured.
 func (cinipv4 *CloudInitNetworkIPv4) IsConfigured() bool {
+	if cinipv4.Mode != "" {
+		return true
+	}
 	if cinipv4.AutoConfigure {
 		return true
 	}
@@ -8,6 +8,9 @@ import (
 func TestInitializeCloudInitNetworkIPv4WithDefaults(t *testing.T) {
 	config := InitializeCloudInitNetworkIPv4WithDefaults()
 
+	if config.Mode != "" {
+		t.Errorf("Expected Mode to be empty, got %s", config.Mode)
+	}
 	if config.AutoConfigure != true {
 		t.Errorf("Expected AutoConfigure to be true, got %v", config.AutoConfigure)
 	}
@@ -22,11 +25,15 @@ func TestInitializeCloudInitNetworkIPv4WithDefaults(t *testing.T) {
 // TestCloudInitNetworkIPv4Getters tests the getters of the CloudInitNetworkIPv4 configuration.
 func TestCloudInitNetworkIPv4Getters(t *testing.T) {
 	config := &CloudInitNetworkIPv4{
+		Mode:          "static",
 		AutoConfigure: false,
 		Address:       "10.10.0.10/22",
 		Gateway:       "10.10.0.1",
 	}
 
+	if config.GetMode() != config.Mode {
+		t.Errorf("GetMode() = %s; want %s", config.GetMode(), config.Mode)
+	}
 	if config.GetAutoConfigure() != config.AutoConfigure {
 		t.Errorf("GetAutoConfigure() = %v; want %v", config.GetAutoConfigure(), config.AutoConfigure)
 	}
@@ -63,3 +70,15 @@ func TestCloudInitNetworkIPv4IsConfiguredWithAutoConfigure(t *testing.T) {
 		t.Errorf("IsConfigured() = %v; want %v", config.IsConfigured(), true)
 	}
 }
+
+// TestCloudInitNetworkIPv4IsConfiguredWithMode tests the IsConfigured function of the CloudInitNetworkIPv4 configuration with Mode.
+func TestCloudInitNetworkIPv4IsConfiguredWithMode(t *testing.T) {
+	config := &CloudInitNetworkIPv4{
+		Mode:          "none",
+		AutoConfigure: false,
+	}
+
+	if config.IsConfigured() != true {
+		t.Errorf("IsConfigured() = %v; want %v", config.IsConfigured(), true)
+	}
+}
@@ -2,6 +2,8 @@ package cloud_init
 
 // CloudInitNetworkIPv6 is a struct that represents the IPv6 network configuration of a cloud-init configuration.
 type CloudInitNetworkIPv6 struct {
+	// Mode is the addressing mode of the network interface (static / dhcpv6 / slaac / none), takes precedence over AutoConfigure.
+	Mode string `json:"mode" yaml:"mode" toml:"mode" mapstructure:"mode"`
 	// AutoConfigure is a boolean value that indicates whether the network configuration should be automatically configured.
 	AutoConfigure bool `json:"auto_configure" yaml:"auto_configure" toml:"auto_configure" mapstructure:"auto_configure"`
 	// Address is the IPv6 address of the network interface.
@@ -13,12 +15,18 @@ type CloudInitNetworkIPv6 struct {
 // InitializeCloudInitNetworkIPv6WithDefaults initializes a CloudInitNetworkIPv6 struct with default values.
 func InitializeCloudInitNetworkIPv6WithDefaults() *CloudInitNetworkIPv6 {
 	return &CloudInitNetworkIPv6{
+		Mode:          "",
 		AutoConfigure: true,
 		Address:       "",
 		Gateway:       "",
 	}
 }
 
+// GetMode returns the Mode field value.
+func (cinipv6 *CloudInitNetworkIPv6) GetMode() string {
+	return cinipv6.Mode
+}
+
 // GetAutoConfigure returns the AutoConfigure field value.
 func (cinipv6 *CloudInitNetworkIPv6) GetAutoConfigure() bool {
 	return cinipv6.AutoConfigure
@@ -36,6 +44,9 @@ func (cinipv6 *CloudInitNetworkIPv6) GetGateway() string {
 
 // IsConfigured returns true if the configuration is configured.
 func (cinipv6 *CloudInitNetworkIPv6) IsConfigured() bool {
+	if cinipv6.Mode != "" {
+		return true
+	}
 	if cinipv6.AutoConfigure {
 		return true
 	}
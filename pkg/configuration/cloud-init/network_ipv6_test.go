@@ -8,6 +8,9 @@ import (
 func TestInitializeCloudInitNetworkIPv6WithDefaults(t *testing.T) {
 	config := InitializeCloudInitNetworkIPv6WithDefaults()
 
+	if config.Mode != "" {
+		t.Errorf("Expected Mode to be empty, got %s", config.Mode)
+	}
 	if config.AutoConfigure != true {
 		t.Errorf("Expected AutoConfigure to be true, got %v", config.AutoConfigure)
 	}
@@ -22,11 +25,15 @@ func TestInitializeCloudInitNetworkIPv6WithDefaults(t *testing.T) {
 // TestCloudInitNetworkIPv6Getters tests the getters of the CloudInitNetworkIPv6 configuration.
 func TestCloudInitNetworkIPv6Getters(t *testing.T) {
 	config := &CloudInitNetworkIPv6{
+		Mode:          "static",
 		AutoConfigure: false,
 		Address:       "2001:db8::1",
 		Gateway:       "2001:db8::ff",
 	}
 
+	if config.GetMode() != config.Mode {
+		t.Errorf("GetMode() = %s; want %s", config.GetMode(), config.Mode)
+	}
 	if config.GetAutoConfigure() != config.AutoConfigure {
 		t.Errorf("GetAutoConfigure() = %v; want %v", config.GetAutoConfigure(), config.AutoConfigure)
 	}
@@ -63,3 +70,15 @@ func TestCloudInitNetworkIPv6IsConfiguredWithAutoConfigure(t *testing.T) {
 		t.Errorf("IsConfigured() = %v; want %v", config.IsConfigured(), false)
 	}
 }
+
+// TestCloudInitNetworkIPv6IsConfiguredWithMode tests the IsConfigured function of the CloudInitNetworkIPv6 configuration with Mode.
+func TestCloudInitNetworkIPv6IsConfiguredWithMode(t *testing.T) {
+	config := &CloudInitNetworkIPv6{
+		Mode:          "none",
+		AutoConfigure: false,
+	}
+
+	if config.IsConfigured() != true {
+		t.Errorf("IsConfigured() = %v; want %v", config.IsConfigured(), true)
+	}
+}
@@ -0,0 +1,26 @@
+package cloud_init
+
+// CloudInitNetworkRoute is a struct that represents a static route of a network device.
+type CloudInitNetworkRoute struct {
+	// To is the destination of the route (CIDR or `default`).
+	To string `json:"to" yaml:"to" toml:"to" mapstructure:"to"`
+	// Via is the gateway of the route.
+	Via string `json:"via" yaml:"via" toml:"via" mapstructure:"via"`
+	// Metric is the metric of the route (0 means not set).
+	Metric int `json:"metric" yaml:"metric" toml:"metric" mapstructure:"metric"`
+}
+
+// GetTo returns the To field value.
+func (cinr *CloudInitNetworkRoute) GetTo() string {
+	return cinr.To
+}
+
+// GetVia returns the Via field value.
+func (cinr *CloudInitNetworkRoute) GetVia() string {
+	return cinr.Via
+}
+
+// GetMetric returns the Metric field value.
+func (cinr *CloudInitNetworkRoute) GetMetric() int {
+	return cinr.Metric
+}
@@ -0,0 +1,24 @@
+package cloud_init
+
+import (
+	"testing"
+)
+
+// TestCloudInitNetworkRouteGetters tests the getters of the CloudInitNetworkRoute configuration.
+func TestCloudInitNetworkRouteGetters(t *testing.T) {
+	config := &CloudInitNetworkRoute{
+		To:     "10.20.0.0/16",
+		Via:    "10.10.0.254",
+		Metric: 100,
+	}
+
+	if config.GetTo() != config.To {
+		t.Errorf("GetTo() = %s; want %s", config.GetTo(), config.To)
+	}
+	if config.GetVia() != config.Via {
+		t.Errorf("GetVia() = %s; want %s", config.GetVia(), config.Via)
+	}
+	if config.GetMetric() != config.Metric {
+		t.Errorf("GetMetric() = %d; want %d", config.GetMetric(), config.Metric)
+	}
+}
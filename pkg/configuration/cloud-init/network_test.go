@@ -1,6 +1,8 @@
 package cloud_init
 
 import (
+	"bytes"
+	"github.com/spf13/viper"
 	"testing"
 )
 
@@ -14,16 +16,24 @@ func TestInitializeCloudInitNetworkWithDefaults(t *testing.T) {
 	if networkConfig.IPv6 == nil {
 		t.Error("Expected IPv6 configuration to be initialized, but got nil")
 	}
+	if networkConfig.Dns == nil {
+		t.Error("Expected DNS configuration to be initialized, but got nil")
+	}
+	if networkConfig.HasAdvancedConfiguration() {
+		t.Error("Expected HasAdvancedConfiguration to be false for default configuration")
+	}
 }
 
 // TestCloudInitNetworkGetters tests the getters of the CloudInitNetwork configuration.
 func TestCloudInitNetworkGetters(t *testing.T) {
 	ipv4Config := InitializeCloudInitNetworkIPv4WithDefaults()
 	ipv6Config := InitializeCloudInitNetworkIPv6WithDefaults()
+	dnsConfig := InitializeCloudInitNetworkDnsWithDefaults()
 
 	networkConfig := &CloudInitNetwork{
 		IPv4: ipv4Config,
 		IPv6: ipv6Config,
+		Dns:  dnsConfig,
 	}
 
 	if networkConfig.GetIPv4() != ipv4Config {
@@ -32,4 +42,57 @@ func TestCloudInitNetworkGetters(t *testing.T) {
 	if networkConfig.GetIPv6() != ipv6Config {
 		t.Error("GetIPv6() did not return the expected IPv6 configuration")
 	}
+	if networkConfig.GetDns() != dnsConfig {
+		t.Error("GetDns() did not return the expected DNS configuration")
+	}
+}
+
+// TestCloudInitNetworkAdvancedConfigurationDecoding tests that interfaces, bonds and VLANs are decoded from the configuration file.
+func TestCloudInitNetworkAdvancedConfigurationDecoding(t *testing.T) {
+	content := []byte(`
+interfaces:
+  - name: eth0
+    match: en*
+    mtu: 9000
+bonds:
+  - name: bond0
+    interfaces: [eth0]
+    mode: active-backup
+    dhcp4: true
+vlans:
+  - name: vlan10
+    id: 10
+    link: bond0
+    addresses: [10.10.0.10/24]
+    gateway4: 10.10.0.1
+    routes:
+      - to: 10.20.0.0/16
+        via: 10.10.0.254
+`)
+
+	reader := viper.New()
+	reader.SetConfigType("yaml")
+	if err := reader.ReadConfig(bytes.NewReader(content)); err != nil {
+		t.Fatalf("failed to read configuration: %v", err)
+	}
+
+	networkConfig := InitializeCloudInitNetworkWithDefaults()
+	if err := reader.Unmarshal(networkConfig); err != nil {
+		t.Fatalf("failed to decode configuration: %v", err)
+	}
+
+	if !networkConfig.HasAdvancedConfiguration() {
+		t.Fatal("Expected HasAdvancedConfiguration to be true")
+	}
+	if networkConfig.GetInterfaces()[0].GetMtu() != 9000 || networkConfig.GetInterfaces()[0].GetMatch() != "en*" {
+		t.Errorf("Interface was not decoded correctly: %+v", networkConfig.GetInterfaces()[0])
+	}
+	if !networkConfig.GetBonds()[0].GetDhcp4() || networkConfig.GetBonds()[0].GetMode() != "active-backup" {
+		t.Errorf("Bond was not decoded correctly: %+v", networkConfig.GetBonds()[0])
+	}
+
+	vlan := networkConfig.GetVlans()[0]
+	if vlan.GetId() != 10 || vlan.GetGateway4() != "10.10.0.1" || len(vlan.GetRoutes()) != 1 || vlan.GetRoutes()[0].GetVia() != "10.10.0.254" {
+		t.Errorf("VLAN was not decoded correctly: %+v", vlan)
+	}
 }
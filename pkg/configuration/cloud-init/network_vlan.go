@@ -0,0 +1,28 @@
+package cloud_init
+
+// CloudInitNetworkVlan is a struct that represents a VLAN subinterface (netplan `vlans`).
+type CloudInitNetworkVlan struct {
+	// Name is the name of the VLAN subinterface (for example, vlan10).
+	Name string `json:"name" yaml:"name" toml:"name" mapstructure:"name"`
+	// Id is the VLAN identifier (1 - 4094).
+	Id int `json:"id" yaml:"id" toml:"id" mapstructure:"id"`
+	// Link is the name of the interface or bond the VLAN is created on.
+	Link string `json:"link" yaml:"link" toml:"link" mapstructure:"link"`
+	// CloudInitNetworkDevice is the addressing configuration of the VLAN subinterface.
+	CloudInitNetworkDevice `json:",inline" yaml:",inline" toml:",inline" mapstructure:",squash"`
+}
+
+// GetName returns the Name field value.
+func (cinv *CloudInitNetworkVlan) GetName() string {
+	return cinv.Name
+}
+
+// GetId returns the Id field value.
+func (cinv *CloudInitNetworkVlan) GetId() int {
+	return cinv.Id
+}
+
+// GetLink returns the Link field value.
+func (cinv *CloudInitNetworkVlan) GetLink() string {
+	return cinv.Link
+}
@@ -0,0 +1,24 @@
+package cloud_init
+
+import (
+	"testing"
+)
+
+// TestCloudInitNetworkVlanGetters tests the getters of the CloudInitNetworkVlan configuration.
+func TestCloudInitNetworkVlanGetters(t *testing.T) {
+	config := &CloudInitNetworkVlan{
+		Name: "vlan10",
+		Id:   10,
+		Link: "bond0",
+	}
+
+	if config.GetName() != config.Name {
+		t.Errorf("GetName() = %s; want %s", config.GetName(), config.Name)
+	}
+	if config.GetId() != config.Id {
+		t.Errorf("GetId() = %d; want %d", config.GetId(), config.Id)
+	}
+	if config.GetLink() != config.Link {
+		t.Errorf("GetLink() = %s; want %s", config.GetLink(), config.Link)
+	}
+}
@@ -0,0 +1,53 @@
+package cloud_init
+
+import "time"
+
+// CloudInitSshKeySources is a struct that represents the sources of SSH keys offered for the cloud-init user.
+type CloudInitSshKeySources struct {
+	// Directories is a list of directories that are scanned for `*.pub` files.
+	Directories []string `json:"directories" yaml:"directories" toml:"directories" mapstructure:"directories"`
+	// AuthorizedKeysFiles is a list of authorized_keys files.
+	AuthorizedKeysFiles []string `json:"authorized_keys_files" yaml:"authorized_keys_files" toml:"authorized_keys_files" mapstructure:"authorized_keys_files"`
+	// Urls is a list of URLs that serve keys in the authorized_keys format (for example, https://github.com/<user>.keys).
+	Urls []string `json:"urls" yaml:"urls" toml:"urls" mapstructure:"urls"`
+	// CacheDirectory is the directory where keys fetched from URLs are cached.
+	CacheDirectory string `json:"cache_directory" yaml:"cache_directory" toml:"cache_directory" mapstructure:"cache_directory"`
+	// CacheTtl is the duration keys fetched from URLs are cached for (for example, 1h).
+	CacheTtl string `json:"cache_ttl" yaml:"cache_ttl" toml:"cache_ttl" mapstructure:"cache_ttl"`
+}
+
+// InitializeCloudInitSshKeySourcesWithDefaults initializes a CloudInitSshKeySources struct with default values.
+func InitializeCloudInitSshKeySourcesWithDefaults() *CloudInitSshKeySources {
+	return &CloudInitSshKeySources{
+		Directories:         []string{"/root/.ssh"},
+		AuthorizedKeysFiles: []string{},
+		Urls:                []string{},
+		CacheDirectory:      "/var/cache/ptm/ssh-keys",
+		CacheTtl:            "1h",
+	}
+}
+
+// GetDirectories returns the Directories field value.
+func (ciks *CloudInitSshKeySources) GetDirectories() []string {
+	return ciks.Directories
+}
+
+// GetAuthorizedKeysFiles returns the AuthorizedKeysFiles field value.
+func (ciks *CloudInitSshKeySources) GetAuthorizedKeysFiles() []string {
+	return ciks.AuthorizedKeysFiles
+}
+
+// GetUrls returns the Urls field value.
+func (ciks *CloudInitSshKeySources) GetUrls() []string {
+	return ciks.Urls
+}
+
+// GetCacheDirectory returns the CacheDirectory field value.
+func (ciks *CloudInitSshKeySources) GetCacheDirectory() string {
+	return ciks.CacheDirectory
+}
+
+// GetCacheTtl returns the CacheTtl field value as a duration.
+func (ciks *CloudInitSshKeySources) GetCacheTtl() (time.Duration, error) {
+	return time.ParseDuration(ciks.CacheTtl)
+}
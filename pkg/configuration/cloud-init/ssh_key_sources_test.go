@@ -0,0 +1,62 @@
+package cloud_init
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestInitializeCloudInitSshKeySourcesWithDefaults tests the initialization of the CloudInitSshKeySources with default values.
+func TestInitializeCloudInitSshKeySourcesWithDefaults(t *testing.T) {
+	config := InitializeCloudInitSshKeySourcesWithDefaults()
+
+	if !reflect.DeepEqual(config.Directories, []string{"/root/.ssh"}) {
+		t.Errorf("Expected Directories to be [/root/.ssh], got %v", config.Directories)
+	}
+	if len(config.AuthorizedKeysFiles) != 0 {
+		t.Errorf("Expected AuthorizedKeysFiles to be empty, got %v", config.AuthorizedKeysFiles)
+	}
+	if len(config.Urls) != 0 {
+		t.Errorf("Expected Urls to be empty, got %v", config.Urls)
+	}
+	if config.CacheDirectory != "/var/cache/ptm/ssh-keys" {
+		t.Errorf("Expected CacheDirectory to be /var/cache/ptm/ssh-keys, got %s", config.CacheDirectory)
+	}
+	if config.CacheTtl != "1h" {
+		t.Errorf("Expected CacheTtl to be 1h, got %s", config.CacheTtl)
+	}
+}
+
+// TestCloudInitSshKeySourcesGetters tests the getters of the CloudInitSshKeySources configuration.
+func TestCloudInitSshKeySourcesGetters(t *testing.T) {
+	config := &CloudInitSshKeySources{
+		Directories:         []string{"/root/.ssh", "/etc/ptm/keys"},
+		AuthorizedKeysFiles: []string{"/root/.ssh/authorized_keys"},
+		Urls:                []string{"https://github.com/administrator.keys"},
+		CacheDirectory:      "/tmp/ptm-keys",
+		CacheTtl:            "30m",
+	}
+
+	if !reflect.DeepEqual(config.GetDirectories(), config.Directories) {
+		t.Errorf("GetDirectories() = %v; want %v", config.GetDirectories(), config.Directories)
+	}
+	if !reflect.DeepEqual(config.GetAuthorizedKeysFiles(), config.AuthorizedKeysFiles) {
+		t.Errorf("GetAuthorizedKeysFiles() = %v; want %v", config.GetAuthorizedKeysFiles(), config.AuthorizedKeysFiles)
+	}
+	if !reflect.DeepEqual(config.GetUrls(), config.Urls) {
+		t.Errorf("GetUrls() = %v; want %v", config.GetUrls(), config.Urls)
+	}
+	if config.GetCacheDirectory() != config.CacheDirectory {
+		t.Errorf("GetCacheDirectory() = %s; want %s", config.GetCacheDirectory(), config.CacheDirectory)
+	}
+
+	ttl, err := config.GetCacheTtl()
+	if err != nil || ttl != 30*time.Minute {
+		t.Errorf("GetCacheTtl() = %v, %v; want %v", ttl, err, 30*time.Minute)
+	}
+
+	config.CacheTtl = "invalid"
+	if _, err := config.GetCacheTtl(); err == nil {
+		t.Errorf("GetCacheTtl() returned nil error for invalid duration")
+	}
+}
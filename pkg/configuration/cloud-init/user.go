@@ -0,0 +1,47 @@
+package cloud_init
+
+// CloudInitUser is a struct that represents an additional user created by cloud-init.
+type CloudInitUser struct {
+	// Name is the name of the user.
+	Name string `json:"name" yaml:"name" toml:"name" mapstructure:"name"`
+	// SshAuthorizedKeys is a list of SSH keys (or paths to files with keys) that will be added to the user.
+	SshAuthorizedKeys []string `json:"ssh_authorized_keys" yaml:"ssh_authorized_keys" toml:"ssh_authorized_keys" mapstructure:"ssh_authorized_keys"`
+	// Groups is a list of supplementary groups of the user.
+	Groups []string `json:"groups" yaml:"groups" toml:"groups" mapstructure:"groups"`
+	// Shell is the login shell of the user (distribution default if empty).
+	Shell string `json:"shell" yaml:"shell" toml:"shell" mapstructure:"shell"`
+	// Sudo is the sudo rule of the user (for example, ALL=(ALL) NOPASSWD:ALL, no sudo access if empty).
+	Sudo string `json:"sudo" yaml:"sudo" toml:"sudo" mapstructure:"sudo"`
+	// LockPasswd is a flag that indicates whether password login should be disabled for the user.
+	LockPasswd bool `json:"lock_passwd" yaml:"lock_passwd" toml:"lock_passwd" mapstructure:"lock_passwd"`
+}
+
+// GetName returns the Name field value.
+func (ciu *CloudInitUser) GetName() string {
+	return ciu.Name
+}
+
+// GetSshAuthorizedKeys returns the SshAuthorizedKeys field value.
+func (ciu *CloudInitUser) GetSshAuthorizedKeys() []string {
+	return ciu.SshAuthorizedKeys
+}
+
+// GetGroups returns the Groups field value.
+func (ciu *CloudInitUser) GetGroups() []string {
+	return ciu.Groups
+}
+
+// GetShell returns the Shell field value.
+func (ciu *CloudInitUser) GetShell() string {
+	return ciu.Shell
+}
+
+// GetSudo returns the Sudo field value.
+func (ciu *CloudInitUser) GetSudo() string {
+	return ciu.Sudo
+}
+
+// GetLockPasswd returns the LockPasswd field value.
+func (ciu *CloudInitUser) GetLockPasswd() bool {
+	return ciu.LockPasswd
+}
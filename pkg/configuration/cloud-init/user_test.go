@@ -0,0 +1,37 @@
+package cloud_init
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCloudInitUserGetters tests the getters of the CloudInitUser configuration.
+func TestCloudInitUserGetters(t *testing.T) {
+	config := &CloudInitUser{
+		Name:              "ops",
+		SshAuthorizedKeys: []string{"/etc/ptm/keys/ops.pub"},
+		Groups:            []string{"sudo", "adm"},
+		Shell:             "/bin/bash",
+		Sudo:              "ALL=(ALL) NOPASSWD:ALL",
+		LockPasswd:        true,
+	}
+
+	if config.GetName() != config.Name {
+		t.Errorf("GetName() = %s; want %s", config.GetName(), config.Name)
+	}
+	if !reflect.DeepEqual(config.GetSshAuthorizedKeys(), config.SshAuthorizedKeys) {
+		t.Errorf("GetSshAuthorizedKeys() = %v; want %v", config.GetSshAuthorizedKeys(), config.SshAuthorizedKeys)
+	}
+	if !reflect.DeepEqual(config.GetGroups(), config.Groups) {
+		t.Errorf("GetGroups() = %v; want %v", config.GetGroups(), config.Groups)
+	}
+	if config.GetShell() != config.Shell {
+		t.Errorf("GetShell() = %s; want %s", config.GetShell(), config.Shell)
+	}
+	if config.GetSudo() != config.Sudo {
+		t.Errorf("GetSudo() = %s; want %s", config.GetSudo(), config.Sudo)
+	}
+	if config.GetLockPasswd() != config.LockPasswd {
+		t.Errorf("GetLockPasswd() = %v; want %v", config.GetLockPasswd(), config.LockPasswd)
+	}
+}
@@ -3,10 +3,13 @@ package configuration
 import (
 	bi "github.com/darki73/ptm/pkg/configuration/base-image"
 	ci "github.com/darki73/ptm/pkg/configuration/cloud-init"
+	dc "github.com/darki73/ptm/pkg/configuration/distribution"
 	"github.com/darki73/ptm/pkg/configuration/downloader"
+	"github.com/darki73/ptm/pkg/configuration/hookscript"
 	"github.com/darki73/ptm/pkg/configuration/qemu"
 	"github.com/darki73/ptm/pkg/configuration/repositories"
 	uu "github.com/darki73/ptm/pkg/configuration/unattended-upgrades"
+	"github.com/darki73/ptm/pkg/distributions"
 	"github.com/darki73/ptm/pkg/log"
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
@@ -28,8 +31,12 @@ type Configuration struct {
 	BaseImage *bi.Configuration `json:"base_image" yaml:"base_image" toml:"base_image" mapstructure:"base_image"`
 	// CloudInit is a reference to the CloudInit configuration.
 	CloudInit *ci.Configuration `json:"cloud_init" yaml:"cloud_init" toml:"cloud_init" mapstructure:"cloud_init"`
+	// Distributions is a list of distributions defined in the configuration file (replacing or extending built-in distributions with the same name).
+	Distributions []*dc.Configuration `json:"distributions" yaml:"distributions" toml:"distributions" mapstructure:"distributions"`
 	// Downloader is a reference to the Downloader configuration.
 	Downloader *downloader.Configuration `json:"downloader" yaml:"downloader" toml:"downloader" mapstructure:"downloader"`
+	// Hookscript is a reference to the Hookscript configuration.
+	Hookscript *hookscript.Configuration `json:"hookscript" yaml:"hookscript" toml:"hookscript" mapstructure:"hookscript"`
 	// Qemu is a reference to the Qemu configuration.
 	Qemu *qemu.Configuration `json:"qemu" yaml:"qemu" toml:"qemu" mapstructure:"qemu"`
 	// Repositories is a list of repositories that will be added to the base image.
@@ -54,11 +61,21 @@ func (configuration *Configuration) GetCloudInit() *ci.Configuration {
 	return configuration.CloudInit
 }
 
+// GetDistributions returns the list of distributions defined in the configuration file.
+func (configuration *Configuration) GetDistributions() []*dc.Configuration {
+	return configuration.Distributions
+}
+
 // GetDownloader returns the Downloader configuration.
 func (configuration *Configuration) GetDownloader() *downloader.Configuration {
 	return configuration.Downloader
 }
 
+// GetHookscript returns the Hookscript configuration.
+func (configuration *Configuration) GetHookscript() *hookscript.Configuration {
+	return configuration.Hookscript
+}
+
 // GetQemu returns the Qemu configuration.
 func (configuration *Configuration) GetQemu() *qemu.Configuration {
 	return configuration.Qemu
@@ -108,7 +125,9 @@ func LoadConfiguration(options *Options) error {
 	configuration = &Configuration{
 		BaseImage:          bi.InitializeWithDefaults(),
 		CloudInit:          ci.InitializeWithDefaults(),
+		Distributions:      []*dc.Configuration{},
 		Downloader:         downloader.InitializeWithDefaults(),
+		Hookscript:         hookscript.InitializeWithDefaults(),
 		Qemu:               qemu.InitializeWithDefaults(),
 		Repositories:       []*repositories.Configuration{},
 		UnattendedUpgrades: uu.InitializeWithDefaults(),
@@ -121,6 +140,10 @@ func LoadConfiguration(options *Options) error {
 		return err
 	}
 
+	if err := configuration.GetBaseImage().Validate(); err != nil {
+		return err
+	}
+
 	setBasePackages()
 
 	viper.WatchConfig()
@@ -173,6 +196,16 @@ func configurationReloadHandler(event fsnotify.Event) {
 		)
 	}
 
+	if err := configuration.GetBaseImage().Validate(); err != nil {
+		log.ErrorfWithFields(
+			"error validating configuration: %s",
+			log.FieldsMap{
+				"source": "configuration",
+			},
+			err,
+		)
+	}
+
 	setBasePackages()
 
 	if err := setLogLevel(); err != nil {
@@ -188,21 +221,12 @@ func configurationReloadHandler(event fsnotify.Event) {
 	ChangeChannel <- true
 }
 
-// setBasePackages sets the base packages.
+// setBasePackages sets the base packages (defaults depend on the package manager of the base image distribution).
 func setBasePackages() {
 	if len(configuration.BasePackages) > 0 {
 		return
 	}
-	configuration.BasePackages = []string{
-		"apt-transport-https",
-		"aptitude",
-		"ca-certificates",
-		"curl",
-		"htop",
-		"jq",
-		"mc",
-		"software-properties-common",
-		"qemu-guest-agent",
-		"wget",
-	}
+	configuration.BasePackages = distributions.GetDefaultBasePackages(
+		distributions.GetPackageManagerForDistribution(configuration.BaseImage.GetDistribution(), configuration.Distributions),
+	)
 }
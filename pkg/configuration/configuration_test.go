@@ -3,7 +3,9 @@ package configuration
 import (
 	bi "github.com/darki73/ptm/pkg/configuration/base-image"
 	ci "github.com/darki73/ptm/pkg/configuration/cloud-init"
+	dc "github.com/darki73/ptm/pkg/configuration/distribution"
 	"github.com/darki73/ptm/pkg/configuration/downloader"
+	"github.com/darki73/ptm/pkg/configuration/hookscript"
 	"github.com/darki73/ptm/pkg/configuration/repositories"
 	uu "github.com/darki73/ptm/pkg/configuration/unattended-upgrades"
 	"reflect"
@@ -15,7 +17,9 @@ func TestConfigurationGetters(t *testing.T) {
 	expectedConfig := &Configuration{
 		BaseImage:          &bi.Configuration{},
 		CloudInit:          &ci.Configuration{},
+		Distributions:      []*dc.Configuration{{Name: "oracle"}},
 		Downloader:         &downloader.Configuration{SaveTo: "/test"},
+		Hookscript:         &hookscript.Configuration{},
 		Repositories:       []*repositories.Configuration{},
 		UnattendedUpgrades: &uu.Configuration{},
 		BasePackages:       []string{"package1", "package2"},
@@ -32,9 +36,15 @@ func TestConfigurationGetters(t *testing.T) {
 	if !reflect.DeepEqual(configuration.GetCloudInit(), expectedConfig.CloudInit) {
 		t.Errorf("GetCloudInit() did not return expected value")
 	}
+	if !reflect.DeepEqual(configuration.GetDistributions(), expectedConfig.Distributions) {
+		t.Errorf("GetDistributions() did not return expected value")
+	}
 	if !reflect.DeepEqual(configuration.GetDownloader(), expectedConfig.Downloader) {
 		t.Errorf("GetDownloader() did not return expected value")
 	}
+	if !reflect.DeepEqual(configuration.GetHookscript(), expectedConfig.Hookscript) {
+		t.Errorf("GetHookscript() did not return expected value")
+	}
 	if !reflect.DeepEqual(configuration.GetRepositories(), expectedConfig.Repositories) {
 		t.Errorf("GetRepositories() did not return expected value")
 	}
@@ -0,0 +1,125 @@
+package distribution
+
+import "fmt"
+
+// Configuration represents the configuration of a user-defined distribution (or an override of a built-in one).
+// Templates accept the `{release}`, `{version}`, `{architecture}`, `{format}` and `{variant}` placeholders, URL and checksum templates also accept `{image}`.
+type Configuration struct {
+	// Name is the name of the distribution (as used in `base_image.distribution`).
+	Name string `json:"name" yaml:"name" toml:"name" mapstructure:"name"`
+	// Url is the template of the URL the image is downloaded from.
+	Url string `json:"url" yaml:"url" toml:"url" mapstructure:"url"`
+	// ImageName is the template of the name of the image.
+	ImageName string `json:"image_name" yaml:"image_name" toml:"image_name" mapstructure:"image_name"`
+	// Checksum is the template of the checksum file, either a file name next to the image or a complete URL.
+	Checksum string `json:"checksum" yaml:"checksum" toml:"checksum" mapstructure:"checksum"`
+	// Signature is the template of the detached signature of the checksum file, either a file name next to the image or a complete URL.
+	Signature string `json:"signature" yaml:"signature" toml:"signature" mapstructure:"signature"`
+	// Releases is a map of releases to versions of the distribution.
+	Releases map[string]string `json:"releases" yaml:"releases" toml:"releases" mapstructure:"releases"`
+	// Architectures is a list of architectures the images are published for.
+	Architectures []string `json:"architectures" yaml:"architectures" toml:"architectures" mapstructure:"architectures"`
+	// Formats is a list of image formats the images are published in.
+	Formats []string `json:"formats" yaml:"formats" toml:"formats" mapstructure:"formats"`
+	// Variant is the name of the variant of the published images.
+	Variant string `json:"variant" yaml:"variant" toml:"variant" mapstructure:"variant"`
+	// PackageManager is the package manager used to customize the images (apt / dnf / zypper / apk / pacman).
+	PackageManager string `json:"package_manager" yaml:"package_manager" toml:"package_manager" mapstructure:"package_manager"`
+	// InitSystem is the init system of the images (systemd / openrc).
+	InitSystem string `json:"init_system" yaml:"init_system" toml:"init_system" mapstructure:"init_system"`
+	// SELinux is a boolean value that indicates if the images ship with SELinux enabled (relabeled after customization).
+	SELinux bool `json:"selinux" yaml:"selinux" toml:"selinux" mapstructure:"selinux"`
+}
+
+// GetName returns the name of the distribution.
+func (configuration *Configuration) GetName() string {
+	return configuration.Name
+}
+
+// GetUrl returns the template of the URL the image is downloaded from.
+func (configuration *Configuration) GetUrl() string {
+	return configuration.Url
+}
+
+// GetImageName returns the template of the name of the image.
+func (configuration *Configuration) GetImageName() string {
+	return configuration.ImageName
+}
+
+// GetChecksum returns the template of the checksum file.
+func (configuration *Configuration) GetChecksum() string {
+	return configuration.Checksum
+}
+
+// GetSignature returns the template of the detached signature of the checksum file.
+func (configuration *Configuration) GetSignature() string {
+	return configuration.Signature
+}
+
+// GetReleases returns the map of releases to versions of the distribution.
+func (configuration *Configuration) GetReleases() map[string]string {
+	return configuration.Releases
+}
+
+// GetArchitectures returns the list of architectures the images are published for.
+func (configuration *Configuration) GetArchitectures() []string {
+	return configuration.Architectures
+}
+
+// GetFormats returns the list of image formats the images are published in.
+func (configuration *Configuration) GetFormats() []string {
+	return configuration.Formats
+}
+
+// GetVariant returns the name of the variant of the published images (`generic` if not set).
+func (configuration *Configuration) GetVariant() string {
+	if configuration.Variant == "" {
+		return "generic"
+	}
+	return configuration.Variant
+}
+
+// GetPackageManager returns the package manager used to customize the images.
+func (configuration *Configuration) GetPackageManager() string {
+	return configuration.PackageManager
+}
+
+// GetInitSystem returns the init system of the images (`systemd` if not set).
+func (configuration *Configuration) GetInitSystem() string {
+	if configuration.InitSystem == "" {
+		return "systemd"
+	}
+	return configuration.InitSystem
+}
+
+// GetSELinux returns true if the images ship with SELinux enabled.
+func (configuration *Configuration) GetSELinux() bool {
+	return configuration.SELinux
+}
+
+// IsConfigurationValid returns true if all the keys required to download an image are set.
+func (configuration *Configuration) IsConfigurationValid() (bool, error) {
+	if configuration.Name == "" {
+		return false, fmt.Errorf("distribution is missing `name`")
+	}
+
+	required := []struct {
+		key string
+		set bool
+	}{
+		{"url", configuration.Url != ""},
+		{"image_name", configuration.ImageName != ""},
+		{"releases", len(configuration.Releases) > 0},
+		{"architectures", len(configuration.Architectures) > 0},
+		{"formats", len(configuration.Formats) > 0},
+		{"package_manager", configuration.PackageManager != ""},
+	}
+
+	for _, field := range required {
+		if !field.set {
+			return false, fmt.Errorf("distribution `%s` is missing `%s`", configuration.Name, field.key)
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,120 @@
+package distribution
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestConfigurationGetters tests the getters of the distribution configuration.
+func TestConfigurationGetters(t *testing.T) {
+	config := &Configuration{
+		Name:           "oracle",
+		Url:            "https://yum.oracle.com/templates/OracleLinux/OL{version}/u4/{architecture}/",
+		ImageName:      "OL{version}U4_{architecture}-kvm-b234.{format}",
+		Checksum:       "{image}.sha256",
+		Signature:      "{image}.sha256.asc",
+		Releases:       map[string]string{"ol9": "9"},
+		Architectures:  []string{"x86_64"},
+		Formats:        []string{"qcow2"},
+		Variant:        "kvm",
+		PackageManager: "dnf",
+		InitSystem:     "systemd",
+		SELinux:        true,
+	}
+
+	if config.GetName() != config.Name {
+		t.Errorf("GetName() = %s, want %s", config.GetName(), config.Name)
+	}
+	if config.GetUrl() != config.Url {
+		t.Errorf("GetUrl() = %s, want %s", config.GetUrl(), config.Url)
+	}
+	if config.GetImageName() != config.ImageName {
+		t.Errorf("GetImageName() = %s, want %s", config.GetImageName(), config.ImageName)
+	}
+	if config.GetChecksum() != config.Checksum {
+		t.Errorf("GetChecksum() = %s, want %s", config.GetChecksum(), config.Checksum)
+	}
+	if config.GetSignature() != config.Signature {
+		t.Errorf("GetSignature() = %s, want %s", config.GetSignature(), config.Signature)
+	}
+	if !reflect.DeepEqual(config.GetReleases(), config.Releases) {
+		t.Errorf("GetReleases() = %v, want %v", config.GetReleases(), config.Releases)
+	}
+	if !reflect.DeepEqual(config.GetArchitectures(), config.Architectures) {
+		t.Errorf("GetArchitectures() = %v, want %v", config.GetArchitectures(), config.Architectures)
+	}
+	if !reflect.DeepEqual(config.GetFormats(), config.Formats) {
+		t.Errorf("GetFormats() = %v, want %v", config.GetFormats(), config.Formats)
+	}
+	if config.GetVariant() != config.Variant {
+		t.Errorf("GetVariant() = %s, want %s", config.GetVariant(), config.Variant)
+	}
+	if config.GetPackageManager() != config.PackageManager {
+		t.Errorf("GetPackageManager() = %s, want %s", config.GetPackageManager(), config.PackageManager)
+	}
+	if config.GetInitSystem() != config.InitSystem {
+		t.Errorf("GetInitSystem() = %s, want %s", config.GetInitSystem(), config.InitSystem)
+	}
+	if !config.GetSELinux() {
+		t.Errorf("GetSELinux() = %v, want %v", config.GetSELinux(), true)
+	}
+}
+
+// TestConfigurationDefaults tests the values used for optional keys which are not set.
+func TestConfigurationDefaults(t *testing.T) {
+	config := &Configuration{}
+
+	if config.GetVariant() != "generic" {
+		t.Errorf("GetVariant() = %s, want generic", config.GetVariant())
+	}
+	if config.GetInitSystem() != "systemd" {
+		t.Errorf("GetInitSystem() = %s, want systemd", config.GetInitSystem())
+	}
+}
+
+// TestIsConfigurationValid tests the validation of the distribution configuration.
+func TestIsConfigurationValid(t *testing.T) {
+	valid := func() *Configuration {
+		return &Configuration{
+			Name:           "oracle",
+			Url:            "https://yum.oracle.com/templates/OracleLinux/OL{version}/u4/{architecture}/",
+			ImageName:      "OL{version}U4_{architecture}-kvm-b234.{format}",
+			Releases:       map[string]string{"ol9": "9"},
+			Architectures:  []string{"x86_64"},
+			Formats:        []string{"qcow2"},
+			PackageManager: "dnf",
+		}
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(config *Configuration)
+		expected string
+	}{
+		{"valid", func(config *Configuration) {}, ""},
+		{"missing name", func(config *Configuration) { config.Name = "" }, "distribution is missing `name`"},
+		{"missing url", func(config *Configuration) { config.Url = "" }, "distribution `oracle` is missing `url`"},
+		{"missing releases", func(config *Configuration) { config.Releases = nil }, "distribution `oracle` is missing `releases`"},
+		{"missing formats", func(config *Configuration) { config.Formats = []string{} }, "distribution `oracle` is missing `formats`"},
+		{"missing package manager", func(config *Configuration) { config.PackageManager = "" }, "distribution `oracle` is missing `package_manager`"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := valid()
+			test.modify(config)
+
+			isValid, err := config.IsConfigurationValid()
+			if test.expected == "" {
+				if !isValid || err != nil {
+					t.Errorf("IsConfigurationValid() = %v, %v, want true, nil", isValid, err)
+				}
+				return
+			}
+
+			if isValid || err == nil || err.Error() != test.expected {
+				t.Errorf("IsConfigurationValid() = %v, %v, want false, %s", isValid, err, test.expected)
+			}
+		})
+	}
+}
@@ -4,12 +4,18 @@ package downloader
 type Configuration struct {
 	// SaveTo is the path to save the downloaded image.
 	SaveTo string `json:"save_to" yaml:"save_to" xml:"save_to" toml:"save_to" mapstructure:"save_to"`
+	// VerifyChecksum is a boolean value that indicates if the checksum of the downloaded image is verified.
+	VerifyChecksum bool `json:"verify_checksum" yaml:"verify_checksum" xml:"verify_checksum" toml:"verify_checksum" mapstructure:"verify_checksum"`
+	// Keyring is the path to the pinned GPG keyring the signature of the checksum file is verified against (empty disables verification).
+	Keyring string `json:"keyring" yaml:"keyring" xml:"keyring" toml:"keyring" mapstructure:"keyring"`
 }
 
 // InitializeWithDefaults initializes the configuration struct with default values.
 func InitializeWithDefaults() *Configuration {
 	return &Configuration{
-		SaveTo: "/etc/ptm/images",
+		SaveTo:         "/etc/ptm/images",
+		VerifyChecksum: true,
+		Keyring:        "",
 	}
 }
 
@@ -17,3 +23,13 @@ func InitializeWithDefaults() *Configuration {
 func (configuration *Configuration) GetSaveTo() string {
 	return configuration.SaveTo
 }
+
+// GetVerifyChecksum returns a boolean value that indicates if the checksum of the downloaded image is verified.
+func (configuration *Configuration) GetVerifyChecksum() bool {
+	return configuration.VerifyChecksum
+}
+
+// GetKeyring returns the path to the pinned GPG keyring the signature of the checksum file is verified against.
+func (configuration *Configuration) GetKeyring() string {
+	return configuration.Keyring
+}
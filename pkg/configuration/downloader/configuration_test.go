@@ -21,3 +21,14 @@ func TestGetSaveTo(t *testing.T) {
 		t.Errorf("GetSaveTo() = %v, want %v", saveTo, expectedSaveTo)
 	}
 }
+
+// TestVerificationDefaults tests if the checksum is verified and the signature is not by default.
+func TestVerificationDefaults(t *testing.T) {
+	config := InitializeWithDefaults()
+	if !config.GetVerifyChecksum() {
+		t.Errorf("GetVerifyChecksum() = %v, want %v", config.GetVerifyChecksum(), true)
+	}
+	if config.GetKeyring() != "" {
+		t.Errorf("GetKeyring() = %v, want empty", config.GetKeyring())
+	}
+}
@@ -0,0 +1,80 @@
+package hookscript
+
+// Configuration is the structure that holds configuration for the virtual machine hookscript.
+type Configuration struct {
+	// Enabled is the flag that enables generation and attachment of the hookscript.
+	Enabled bool `json:"enabled" yaml:"enabled" toml:"enabled" mapstructure:"enabled"`
+	// Name is the file name of the hookscript in the snippets storage.
+	Name string `json:"name" yaml:"name" toml:"name" mapstructure:"name"`
+	// Storage is the name of the storage with snippets content enabled (first available one is used if empty).
+	Storage string `json:"storage" yaml:"storage" toml:"storage" mapstructure:"storage"`
+	// PreStart is the list of commands executed before the virtual machine is started.
+	PreStart []string `json:"pre_start" yaml:"pre_start" toml:"pre_start" mapstructure:"pre_start"`
+	// PostStart is the list of commands executed after the virtual machine is started.
+	PostStart []string `json:"post_start" yaml:"post_start" toml:"post_start" mapstructure:"post_start"`
+	// PreStop is the list of commands executed before the virtual machine is stopped.
+	PreStop []string `json:"pre_stop" yaml:"pre_stop" toml:"pre_stop" mapstructure:"pre_stop"`
+	// PostStop is the list of commands executed after the virtual machine is stopped.
+	PostStop []string `json:"post_stop" yaml:"post_stop" toml:"post_stop" mapstructure:"post_stop"`
+}
+
+// InitializeWithDefaults initializes the configuration with default values.
+func InitializeWithDefaults() *Configuration {
+	return &Configuration{
+		Enabled:   false,
+		Name:      "",
+		Storage:   "",
+		PreStart:  []string{},
+		PostStart: []string{},
+		PreStop:   []string{},
+		PostStop:  []string{},
+	}
+}
+
+// GetEnabled returns the flag that enables generation and attachment of the hookscript.
+func (configuration *Configuration) GetEnabled() bool {
+	return configuration.Enabled
+}
+
+// GetName returns the file name of the hookscript in the snippets storage.
+func (configuration *Configuration) GetName() string {
+	return configuration.Name
+}
+
+// GetStorage returns the name of the storage with snippets content enabled.
+func (configuration *Configuration) GetStorage() string {
+	return configuration.Storage
+}
+
+// GetPreStart returns the list of commands executed before the virtual machine is started.
+func (configuration *Configuration) GetPreStart() []string {
+	return configuration.PreStart
+}
+
+// GetPostStart returns the list of commands executed after the virtual machine is started.
+func (configuration *Configuration) GetPostStart() []string {
+	return configuration.PostStart
+}
+
+// GetPreStop returns the list of commands executed before the virtual machine is stopped.
+func (configuration *Configuration) GetPreStop() []string {
+	return configuration.PreStop
+}
+
+// GetPostStop returns the list of commands executed after the virtual machine is stopped.
+func (configuration *Configuration) GetPostStop() []string {
+	return configuration.PostStop
+}
+
+// HasActions returns true if at least one phase has commands to execute.
+func (configuration *Configuration) HasActions() bool {
+	return len(configuration.PreStart) > 0 ||
+		len(configuration.PostStart) > 0 ||
+		len(configuration.PreStop) > 0 ||
+		len(configuration.PostStop) > 0
+}
+
+// IsConfigured returns true if the hookscript should be generated and attached.
+func (configuration *Configuration) IsConfigured() bool {
+	return configuration.Enabled && configuration.HasActions()
+}
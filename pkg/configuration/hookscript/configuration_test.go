@@ -0,0 +1,40 @@
+package hookscript
+
+import (
+	"testing"
+)
+
+// TestInitializeWithDefaults tests the InitializeWithDefaults function.
+func TestInitializeWithDefaults(t *testing.T) {
+	configuration := InitializeWithDefaults()
+
+	if configuration.GetEnabled() {
+		t.Errorf("InitializeWithDefaults() Enabled = %v, want %v", configuration.GetEnabled(), false)
+	}
+
+	if configuration.HasActions() {
+		t.Errorf("InitializeWithDefaults() HasActions = %v, want %v", configuration.HasActions(), false)
+	}
+}
+
+// TestIsConfigured tests the IsConfigured function.
+func TestIsConfigured(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configuration *Configuration
+		expected      bool
+	}{
+		{"Disabled", &Configuration{Enabled: false, PreStart: []string{"echo"}}, false},
+		{"Enabled Without Actions", &Configuration{Enabled: true}, false},
+		{"Enabled With Pre Start", &Configuration{Enabled: true, PreStart: []string{"echo"}}, true},
+		{"Enabled With Post Stop", &Configuration{Enabled: true, PostStop: []string{"echo"}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := tc.configuration.IsConfigured(); result != tc.expected {
+				t.Errorf("IsConfigured() = %v, want %v", result, tc.expected)
+			}
+		})
+	}
+}
@@ -8,23 +8,35 @@ type Configuration struct {
 	Name string `json:"name" yaml:"name" toml:"name" mapstructure:"name"`
 	// Image is the path to the image used to create the virtual machine.
 	Image string `json:"image" yaml:"image" toml:"image" mapstructure:"image"`
+	// Architecture is the architecture of the virtual machine (defaults to the base image architecture).
+	Architecture string `json:"architecture" yaml:"architecture" toml:"architecture" mapstructure:"architecture"`
 	// Network is the reference to the network configuration.
 	Network *QemuNetwork `json:"network" yaml:"network" toml:"network" mapstructure:"network"`
 	// Resources is the reference to the resources configuration.
 	Resources *QemuResources `json:"resources" yaml:"resources" toml:"resources" mapstructure:"resources"`
 	// Storage is the reference to the storage configuration.
 	Storage *QemuStorage `json:"storage" yaml:"storage" toml:"storage" mapstructure:"storage"`
+	// Rng is the reference to the VirtIO RNG device configuration.
+	Rng *QemuRng `json:"rng" yaml:"rng" toml:"rng" mapstructure:"rng"`
+	// GuestAgent is the reference to the guest agent configuration.
+	GuestAgent *QemuGuestAgent `json:"guest_agent" yaml:"guest_agent" toml:"guest_agent" mapstructure:"guest_agent"`
+	// Watchdog is the reference to the watchdog device configuration.
+	Watchdog *QemuWatchdog `json:"watchdog" yaml:"watchdog" toml:"watchdog" mapstructure:"watchdog"`
 }
 
 // InitializeWithDefaults initializes the configuration with default values.
 func InitializeWithDefaults() *Configuration {
 	return &Configuration{
-		Identifier: 0,
-		Name:       "",
-		Image:      "",
-		Network:    InitializeQemuNetworkWithDefaults(),
-		Resources:  InitializeQemuResourcesWithDefaults(),
-		Storage:    InitializeQemuStorageWithDefaults(),
+		Identifier:   0,
+		Name:         "",
+		Image:        "",
+		Architecture: "",
+		Network:      InitializeQemuNetworkWithDefaults(),
+		Resources:    InitializeQemuResourcesWithDefaults(),
+		Storage:      InitializeQemuStorageWithDefaults(),
+		Rng:          InitializeQemuRngWithDefaults(),
+		GuestAgent:   InitializeQemuGuestAgentWithDefaults(),
+		Watchdog:     InitializeQemuWatchdogWithDefaults(),
 	}
 }
 
@@ -43,6 +55,11 @@ func (configuration *Configuration) GetImage() string {
 	return configuration.Image
 }
 
+// GetArchitecture returns the architecture of the virtual machine.
+func (configuration *Configuration) GetArchitecture() string {
+	return configuration.Architecture
+}
+
 // GetNetwork returns the reference to the network configuration.
 func (configuration *Configuration) GetNetwork() *QemuNetwork {
 	return configuration.Network
@@ -58,6 +75,21 @@ func (configuration *Configuration) GetStorage() *QemuStorage {
 	return configuration.Storage
 }
 
+// GetRng returns the reference to the VirtIO RNG device configuration.
+func (configuration *Configuration) GetRng() *QemuRng {
+	return configuration.Rng
+}
+
+// GetGuestAgent returns the reference to the guest agent configuration.
+func (configuration *Configuration) GetGuestAgent() *QemuGuestAgent {
+	return configuration.GuestAgent
+}
+
+// GetWatchdog returns the reference to the watchdog device configuration.
+func (configuration *Configuration) GetWatchdog() *QemuWatchdog {
+	return configuration.Watchdog
+}
+
 // IsConfigured returns true if the configuration is configured.
 func (configuration *Configuration) IsConfigured() bool {
 	if configuration.Identifier == 0 {
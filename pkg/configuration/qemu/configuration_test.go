@@ -43,6 +43,14 @@ func TestConfigurationGetImage(t *testing.T) {
 	}
 }
 
+// TestConfigurationGetArchitecture tests the GetArchitecture method.
+func TestConfigurationGetArchitecture(t *testing.T) {
+	config := &Configuration{Architecture: "arm64"}
+	if architecture := config.GetArchitecture(); architecture != "arm64" {
+		t.Errorf("GetArchitecture() = %s, want %s", architecture, "arm64")
+	}
+}
+
 // TestConfigurationGetNetwork tests the GetNetwork method.
 func TestConfigurationGetNetwork(t *testing.T) {
 	network := InitializeQemuNetworkWithDefaults()
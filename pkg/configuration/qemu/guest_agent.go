@@ -0,0 +1,43 @@
+package qemu
+
+// QemuGuestAgent is a structure that holds information for QEMU guest agent configuration.
+type QemuGuestAgent struct {
+	// Enabled indicates whether the QEMU guest agent should be enabled.
+	Enabled bool `json:"enabled" yaml:"enabled" toml:"enabled" mapstructure:"enabled"`
+	// FstrimClonedDisks indicates whether fstrim should be run after moving a disk or migrating the virtual machine.
+	FstrimClonedDisks bool `json:"fstrim_cloned_disks" yaml:"fstrim_cloned_disks" toml:"fstrim_cloned_disks" mapstructure:"fstrim_cloned_disks"`
+	// FreezeFsOnBackup indicates whether guest filesystems should be frozen during backups.
+	FreezeFsOnBackup bool `json:"freeze_fs_on_backup" yaml:"freeze_fs_on_backup" toml:"freeze_fs_on_backup" mapstructure:"freeze_fs_on_backup"`
+	// Type is the guest agent communication channel type (virtio / isa).
+	Type string `json:"type" yaml:"type" toml:"type" mapstructure:"type"`
+}
+
+// InitializeQemuGuestAgentWithDefaults initializes the QemuGuestAgent with default values.
+func InitializeQemuGuestAgentWithDefaults() *QemuGuestAgent {
+	return &QemuGuestAgent{
+		Enabled:           true,
+		FstrimClonedDisks: true,
+		FreezeFsOnBackup:  true,
+		Type:              "virtio",
+	}
+}
+
+// GetEnabled returns whether the QEMU guest agent should be enabled.
+func (qemuGuestAgent *QemuGuestAgent) GetEnabled() bool {
+	return qemuGuestAgent.Enabled
+}
+
+// GetFstrimClonedDisks returns whether fstrim should be run after moving a disk or migrating the virtual machine.
+func (qemuGuestAgent *QemuGuestAgent) GetFstrimClonedDisks() bool {
+	return qemuGuestAgent.FstrimClonedDisks
+}
+
+// GetFreezeFsOnBackup returns whether guest filesystems should be frozen during backups.
+func (qemuGuestAgent *QemuGuestAgent) GetFreezeFsOnBackup() bool {
+	return qemuGuestAgent.FreezeFsOnBackup
+}
+
+// GetType returns the guest agent communication channel type.
+func (qemuGuestAgent *QemuGuestAgent) GetType() string {
+	return qemuGuestAgent.Type
+}
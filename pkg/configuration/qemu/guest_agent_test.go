@@ -0,0 +1,47 @@
+package qemu
+
+import (
+	"testing"
+)
+
+// TestInitializeQemuGuestAgentWithDefaults tests the initialization with default values.
+func TestInitializeQemuGuestAgentWithDefaults(t *testing.T) {
+	qemuGuestAgent := InitializeQemuGuestAgentWithDefaults()
+
+	if !qemuGuestAgent.Enabled {
+		t.Errorf("Expected Enabled to be true, got %t", qemuGuestAgent.Enabled)
+	}
+
+	if !qemuGuestAgent.FstrimClonedDisks {
+		t.Errorf("Expected FstrimClonedDisks to be true, got %t", qemuGuestAgent.FstrimClonedDisks)
+	}
+
+	if !qemuGuestAgent.FreezeFsOnBackup {
+		t.Errorf("Expected FreezeFsOnBackup to be true, got %t", qemuGuestAgent.FreezeFsOnBackup)
+	}
+
+	if qemuGuestAgent.Type != "virtio" {
+		t.Errorf("Expected Type to be 'virtio', got %s", qemuGuestAgent.Type)
+	}
+}
+
+// TestGuestAgentGetters tests the getters of the QemuGuestAgent.
+func TestGuestAgentGetters(t *testing.T) {
+	qemuGuestAgent := &QemuGuestAgent{Enabled: false, FstrimClonedDisks: false, FreezeFsOnBackup: false, Type: "isa"}
+
+	if enabled := qemuGuestAgent.GetEnabled(); enabled {
+		t.Errorf("GetEnabled() = %t, want %t", enabled, false)
+	}
+
+	if trim := qemuGuestAgent.GetFstrimClonedDisks(); trim {
+		t.Errorf("GetFstrimClonedDisks() = %t, want %t", trim, false)
+	}
+
+	if freeze := qemuGuestAgent.GetFreezeFsOnBackup(); freeze {
+		t.Errorf("GetFreezeFsOnBackup() = %t, want %t", freeze, false)
+	}
+
+	if agentType := qemuGuestAgent.GetType(); agentType != "isa" {
+		t.Errorf("GetType() = %s, want %s", agentType, "isa")
+	}
+}
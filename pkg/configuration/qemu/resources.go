@@ -15,14 +15,47 @@ type QemuResources struct {
 	Memory string `json:"memory" yaml:"memory" toml:"memory" mapstructure:"memory"`
 	// CpuType is the CPU type to use.
 	CpuType string `json:"cpu_type" yaml:"cpu_type" toml:"cpu_type" mapstructure:"cpu_type"`
+	// Sockets is the number of CPU sockets to use.
+	Sockets int `json:"sockets" yaml:"sockets" toml:"sockets" mapstructure:"sockets"`
+	// VCpus is the number of hotplugged vCPUs to start the virtual machine with.
+	VCpus int `json:"vcpus" yaml:"vcpus" toml:"vcpus" mapstructure:"vcpus"`
+	// Numa is the flag that enables NUMA.
+	Numa bool `json:"numa" yaml:"numa" toml:"numa" mapstructure:"numa"`
+	// CpuFlags is the list of CPU flags to enable (+flag) or disable (-flag).
+	CpuFlags []string `json:"cpu_flags" yaml:"cpu_flags" toml:"cpu_flags" mapstructure:"cpu_flags"`
+	// CpuLimit is the limit of CPU usage (0 means unlimited).
+	CpuLimit float64 `json:"cpu_limit" yaml:"cpu_limit" toml:"cpu_limit" mapstructure:"cpu_limit"`
+	// CpuUnits is the CPU weight of the virtual machine.
+	CpuUnits int `json:"cpu_units" yaml:"cpu_units" toml:"cpu_units" mapstructure:"cpu_units"`
+	// Affinity is the list of host CPUs the virtual machine is allowed to run on (example: 0-3,8).
+	Affinity string `json:"affinity" yaml:"affinity" toml:"affinity" mapstructure:"affinity"`
+	// Ballooning is the flag that enables the memory balloon device.
+	Ballooning bool `json:"ballooning" yaml:"ballooning" toml:"ballooning" mapstructure:"ballooning"`
+	// MinimumMemory is the minimum amount of memory the balloon device is allowed to shrink to.
+	MinimumMemory string `json:"minimum_memory" yaml:"minimum_memory" toml:"minimum_memory" mapstructure:"minimum_memory"`
+	// Hugepages is the size of hugepages to use (any / 2 / 1024).
+	Hugepages string `json:"hugepages" yaml:"hugepages" toml:"hugepages" mapstructure:"hugepages"`
+	// Hotplug is the list of devices which can be hotplugged (disk / network / usb / memory / cpu / cloudinit).
+	Hotplug []string `json:"hotplug" yaml:"hotplug" toml:"hotplug" mapstructure:"hotplug"`
 }
 
 // InitializeQemuResourcesWithDefaults initializes the QemuResources with default values.
 func InitializeQemuResourcesWithDefaults() *QemuResources {
 	return &QemuResources{
-		Cores:   0,
-		Memory:  "",
-		CpuType: "host",
+		Cores:         0,
+		Memory:        "",
+		CpuType:       "host",
+		Sockets:       0,
+		VCpus:         0,
+		Numa:          false,
+		CpuFlags:      []string{},
+		CpuLimit:      0,
+		CpuUnits:      0,
+		Affinity:      "",
+		Ballooning:    true,
+		MinimumMemory: "",
+		Hugepages:     "",
+		Hotplug:       []string{},
 	}
 }
 
@@ -33,7 +66,92 @@ func (qemuResources *QemuResources) GetCores() int {
 
 // GetMemory returns the amount of memory to use.
 func (qemuResources *QemuResources) GetMemory() (int64, error) {
-	memory := strings.ToLower(qemuResources.Memory)
+	return parseMemory(qemuResources.Memory)
+}
+
+// GetCpuType returns the CPU type to use.
+func (qemuResources *QemuResources) GetCpuType() string {
+	return qemuResources.CpuType
+}
+
+// GetSockets returns the number of CPU sockets to use.
+func (qemuResources *QemuResources) GetSockets() int {
+	return qemuResources.Sockets
+}
+
+// GetVCpus returns the number of hotplugged vCPUs to start the virtual machine with.
+func (qemuResources *QemuResources) GetVCpus() int {
+	return qemuResources.VCpus
+}
+
+// GetNuma returns the flag that enables NUMA.
+func (qemuResources *QemuResources) GetNuma() bool {
+	return qemuResources.Numa
+}
+
+// GetCpuFlags returns the list of CPU flags to enable or disable.
+func (qemuResources *QemuResources) GetCpuFlags() []string {
+	return qemuResources.CpuFlags
+}
+
+// GetCpuLimit returns the limit of CPU usage.
+func (qemuResources *QemuResources) GetCpuLimit() float64 {
+	return qemuResources.CpuLimit
+}
+
+// GetCpuUnits returns the CPU weight of the virtual machine.
+func (qemuResources *QemuResources) GetCpuUnits() int {
+	return qemuResources.CpuUnits
+}
+
+// GetAffinity returns the list of host CPUs the virtual machine is allowed to run on.
+func (qemuResources *QemuResources) GetAffinity() string {
+	return qemuResources.Affinity
+}
+
+// GetBallooning returns the flag that enables the memory balloon device.
+func (qemuResources *QemuResources) GetBallooning() bool {
+	return qemuResources.Ballooning
+}
+
+// GetMinimumMemory returns the minimum amount of memory the balloon device is allowed to shrink to.
+func (qemuResources *QemuResources) GetMinimumMemory() (int64, error) {
+	if qemuResources.MinimumMemory == "" {
+		return 0, nil
+	}
+	return parseMemory(qemuResources.MinimumMemory)
+}
+
+// GetHugepages returns the size of hugepages to use.
+func (qemuResources *QemuResources) GetHugepages() string {
+	return qemuResources.Hugepages
+}
+
+// GetHotplug returns the list of devices which can be hotplugged.
+func (qemuResources *QemuResources) GetHotplug() []string {
+	return qemuResources.Hotplug
+}
+
+// IsConfigured returns true if the configuration is configured.
+func (qemuResources *QemuResources) IsConfigured() bool {
+	if qemuResources.Cores == 0 {
+		return false
+	}
+
+	if qemuResources.Memory == "" {
+		return false
+	}
+
+	if qemuResources.CpuType == "" {
+		return false
+	}
+
+	return true
+}
+
+// parseMemory converts the memory string (1024 / 1024M / 1G / 1T) to megabytes.
+func parseMemory(value string) (int64, error) {
+	memory := strings.ToLower(value)
 
 	if utils.IsNumeric(memory) {
 		result, err := strconv.Atoi(memory)
@@ -50,6 +168,7 @@ func (qemuResources *QemuResources) GetMemory() (int64, error) {
 		}
 		return result, nil
 	}
+
 	if strings.HasSuffix(memory, "g") {
 		result, err := utils.ConvertToMegabytes(memory)
 		if err != nil {
@@ -57,6 +176,7 @@ func (qemuResources *QemuResources) GetMemory() (int64, error) {
 		}
 		return result, nil
 	}
+
 	if strings.HasSuffix(memory, "m") {
 		result, err := utils.ConvertToMegabytes(memory)
 		if err != nil {
@@ -67,25 +187,3 @@ func (qemuResources *QemuResources) GetMemory() (int64, error) {
 
 	return 0, fmt.Errorf("failed to convert memory to megabytes: %s", "unknown suffix")
 }
-
-// GetCpuType returns the CPU type to use.
-func (qemuResources *QemuResources) GetCpuType() string {
-	return qemuResources.CpuType
-}
-
-// IsConfigured returns true if the configuration is configured.
-func (qemuResources *QemuResources) IsConfigured() bool {
-	if qemuResources.Cores == 0 {
-		return false
-	}
-
-	if qemuResources.Memory == "" {
-		return false
-	}
-
-	if qemuResources.CpuType == "" {
-		return false
-	}
-
-	return true
-}
@@ -11,6 +11,10 @@ func TestInitializeQemuResourcesWithDefaults(t *testing.T) {
 	if qemuResources.CpuType != "host" {
 		t.Errorf("Expected CpuType to be 'host', got %s", qemuResources.CpuType)
 	}
+
+	if !qemuResources.Ballooning {
+		t.Errorf("Expected Ballooning to be true, got %v", qemuResources.Ballooning)
+	}
 }
 
 // TestGetCores tests the GetCores method.
@@ -79,3 +83,33 @@ func TestResourcesIsConfigured(t *testing.T) {
 		})
 	}
 }
+
+// TestGetMinimumMemory tests the GetMinimumMemory method with various memory sizes.
+func TestGetMinimumMemory(t *testing.T) {
+	testCases := []struct {
+		name      string
+		memory    string
+		expected  int64
+		expectErr bool
+	}{
+		{"Empty Memory", "", 0, false},
+		{"Numeric Memory", "512", 512, false},
+		{"Memory in G", "1G", 1024, false},
+		{"Invalid Memory", "invalid", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			qemuResources := &QemuResources{MinimumMemory: tc.memory}
+			got, err := qemuResources.GetMinimumMemory()
+
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("GetMinimumMemory() error = %v, expectErr %v", err, tc.expectErr)
+			}
+
+			if got != tc.expected {
+				t.Errorf("GetMinimumMemory() = %d, want %d", got, tc.expected)
+			}
+		})
+	}
+}
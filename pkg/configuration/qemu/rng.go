@@ -0,0 +1,43 @@
+package qemu
+
+// QemuRng is a structure that holds information for QEMU VirtIO RNG device configuration.
+type QemuRng struct {
+	// Enabled indicates whether the VirtIO RNG device should be added.
+	Enabled bool `json:"enabled" yaml:"enabled" toml:"enabled" mapstructure:"enabled"`
+	// Source is the entropy source on the host.
+	Source string `json:"source" yaml:"source" toml:"source" mapstructure:"source"`
+	// MaxBytes is the maximum bytes of entropy injected into the guest every period.
+	MaxBytes int `json:"max_bytes" yaml:"max_bytes" toml:"max_bytes" mapstructure:"max_bytes"`
+	// Period is the period (in milliseconds) for the max bytes limit.
+	Period int `json:"period" yaml:"period" toml:"period" mapstructure:"period"`
+}
+
+// InitializeQemuRngWithDefaults initializes the QemuRng with default values.
+func InitializeQemuRngWithDefaults() *QemuRng {
+	return &QemuRng{
+		Enabled:  false,
+		Source:   "/dev/urandom",
+		MaxBytes: 1024,
+		Period:   1000,
+	}
+}
+
+// GetEnabled returns whether the VirtIO RNG device should be added.
+func (qemuRng *QemuRng) GetEnabled() bool {
+	return qemuRng.Enabled
+}
+
+// GetSource returns the entropy source on the host.
+func (qemuRng *QemuRng) GetSource() string {
+	return qemuRng.Source
+}
+
+// GetMaxBytes returns the maximum bytes of entropy injected into the guest every period.
+func (qemuRng *QemuRng) GetMaxBytes() int {
+	return qemuRng.MaxBytes
+}
+
+// GetPeriod returns the period (in milliseconds) for the max bytes limit.
+func (qemuRng *QemuRng) GetPeriod() int {
+	return qemuRng.Period
+}
@@ -0,0 +1,47 @@
+package qemu
+
+import (
+	"testing"
+)
+
+// TestInitializeQemuRngWithDefaults tests the initialization with default values.
+func TestInitializeQemuRngWithDefaults(t *testing.T) {
+	qemuRng := InitializeQemuRngWithDefaults()
+
+	if qemuRng.Enabled {
+		t.Errorf("Expected Enabled to be false, got %t", qemuRng.Enabled)
+	}
+
+	if qemuRng.Source != "/dev/urandom" {
+		t.Errorf("Expected Source to be '/dev/urandom', got %s", qemuRng.Source)
+	}
+
+	if qemuRng.MaxBytes != 1024 {
+		t.Errorf("Expected MaxBytes to be 1024, got %d", qemuRng.MaxBytes)
+	}
+
+	if qemuRng.Period != 1000 {
+		t.Errorf("Expected Period to be 1000, got %d", qemuRng.Period)
+	}
+}
+
+// TestRngGetters tests the getters of the QemuRng.
+func TestRngGetters(t *testing.T) {
+	qemuRng := &QemuRng{Enabled: true, Source: "/dev/hwrng", MaxBytes: 2048, Period: 500}
+
+	if enabled := qemuRng.GetEnabled(); !enabled {
+		t.Errorf("GetEnabled() = %t, want %t", enabled, true)
+	}
+
+	if source := qemuRng.GetSource(); source != "/dev/hwrng" {
+		t.Errorf("GetSource() = %s, want %s", source, "/dev/hwrng")
+	}
+
+	if maxBytes := qemuRng.GetMaxBytes(); maxBytes != 2048 {
+		t.Errorf("GetMaxBytes() = %d, want %d", maxBytes, 2048)
+	}
+
+	if period := qemuRng.GetPeriod(); period != 500 {
+		t.Errorf("GetPeriod() = %d, want %d", period, 500)
+	}
+}
@@ -0,0 +1,35 @@
+package qemu
+
+// QemuWatchdog is a structure that holds information for QEMU watchdog device configuration.
+type QemuWatchdog struct {
+	// Enabled indicates whether the watchdog device should be added.
+	Enabled bool `json:"enabled" yaml:"enabled" toml:"enabled" mapstructure:"enabled"`
+	// Model is the watchdog device model.
+	Model string `json:"model" yaml:"model" toml:"model" mapstructure:"model"`
+	// Action is the action to perform when the guest stops feeding the watchdog.
+	Action string `json:"action" yaml:"action" toml:"action" mapstructure:"action"`
+}
+
+// InitializeQemuWatchdogWithDefaults initializes the QemuWatchdog with default values.
+func InitializeQemuWatchdogWithDefaults() *QemuWatchdog {
+	return &QemuWatchdog{
+		Enabled: false,
+		Model:   "i6300esb",
+		Action:  "reset",
+	}
+}
+
+// GetEnabled returns whether the watchdog device should be added.
+func (qemuWatchdog *QemuWatchdog) GetEnabled() bool {
+	return qemuWatchdog.Enabled
+}
+
+// GetModel returns the watchdog device model.
+func (qemuWatchdog *QemuWatchdog) GetModel() string {
+	return qemuWatchdog.Model
+}
+
+// GetAction returns the action to perform when the guest stops feeding the watchdog.
+func (qemuWatchdog *QemuWatchdog) GetAction() string {
+	return qemuWatchdog.Action
+}
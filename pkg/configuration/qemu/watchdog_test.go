@@ -0,0 +1,39 @@
+package qemu
+
+import (
+	"testing"
+)
+
+// TestInitializeQemuWatchdogWithDefaults tests the initialization with default values.
+func TestInitializeQemuWatchdogWithDefaults(t *testing.T) {
+	qemuWatchdog := InitializeQemuWatchdogWithDefaults()
+
+	if qemuWatchdog.Enabled {
+		t.Errorf("Expected Enabled to be false, got %t", qemuWatchdog.Enabled)
+	}
+
+	if qemuWatchdog.Model != "i6300esb" {
+		t.Errorf("Expected Model to be 'i6300esb', got %s", qemuWatchdog.Model)
+	}
+
+	if qemuWatchdog.Action != "reset" {
+		t.Errorf("Expected Action to be 'reset', got %s", qemuWatchdog.Action)
+	}
+}
+
+// TestWatchdogGetters tests the getters of the QemuWatchdog.
+func TestWatchdogGetters(t *testing.T) {
+	qemuWatchdog := &QemuWatchdog{Enabled: true, Model: "ib700", Action: "poweroff"}
+
+	if enabled := qemuWatchdog.GetEnabled(); !enabled {
+		t.Errorf("GetEnabled() = %t, want %t", enabled, true)
+	}
+
+	if model := qemuWatchdog.GetModel(); model != "ib700" {
+		t.Errorf("GetModel() = %s, want %s", model, "ib700")
+	}
+
+	if action := qemuWatchdog.GetAction(); action != "poweroff" {
+		t.Errorf("GetAction() = %s, want %s", action, "poweroff")
+	}
+}
@@ -74,3 +74,84 @@ func (configuration *Configuration) GetConfigurationContents() string {
 		configuration.GetComponent(),
 	)
 }
+
+// GetYumConfigurationFullPath returns the full path to the repository file for dnf based distributions.
+func (configuration *Configuration) GetYumConfigurationFullPath() string {
+	return fmt.Sprintf(
+		"/etc/yum.repos.d/%s.repo",
+		configuration.GetName(),
+	)
+}
+
+// GetYumConfigurationContents returns the contents of the repository file for dnf based distributions.
+// Release and component are not used, as the URL already points to the repository (dnf variables like `$releasever` are allowed).
+func (configuration *Configuration) GetYumConfigurationContents() string {
+	gpgCheck := 0
+	if configuration.GetGPG() != "" {
+		gpgCheck = 1
+	}
+
+	contents := fmt.Sprintf(
+		"[%s]\nname=%s\nbaseurl=%s\nenabled=1\ngpgcheck=%d\n",
+		configuration.GetName(),
+		configuration.GetName(),
+		configuration.GetURL(),
+		gpgCheck,
+	)
+
+	if gpgCheck == 1 {
+		contents += fmt.Sprintf("gpgkey=%s\n", configuration.GetGPG())
+	}
+
+	return contents
+}
+
+// GetApkKeyFullPath returns the full path to the signing key for Alpine Linux.
+func (configuration *Configuration) GetApkKeyFullPath() string {
+	return fmt.Sprintf(
+		"/etc/apk/keys/%s.rsa.pub",
+		configuration.GetKeyName(),
+	)
+}
+
+// GetApkConfigurationFullPath returns the full path to the repositories file for Alpine Linux.
+func (configuration *Configuration) GetApkConfigurationFullPath() string {
+	return "/etc/apk/repositories"
+}
+
+// GetPacmanKeyFullPath returns the full path to the key for Arch Linux.
+func (configuration *Configuration) GetPacmanKeyFullPath() string {
+	return fmt.Sprintf(
+		"/etc/pacman.d/ptm-keys/%s.gpg",
+		configuration.GetKeyName(),
+	)
+}
+
+// GetPacmanKeyDropInFullPath returns the full path to the pacman-init service drop-in which imports the key on the first boot (Arch Linux).
+func (configuration *Configuration) GetPacmanKeyDropInFullPath() string {
+	return fmt.Sprintf(
+		"/etc/systemd/system/pacman-init.service.d/ptm-%s.conf",
+		configuration.GetName(),
+	)
+}
+
+// GetPacmanConfigurationFullPath returns the full path to the pacman configuration for Arch Linux.
+func (configuration *Configuration) GetPacmanConfigurationFullPath() string {
+	return "/etc/pacman.conf"
+}
+
+// GetPacmanConfigurationLines returns the lines of the repository section appended to the pacman configuration (Arch Linux).
+// Signatures are only required if the key is configured, `key_name` is expected to be the key ID.
+func (configuration *Configuration) GetPacmanConfigurationLines() []string {
+	sigLevel := "Optional TrustAll"
+	if configuration.GetGPG() != "" {
+		sigLevel = "Required"
+	}
+
+	return []string{
+		"",
+		fmt.Sprintf("[%s]", configuration.GetName()),
+		fmt.Sprintf("SigLevel = %s", sigLevel),
+		fmt.Sprintf("Server = %s", configuration.GetURL()),
+	}
+}
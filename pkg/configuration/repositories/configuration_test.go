@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -54,4 +55,65 @@ func TestConfiguration(t *testing.T) {
 	if configContents := config.GetConfigurationContents(); configContents != expectedConfigContents {
 		t.Errorf("GetConfigurationContents() = %v, want %v", configContents, expectedConfigContents)
 	}
+
+	expectedYumConfigPath := "/etc/yum.repos.d/test-config.repo"
+	if yumConfigPath := config.GetYumConfigurationFullPath(); yumConfigPath != expectedYumConfigPath {
+		t.Errorf("GetYumConfigurationFullPath() = %v, want %v", yumConfigPath, expectedYumConfigPath)
+	}
+
+	expectedYumConfigContents := "[test-config]\nname=test-config\nbaseurl=https://example.com\nenabled=1\ngpgcheck=1\ngpgkey=https://example.com/gpg\n"
+	if yumConfigContents := config.GetYumConfigurationContents(); yumConfigContents != expectedYumConfigContents {
+		t.Errorf("GetYumConfigurationContents() = %v, want %v", yumConfigContents, expectedYumConfigContents)
+	}
+
+	expectedApkKeyPath := "/etc/apk/keys/test-key.rsa.pub"
+	if apkKeyPath := config.GetApkKeyFullPath(); apkKeyPath != expectedApkKeyPath {
+		t.Errorf("GetApkKeyFullPath() = %v, want %v", apkKeyPath, expectedApkKeyPath)
+	}
+
+	expectedApkConfigPath := "/etc/apk/repositories"
+	if apkConfigPath := config.GetApkConfigurationFullPath(); apkConfigPath != expectedApkConfigPath {
+		t.Errorf("GetApkConfigurationFullPath() = %v, want %v", apkConfigPath, expectedApkConfigPath)
+	}
+
+	expectedPacmanKeyPath := "/etc/pacman.d/ptm-keys/test-key.gpg"
+	if pacmanKeyPath := config.GetPacmanKeyFullPath(); pacmanKeyPath != expectedPacmanKeyPath {
+		t.Errorf("GetPacmanKeyFullPath() = %v, want %v", pacmanKeyPath, expectedPacmanKeyPath)
+	}
+
+	expectedPacmanKeyDropInPath := "/etc/systemd/system/pacman-init.service.d/ptm-test-config.conf"
+	if pacmanKeyDropInPath := config.GetPacmanKeyDropInFullPath(); pacmanKeyDropInPath != expectedPacmanKeyDropInPath {
+		t.Errorf("GetPacmanKeyDropInFullPath() = %v, want %v", pacmanKeyDropInPath, expectedPacmanKeyDropInPath)
+	}
+
+	expectedPacmanConfigLines := []string{"", "[test-config]", "SigLevel = Required", "Server = https://example.com"}
+	if pacmanConfigLines := config.GetPacmanConfigurationLines(); !reflect.DeepEqual(pacmanConfigLines, expectedPacmanConfigLines) {
+		t.Errorf("GetPacmanConfigurationLines() = %v, want %v", pacmanConfigLines, expectedPacmanConfigLines)
+	}
+}
+
+// TestGetYumConfigurationContentsWithoutGPG tests the GetYumConfigurationContents method without a GPG key.
+func TestGetYumConfigurationContentsWithoutGPG(t *testing.T) {
+	config := Configuration{
+		Name: "local",
+		URL:  "https://mirror.example.com/el$releasever/$basearch",
+	}
+
+	expected := "[local]\nname=local\nbaseurl=https://mirror.example.com/el$releasever/$basearch\nenabled=1\ngpgcheck=0\n"
+	if contents := config.GetYumConfigurationContents(); contents != expected {
+		t.Errorf("GetYumConfigurationContents() = %v, want %v", contents, expected)
+	}
+}
+
+// TestGetPacmanConfigurationLinesWithoutGPG tests the GetPacmanConfigurationLines method without a GPG key.
+func TestGetPacmanConfigurationLinesWithoutGPG(t *testing.T) {
+	config := Configuration{
+		Name: "local",
+		URL:  "https://mirror.example.com/$repo/os/$arch",
+	}
+
+	expected := []string{"", "[local]", "SigLevel = Optional TrustAll", "Server = https://mirror.example.com/$repo/os/$arch"}
+	if lines := config.GetPacmanConfigurationLines(); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("GetPacmanConfigurationLines() = %v, want %v", lines, expected)
+	}
 }
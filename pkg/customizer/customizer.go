@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"github.com/cqroot/prompt/choose"
 	config "github.com/darki73/ptm/pkg/configuration"
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	"github.com/darki73/ptm/pkg/distributions"
 	"github.com/darki73/ptm/pkg/prompter"
 	"github.com/darki73/ptm/pkg/proxmox"
+	"github.com/darki73/ptm/pkg/utils"
 	vc "github.com/darki73/ptm/pkg/virt-customize"
+	"reflect"
 )
 
 // Customizer represents the customizer.
@@ -17,6 +21,10 @@ type Customizer struct {
 	virtCustomizeConfiguration *vc.VirtCustomize
 	// selectedImage represents the selected image.
 	selectedImage string
+	// selectedImageMetadata represents the metadata recorded when the selected image was downloaded (nil if there is none).
+	selectedImageMetadata *proxmox.ImageMetadata
+	// watchdogModel represents the watchdog device model the watchdog daemon is enabled for (overrides the configuration file).
+	watchdogModel string
 }
 
 // NewCustomizer creates a new customizer instance.
@@ -25,23 +33,68 @@ func NewCustomizer(configuration *config.Configuration) *Customizer {
 		configuration:              configuration,
 		virtCustomizeConfiguration: nil,
 		selectedImage:              "",
+		selectedImageMetadata:      nil,
+		watchdogModel:              "",
 	}
 }
 
+// GetWatchdogModel returns the watchdog device model the watchdog daemon is enabled for.
+func (customizer *Customizer) GetWatchdogModel() string {
+	if customizer.watchdogModel != "" {
+		return customizer.watchdogModel
+	}
+
+	watchdogConfiguration := customizer.configuration.GetQemu().GetWatchdog()
+	if watchdogConfiguration != nil && watchdogConfiguration.GetEnabled() {
+		return watchdogConfiguration.GetModel()
+	}
+
+	return ""
+}
+
+// SetWatchdogModel sets the watchdog device model the watchdog daemon is enabled for (overrides the configuration file).
+func (customizer *Customizer) SetWatchdogModel(watchdogModel string) *Customizer {
+	customizer.watchdogModel = watchdogModel
+	return customizer
+}
+
 // Run runs the customizer.
 func (customizer *Customizer) Run() error {
 	if err := customizer.askForImageToCustomize(); err != nil {
 		return err
 	}
 
+	if err := customizer.ensureArchitectureIsSupported(); err != nil {
+		return err
+	}
+
+	distribution, err := customizer.resolveDistribution()
+	if err != nil {
+		return err
+	}
+
 	customizer.virtCustomizeConfiguration = vc.NewVirtCustomizeConfiguration(
 		customizer.selectedImage,
-		customizer.configuration.GetBasePackages(),
+		customizer.resolveBasePackages(distribution),
 		customizer.configuration.GetExtraPackages(),
 		customizer.configuration.GetRepositories(),
 		customizer.configuration.GetUnattendedUpgrades(),
 	)
 
+	customizer.virtCustomizeConfiguration.
+		SetPackageManager(distribution.GetPackageManager()).
+		SetInitSystem(distribution.GetInitSystem()).
+		SetSELinuxRelabel(distribution.IsSELinuxEnabled())
+
+	if watchdogModel := customizer.GetWatchdogModel(); watchdogModel != "" {
+		customizer.virtCustomizeConfiguration.SetWatchdogModel(watchdogModel)
+	}
+
+	cloudInitImageConfiguration := customizer.configuration.GetCloudInit().GetImage()
+	if cloudInitImageConfiguration != nil && cloudInitImageConfiguration.GetEnabled() {
+		customizer.virtCustomizeConfiguration.SetCloudInitImageConfiguration(cloudInitImageConfiguration)
+	}
+
 	cli := vc.NewCommandLineInterface(customizer.virtCustomizeConfiguration)
 
 	return cli.Execute()
@@ -56,7 +109,10 @@ func (customizer *Customizer) askForImageToCustomize() error {
 		return err
 	}
 
+	metadata := make(map[string]*proxmox.ImageMetadata)
+
 	for _, image := range images.GetISOs() {
+		metadata[image.GetFullPath()] = image.GetMetadata()
 		choice := choose.Choice{
 			Text: image.GetFullPath(),
 			Note: fmt.Sprintf(
@@ -78,6 +134,83 @@ func (customizer *Customizer) askForImageToCustomize() error {
 	}
 
 	customizer.selectedImage = result
+	customizer.selectedImageMetadata = metadata[result]
+
+	return nil
+}
+
+// resolveDistribution returns the distribution of the selected image, which decides the package manager, init system and SELinux relabel.
+// The distribution recorded when the image was downloaded is used, the base image configuration only for images without metadata.
+func (customizer *Customizer) resolveDistribution() (distributions.Distribution, error) {
+	baseImage := customizer.configuration.GetBaseImage()
+
+	metadata := customizer.selectedImageMetadata
+	if metadata == nil || metadata.Distribution == "" {
+		fmt.Printf("Image `%s` has no metadata, assuming `%s` from the base image configuration\n", customizer.selectedImage, baseImage.GetDistribution())
+	} else {
+		if metadata.Distribution != baseImage.GetDistribution() {
+			fmt.Printf("Image `%s` is `%s`, customizing it as such instead of `%s` from the base image configuration\n", customizer.selectedImage, metadata.Distribution, baseImage.GetDistribution())
+		}
+
+		baseImage = &bi.Configuration{
+			Distribution: metadata.Distribution,
+			Release:      metadata.Release,
+			Minimal:      metadata.Minimal,
+			Architecture: metadata.Architecture,
+			Format:       metadata.Format,
+			Variant:      metadata.Variant,
+			Serial:       metadata.Serial,
+			Catalog:      baseImage.GetCatalog(),
+		}
+	}
+
+	distros, err := distributions.NewDistributions(baseImage, customizer.configuration.GetDistributions())
+	if err != nil {
+		return nil, fmt.Errorf("unable to customize image `%s`: %v", customizer.selectedImage, err)
+	}
+
+	return distros.GetActiveDistribution(), nil
+}
+
+// resolveBasePackages returns the base packages to install into the selected image.
+// Default base packages depend on the package manager, so they are replaced when the image uses a different package manager than the base image configuration.
+func (customizer *Customizer) resolveBasePackages(distribution distributions.Distribution) []string {
+	basePackages := customizer.configuration.GetBasePackages()
+
+	configuredPackageManager := distributions.GetPackageManagerForDistribution(
+		customizer.configuration.GetBaseImage().GetDistribution(),
+		customizer.configuration.GetDistributions(),
+	)
+	if configuredPackageManager == distribution.GetPackageManager() {
+		return basePackages
+	}
+
+	if !reflect.DeepEqual(basePackages, distributions.GetDefaultBasePackages(configuredPackageManager)) {
+		return basePackages
+	}
+
+	return distributions.GetDefaultBasePackages(distribution.GetPackageManager())
+}
+
+// ensureArchitectureIsSupported ensures that the selected image can be customized on this host.
+// virt-customize runs commands inside the image, which is not possible for foreign architectures.
+func (customizer *Customizer) ensureArchitectureIsSupported() error {
+	imageArchitecture, err := utils.GetImageArchitecture(customizer.selectedImage)
+	if err != nil {
+		fmt.Println("Unable to detect image architecture, skipping architecture check:", err)
+		return nil
+	}
+
+	hostArchitecture := utils.GetHostArchitecture()
+	if imageArchitecture != hostArchitecture {
+		return fmt.Errorf(
+			"image `%s` is built for `%s`, but the host is `%s`: customizing images of a different architecture is not supported, please run customization on a `%s` host",
+			customizer.selectedImage,
+			imageArchitecture,
+			hostArchitecture,
+			imageArchitecture,
+		)
+	}
 
 	return nil
 }
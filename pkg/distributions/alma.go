@@ -0,0 +1,127 @@
+package distributions
+
+import (
+	"fmt"
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+)
+
+// Alma is the structure that holds configuration for AlmaLinux distributions.
+type Alma struct {
+	// baseDistribution is the configuration shared by all distributions.
+	baseDistribution
+}
+
+// NewAlma returns a new instance of AlmaLinux distribution configuration.
+func NewAlma() *Alma {
+	return &Alma{
+		baseDistribution: baseDistribution{
+			completeVersionBaseUrl: "https://repo.almalinux.org/almalinux",
+			minimalVersionBaseUrl:  "https://repo.almalinux.org/almalinux",
+			variants: []*Variant{
+				NewVariant("genericcloud", []string{"x86_64", "aarch64"}, []string{"qcow2"}),
+			},
+			defaultVariant: "genericcloud",
+			packageManager: PackageManagerDnf,
+			initSystem:     InitSystemSystemd,
+			selinux:        true,
+		},
+	}
+}
+
+// Initialize initializes the AlmaLinux distribution.
+func (alma *Alma) Initialize(baseImage *bi.Configuration) Distribution {
+	// NOTE: AlmaLinux has no codenames for major versions, so releases are the major versions themselves.
+	releaseToVersion := map[string]string{
+		"8": "8",
+		"9": "9",
+	}
+
+	alma.initialize(baseImage, releaseToVersion)
+
+	return alma
+}
+
+// GetImageName returns the image name.
+func (alma *Alma) GetImageName() (string, error) {
+	version, err := alma.GetVersionFromReleaseOrVersion(alma.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	if err := validateVariant(alma, alma.baseImage); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"AlmaLinux-%s-GenericCloud-latest.%s.%s",
+		version,
+		alma.baseImage.GetArchitecture(),
+		alma.baseImage.GetFormat(),
+	), nil
+}
+
+// GetCompleteVersionUrl returns the complete version URL of the AlmaLinux.
+func (alma *Alma) GetCompleteVersionUrl() (string, error) {
+	version, err := alma.GetVersionFromReleaseOrVersion(alma.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := alma.GetImageName()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/cloud/%s/images/%s",
+		alma.GetCompleteVersionBaseUrl(),
+		version,
+		alma.baseImage.GetArchitecture(),
+		imageName,
+	), nil
+}
+
+// GetMinimalVersionUrl returns the minimal version URL of the AlmaLinux.
+func (alma *Alma) GetMinimalVersionUrl() (string, error) {
+	version, err := alma.GetVersionFromReleaseOrVersion(alma.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := alma.GetImageName()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/cloud/%s/images/%s",
+		alma.GetMinimalVersionBaseUrl(),
+		version,
+		alma.baseImage.GetArchitecture(),
+		imageName,
+	), nil
+}
+
+// GetUrl returns the URL of the AlmaLinux.
+func (alma *Alma) GetUrl() (string, error) {
+	if alma.baseImage.GetMinimal() {
+		return alma.GetMinimalVersionUrl()
+	}
+
+	return alma.GetCompleteVersionUrl()
+}
+
+// GetChecksumUrl returns the URL of the `CHECKSUM` file published next to the AlmaLinux image.
+func (alma *Alma) GetChecksumUrl() (string, error) {
+	url, err := alma.GetUrl()
+	if err != nil {
+		return "", err
+	}
+
+	return replaceFileName(url, "CHECKSUM"), nil
+}
+
+// GetSignatureUrl returns an empty string as the checksum file of AlmaLinux is clearsigned.
+func (alma *Alma) GetSignatureUrl() (string, error) {
+	return "", nil
+}
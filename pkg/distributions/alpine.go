@@ -0,0 +1,132 @@
+package distributions
+
+import (
+	"fmt"
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+)
+
+// Alpine is the structure that holds configuration for Alpine Linux distributions.
+type Alpine struct {
+	// baseDistribution is the configuration shared by all distributions.
+	baseDistribution
+}
+
+// NewAlpine returns a new instance of Alpine Linux distribution configuration.
+func NewAlpine() *Alpine {
+	return &Alpine{
+		baseDistribution: baseDistribution{
+			completeVersionBaseUrl: "https://dl-cdn.alpinelinux.org/alpine",
+			minimalVersionBaseUrl:  "https://dl-cdn.alpinelinux.org/alpine",
+			variants: []*Variant{
+				NewVariant("nocloud", []string{"x86_64", "aarch64"}, []string{"qcow2"}),
+			},
+			defaultVariant: "nocloud",
+			packageManager: PackageManagerApk,
+			initSystem:     InitSystemOpenRC,
+			selinux:        false,
+		},
+	}
+}
+
+// Initialize initializes the Alpine Linux distribution.
+func (alpine *Alpine) Initialize(baseImage *bi.Configuration) Distribution {
+	// NOTE: Releases are the stable branches, versions are the point releases the images are published for.
+	releaseToVersion := map[string]string{
+		"3.20": "3.20.3",
+		"3.21": "3.21.0",
+	}
+
+	alpine.initialize(baseImage, releaseToVersion)
+
+	return alpine
+}
+
+// GetImageName returns the image name.
+// x86_64 images boot with BIOS (Proxmox VE default), aarch64 images are only published for UEFI.
+func (alpine *Alpine) GetImageName() (string, error) {
+	version, err := alpine.GetVersionFromReleaseOrVersion(alpine.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	if err := validateVariant(alpine, alpine.baseImage); err != nil {
+		return "", err
+	}
+
+	firmware := "bios"
+	if alpine.baseImage.GetArchitecture() == "aarch64" {
+		firmware = "uefi"
+	}
+
+	return fmt.Sprintf(
+		"nocloud_alpine-%s-%s-%s-cloudinit-r0.%s",
+		version,
+		alpine.baseImage.GetArchitecture(),
+		firmware,
+		alpine.baseImage.GetFormat(),
+	), nil
+}
+
+// GetCompleteVersionUrl returns the complete version URL of the Alpine Linux.
+func (alpine *Alpine) GetCompleteVersionUrl() (string, error) {
+	release, err := alpine.GetReleaseFromReleaseOrVersion(alpine.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := alpine.GetImageName()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s/v%s/releases/cloud/%s",
+		alpine.GetCompleteVersionBaseUrl(),
+		release,
+		imageName,
+	), nil
+}
+
+// GetMinimalVersionUrl returns the minimal version URL of the Alpine Linux.
+func (alpine *Alpine) GetMinimalVersionUrl() (string, error) {
+	release, err := alpine.GetReleaseFromReleaseOrVersion(alpine.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := alpine.GetImageName()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s/v%s/releases/cloud/%s",
+		alpine.GetMinimalVersionBaseUrl(),
+		release,
+		imageName,
+	), nil
+}
+
+// GetUrl returns the URL of the Alpine Linux.
+func (alpine *Alpine) GetUrl() (string, error) {
+	if alpine.baseImage.GetMinimal() {
+		return alpine.GetMinimalVersionUrl()
+	}
+
+	return alpine.GetCompleteVersionUrl()
+}
+
+// GetChecksumUrl returns the URL of the `.sha512` file published for the Alpine Linux image.
+func (alpine *Alpine) GetChecksumUrl() (string, error) {
+	url, err := alpine.GetUrl()
+	if err != nil {
+		return "", err
+	}
+
+	return url + ".sha512", nil
+}
+
+// GetSignatureUrl returns an empty string as Alpine Linux does not publish a detached signature of the checksum file.
+func (alpine *Alpine) GetSignatureUrl() (string, error) {
+	return "", nil
+}
@@ -0,0 +1,122 @@
+package distributions
+
+import (
+	"fmt"
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+)
+
+// Arch is the structure that holds configuration for Arch Linux distributions.
+type Arch struct {
+	// baseDistribution is the configuration shared by all distributions.
+	baseDistribution
+}
+
+// NewArch returns a new instance of Arch Linux distribution configuration.
+func NewArch() *Arch {
+	return &Arch{
+		baseDistribution: baseDistribution{
+			completeVersionBaseUrl: "https://geo.mirror.pkgbuild.com/images",
+			minimalVersionBaseUrl:  "https://geo.mirror.pkgbuild.com/images",
+			variants: []*Variant{
+				NewVariant("cloudimg", []string{"x86_64"}, []string{"qcow2"}),
+			},
+			defaultVariant: "cloudimg",
+			packageManager: PackageManagerPacman,
+			initSystem:     InitSystemSystemd,
+			selinux:        false,
+		},
+	}
+}
+
+// Initialize initializes the Arch Linux distribution.
+func (arch *Arch) Initialize(baseImage *bi.Configuration) Distribution {
+	// NOTE: Arch Linux is a rolling release, so the only release is the latest image.
+	releaseToVersion := map[string]string{
+		"latest": "latest",
+	}
+
+	arch.initialize(baseImage, releaseToVersion)
+
+	return arch
+}
+
+// GetImageName returns the image name.
+func (arch *Arch) GetImageName() (string, error) {
+	if _, err := arch.GetVersionFromReleaseOrVersion(arch.baseImage.GetRelease()); err != nil {
+		return "", err
+	}
+
+	if err := validateVariant(arch, arch.baseImage); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"Arch-Linux-%s-cloudimg.%s",
+		arch.baseImage.GetArchitecture(),
+		arch.baseImage.GetFormat(),
+	), nil
+}
+
+// GetCompleteVersionUrl returns the complete version URL of the Arch Linux.
+func (arch *Arch) GetCompleteVersionUrl() (string, error) {
+	release, err := arch.GetReleaseFromReleaseOrVersion(arch.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := arch.GetImageName()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/%s",
+		arch.GetCompleteVersionBaseUrl(),
+		release,
+		imageName,
+	), nil
+}
+
+// GetMinimalVersionUrl returns the minimal version URL of the Arch Linux.
+func (arch *Arch) GetMinimalVersionUrl() (string, error) {
+	release, err := arch.GetReleaseFromReleaseOrVersion(arch.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := arch.GetImageName()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/%s",
+		arch.GetMinimalVersionBaseUrl(),
+		release,
+		imageName,
+	), nil
+}
+
+// GetUrl returns the URL of the Arch Linux.
+func (arch *Arch) GetUrl() (string, error) {
+	if arch.baseImage.GetMinimal() {
+		return arch.GetMinimalVersionUrl()
+	}
+
+	return arch.GetCompleteVersionUrl()
+}
+
+// GetChecksumUrl returns the URL of the `.SHA256` file published for the Arch Linux image.
+func (arch *Arch) GetChecksumUrl() (string, error) {
+	url, err := arch.GetUrl()
+	if err != nil {
+		return "", err
+	}
+
+	return url + ".SHA256", nil
+}
+
+// GetSignatureUrl returns an empty string as Arch Linux does not publish a detached signature of the checksum file.
+func (arch *Arch) GetSignatureUrl() (string, error) {
+	return "", nil
+}
@@ -0,0 +1,248 @@
+package distributions
+
+import (
+	"fmt"
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	"github.com/darki73/ptm/pkg/utils"
+)
+
+// baseDistribution is the structure that holds the configuration shared by all distributions.
+// Distributions embed it and only implement the naming and URL logic of their images.
+type baseDistribution struct {
+	// baseImage is the user configuration for base image.
+	baseImage *bi.Configuration
+	// completeVersionBaseUrl is the base URL for complete version of the distribution.
+	completeVersionBaseUrl string
+	// minimalVersionBaseUrl is the base URL for minimal version of the distribution.
+	minimalVersionBaseUrl string
+	// versionToRelease is a map of versions to releases of the distribution.
+	versionToRelease map[string]string
+	// releaseToVersion is a map of releases to versions of the distribution.
+	releaseToVersion map[string]string
+	// supportedVersions is a list of supported versions of the distribution.
+	supportedVersions []string
+	// supportedReleases is a list of supported releases of the distribution.
+	supportedReleases []string
+	// extraReleases is a map of releases to versions added to the releases of the distribution by the configuration file.
+	extraReleases map[string]string
+	// variants is a list of supported variants of the distribution, each with the architectures and formats it is published for.
+	variants []*Variant
+	// defaultVariant is the name of the variant used when the base image does not select one.
+	defaultVariant string
+	// minimalVariant is the name of the variant used for minimal base images which do not select one (empty to use the default variant).
+	minimalVariant string
+	// packageManager is the package manager used by the distribution.
+	packageManager string
+	// initSystem is the init system used by the distribution.
+	initSystem string
+	// selinux is a boolean value that indicates if the images of the distribution ship with SELinux enabled.
+	selinux bool
+}
+
+// initialize sets the base image and releases of the distribution.
+// Releases added by the configuration file take precedence over the releases of the distribution.
+func (distribution *baseDistribution) initialize(baseImage *bi.Configuration, releaseToVersion map[string]string) {
+	if len(distribution.extraReleases) > 0 {
+		merged := make(map[string]string, len(releaseToVersion)+len(distribution.extraReleases))
+		for release, version := range releaseToVersion {
+			merged[release] = version
+		}
+		for release, version := range distribution.extraReleases {
+			merged[release] = version
+		}
+		releaseToVersion = merged
+	}
+
+	distribution.baseImage = baseImage
+	distribution.releaseToVersion = releaseToVersion
+	distribution.versionToRelease = make(map[string]string, len(releaseToVersion))
+	distribution.supportedReleases = make([]string, 0, len(releaseToVersion))
+	distribution.supportedVersions = make([]string, 0, len(releaseToVersion))
+
+	for key, value := range releaseToVersion {
+		distribution.supportedReleases = append(distribution.supportedReleases, key)
+		distribution.supportedVersions = append(distribution.supportedVersions, value)
+		distribution.versionToRelease[value] = key
+	}
+}
+
+// extendReleases adds the releases to the releases of the distribution (applied when the distribution is initialized).
+func (distribution *baseDistribution) extendReleases(releases map[string]string) {
+	if distribution.extraReleases == nil {
+		distribution.extraReleases = make(map[string]string, len(releases))
+	}
+
+	for release, version := range releases {
+		distribution.extraReleases[release] = version
+	}
+}
+
+// GetPackageManager returns the package manager used by the distribution.
+func (distribution *baseDistribution) GetPackageManager() string {
+	return distribution.packageManager
+}
+
+// GetInitSystem returns the init system used by the distribution.
+func (distribution *baseDistribution) GetInitSystem() string {
+	return distribution.initSystem
+}
+
+// IsSELinuxEnabled returns true if the images of the distribution ship with SELinux enabled.
+func (distribution *baseDistribution) IsSELinuxEnabled() bool {
+	return distribution.selinux
+}
+
+// GetVersionToRelease returns a map of versions to releases of the distribution.
+func (distribution *baseDistribution) GetVersionToRelease() map[string]string {
+	return distribution.versionToRelease
+}
+
+// GetReleaseToVersion returns a map of releases to versions of the distribution.
+func (distribution *baseDistribution) GetReleaseToVersion() map[string]string {
+	return distribution.releaseToVersion
+}
+
+// GetCompleteVersionBaseUrl returns the base URL to the complete version of the distribution.
+func (distribution *baseDistribution) GetCompleteVersionBaseUrl() string {
+	return distribution.completeVersionBaseUrl
+}
+
+// GetMinimalVersionBaseUrl returns the base URL to the minimal version of the distribution.
+func (distribution *baseDistribution) GetMinimalVersionBaseUrl() string {
+	return distribution.minimalVersionBaseUrl
+}
+
+// GetSupportedVersions returns a list of supported versions of the distribution.
+func (distribution *baseDistribution) GetSupportedVersions() []string {
+	return distribution.supportedVersions
+}
+
+// GetSupportedReleases returns a list of supported releases of the distribution.
+func (distribution *baseDistribution) GetSupportedReleases() []string {
+	return distribution.supportedReleases
+}
+
+// IsVersionSupported returns true if the version is supported by the distribution.
+func (distribution *baseDistribution) IsVersionSupported(version string) bool {
+	return utils.SliceContains(distribution.supportedVersions, version)
+}
+
+// IsReleaseSupported returns true if the release is supported by the distribution.
+func (distribution *baseDistribution) IsReleaseSupported(release string) bool {
+	return utils.SliceContains(distribution.supportedReleases, release)
+}
+
+// GetCompleteSupportedArchitectures returns a list of supported architectures for complete type of the distribution.
+func (distribution *baseDistribution) GetCompleteSupportedArchitectures() []string {
+	return distribution.getTypeVariant(false).GetArchitectures()
+}
+
+// GetCompleteSupportedImageFormats returns a list of supported image formats for complete type of the distribution.
+func (distribution *baseDistribution) GetCompleteSupportedImageFormats() []string {
+	return distribution.getTypeVariant(false).GetFormats()
+}
+
+// GetMinimalSupportedArchitectures returns a list of supported architectures for minimal type of the distribution.
+func (distribution *baseDistribution) GetMinimalSupportedArchitectures() []string {
+	return distribution.getTypeVariant(true).GetArchitectures()
+}
+
+// GetMinimalSupportedImageFormats returns a list of supported image formats for minimal type of the distribution.
+func (distribution *baseDistribution) GetMinimalSupportedImageFormats() []string {
+	return distribution.getTypeVariant(true).GetFormats()
+}
+
+// IsArchitectureSupported returns true if the architecture is supported by the selected variant of the distribution.
+func (distribution *baseDistribution) IsArchitectureSupported(architecture string) bool {
+	variant, err := distribution.GetVariant()
+	if err != nil {
+		return false
+	}
+
+	return variant.IsArchitectureSupported(architecture)
+}
+
+// IsImageFormatSupported returns true if the image format is supported by the selected variant of the distribution.
+func (distribution *baseDistribution) IsImageFormatSupported(imageFormat string) bool {
+	variant, err := distribution.GetVariant()
+	if err != nil {
+		return false
+	}
+
+	return variant.IsFormatSupported(imageFormat)
+}
+
+// GetVersionFromRelease returns the version of the distribution from the release.
+func (distribution *baseDistribution) GetVersionFromRelease(release string) (string, error) {
+	if distribution.IsReleaseSupported(release) {
+		return distribution.releaseToVersion[release], nil
+	}
+
+	return "", fmt.Errorf("release %s is not supported", release)
+}
+
+// GetReleaseFromVersion returns the release of the distribution from the version.
+func (distribution *baseDistribution) GetReleaseFromVersion(version string) (string, error) {
+	if distribution.IsVersionSupported(version) {
+		return distribution.versionToRelease[version], nil
+	}
+
+	return "", fmt.Errorf("version %s is not supported", version)
+}
+
+// GetReleaseFromReleaseOrVersion returns the release of the distribution from the release or version.
+func (distribution *baseDistribution) GetReleaseFromReleaseOrVersion(releaseOrVersion string) (string, error) {
+	if distribution.IsReleaseSupported(releaseOrVersion) {
+		return releaseOrVersion, nil
+	}
+
+	if distribution.IsVersionSupported(releaseOrVersion) {
+		return distribution.versionToRelease[releaseOrVersion], nil
+	}
+
+	return "", fmt.Errorf("release or version %s is not supported", releaseOrVersion)
+}
+
+// GetVersionFromReleaseOrVersion returns the version of the distribution from the release or version.
+func (distribution *baseDistribution) GetVersionFromReleaseOrVersion(releaseOrVersion string) (string, error) {
+	if distribution.IsReleaseSupported(releaseOrVersion) {
+		return distribution.releaseToVersion[releaseOrVersion], nil
+	}
+
+	if distribution.IsVersionSupported(releaseOrVersion) {
+		return releaseOrVersion, nil
+	}
+
+	return "", fmt.Errorf("release or version %s is not supported", releaseOrVersion)
+}
+
+// GetSupportedVariants returns a list of supported variants of the distribution.
+func (distribution *baseDistribution) GetSupportedVariants() []*Variant {
+	return distribution.variants
+}
+
+// GetVariant returns the variant of the image (the minimal variant is the default for minimal base images, if the distribution has one).
+func (distribution *baseDistribution) GetVariant() (*Variant, error) {
+	return resolveVariant(distribution.baseImage, distribution.variants, distribution.getTypeVariantName(distribution.baseImage.GetMinimal()))
+}
+
+// getTypeVariantName returns the name of the variant used for the complete or minimal type of the distribution.
+func (distribution *baseDistribution) getTypeVariantName(minimal bool) string {
+	if minimal && distribution.minimalVariant != "" {
+		return distribution.minimalVariant
+	}
+
+	return distribution.defaultVariant
+}
+
+// getTypeVariant returns the variant used for the complete or minimal type of the distribution (without architectures and formats if it is not declared).
+func (distribution *baseDistribution) getTypeVariant(minimal bool) *Variant {
+	name := distribution.getTypeVariantName(minimal)
+	for _, variant := range distribution.variants {
+		if variant.GetName() == name {
+			return variant
+		}
+	}
+
+	return NewVariant(name, []string{}, []string{})
+}
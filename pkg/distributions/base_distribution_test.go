@@ -0,0 +1,404 @@
+package distributions
+
+import (
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// TestBaseDistributionDefaults tests the default values of the distributions built on the shared base.
+func TestBaseDistributionDefaults(t *testing.T) {
+	tests := []struct {
+		name          string
+		distribution  Distribution
+		baseUrl       string
+		architectures []string
+		formats       []string
+	}{
+		{"rocky", NewRocky(), "https://dl.rockylinux.org/pub/rocky", []string{"x86_64", "aarch64"}, []string{"qcow2"}},
+		{"alma", NewAlma(), "https://repo.almalinux.org/almalinux", []string{"x86_64", "aarch64"}, []string{"qcow2"}},
+		{"centos", NewCentOS(), "https://cloud.centos.org/centos", []string{"x86_64", "aarch64"}, []string{"qcow2"}},
+		{"fedora", NewFedora(), "https://download.fedoraproject.org/pub/fedora/linux/releases", []string{"x86_64", "aarch64"}, []string{"qcow2"}},
+		{"opensuse", NewOpenSUSE(), "https://download.opensuse.org", []string{"x86_64", "aarch64"}, []string{"qcow2"}},
+		{"alpine", NewAlpine(), "https://dl-cdn.alpinelinux.org/alpine", []string{"x86_64", "aarch64"}, []string{"qcow2"}},
+		{"arch", NewArch(), "https://geo.mirror.pkgbuild.com/images", []string{"x86_64"}, []string{"qcow2"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			distribution := test.distribution
+
+			if distribution.GetCompleteVersionBaseUrl() != test.baseUrl || distribution.GetMinimalVersionBaseUrl() != test.baseUrl {
+				t.Errorf("base URLs are %s / %s, want %s", distribution.GetCompleteVersionBaseUrl(), distribution.GetMinimalVersionBaseUrl(), test.baseUrl)
+			}
+
+			for _, architectures := range [][]string{distribution.GetCompleteSupportedArchitectures(), distribution.GetMinimalSupportedArchitectures()} {
+				if !reflect.DeepEqual(architectures, test.architectures) {
+					t.Errorf("supported architectures are %v, want %v", architectures, test.architectures)
+				}
+			}
+
+			for _, formats := range [][]string{distribution.GetCompleteSupportedImageFormats(), distribution.GetMinimalSupportedImageFormats()} {
+				if !reflect.DeepEqual(formats, test.formats) {
+					t.Errorf("supported image formats are %v, want %v", formats, test.formats)
+				}
+			}
+		})
+	}
+}
+
+// TestBaseDistributionInitialize tests the releases and versions set by the Initialize function.
+func TestBaseDistributionInitialize(t *testing.T) {
+	tests := []struct {
+		name             string
+		release          string
+		releaseToVersion map[string]string
+	}{
+		{"rocky", "9", map[string]string{"green-obsidian": "8", "blue-onyx": "9"}},
+		{"alma", "9", map[string]string{"8": "8", "9": "9"}},
+		{"centos", "9-stream", map[string]string{"9-stream": "9", "10-stream": "10"}},
+		{"fedora", "41", map[string]string{"40": "40", "41": "41", "42": "42"}},
+		{"opensuse", "15.6", map[string]string{"15.6": "15.6", "tumbleweed": "tumbleweed"}},
+		{"alpine", "3.20", map[string]string{"3.20": "3.20.3", "3.21": "3.21.0"}},
+		{"arch", "latest", map[string]string{"latest": "latest"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			distribution := newNamedMap()[test.name]
+			baseImage := &bi.Configuration{Distribution: test.name, Release: test.release, Architecture: "x86_64", Format: "qcow2"}
+
+			if result := distribution.Initialize(baseImage); result != distribution {
+				t.Error("Initialize() did not return the distribution instance")
+			}
+
+			// NOTE: Initializing twice must not duplicate the supported releases and versions.
+			distribution.Initialize(baseImage)
+
+			if result := distribution.GetReleaseToVersion(); !reflect.DeepEqual(result, test.releaseToVersion) {
+				t.Errorf("GetReleaseToVersion returned %v, want %v", result, test.releaseToVersion)
+			}
+
+			expectedVersionToRelease := map[string]string{}
+			expectedReleases := []string{}
+			expectedVersions := []string{}
+			for release, version := range test.releaseToVersion {
+				expectedVersionToRelease[version] = release
+				expectedReleases = append(expectedReleases, release)
+				expectedVersions = append(expectedVersions, version)
+			}
+			sort.Strings(expectedReleases)
+			sort.Strings(expectedVersions)
+
+			if result := distribution.GetVersionToRelease(); !reflect.DeepEqual(result, expectedVersionToRelease) {
+				t.Errorf("GetVersionToRelease returned %v, want %v", result, expectedVersionToRelease)
+			}
+
+			releases := append([]string{}, distribution.GetSupportedReleases()...)
+			sort.Strings(releases)
+			if !reflect.DeepEqual(releases, expectedReleases) {
+				t.Errorf("GetSupportedReleases returned %v, want %v", releases, expectedReleases)
+			}
+
+			versions := append([]string{}, distribution.GetSupportedVersions()...)
+			sort.Strings(versions)
+			if !reflect.DeepEqual(versions, expectedVersions) {
+				t.Errorf("GetSupportedVersions returned %v, want %v", versions, expectedVersions)
+			}
+		})
+	}
+}
+
+// TestBaseDistributionCustomization tests the package manager, init system and SELinux settings of the distributions.
+func TestBaseDistributionCustomization(t *testing.T) {
+	tests := []struct {
+		name           string
+		distribution   string
+		release        string
+		packageManager string
+		initSystem     string
+		selinux        bool
+	}{
+		{"ubuntu", "ubuntu", "jammy", PackageManagerApt, InitSystemSystemd, false},
+		{"debian", "debian", "bookworm", PackageManagerApt, InitSystemSystemd, false},
+		{"rocky", "rocky", "9", PackageManagerDnf, InitSystemSystemd, true},
+		{"alma", "alma", "9", PackageManagerDnf, InitSystemSystemd, true},
+		{"centos", "centos", "9-stream", PackageManagerDnf, InitSystemSystemd, true},
+		{"fedora", "fedora", "41", PackageManagerDnf, InitSystemSystemd, true},
+		{"opensuse leap", "opensuse", "15.6", PackageManagerZypper, InitSystemSystemd, false},
+		{"opensuse tumbleweed", "opensuse", "tumbleweed", PackageManagerZypper, InitSystemSystemd, true},
+		{"alpine", "alpine", "3.20", PackageManagerApk, InitSystemOpenRC, false},
+		{"arch", "arch", "latest", PackageManagerPacman, InitSystemSystemd, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			distribution := newNamedMap()[test.distribution].Initialize(&bi.Configuration{Distribution: test.distribution, Release: test.release})
+
+			if distribution.GetPackageManager() != test.packageManager || distribution.GetInitSystem() != test.initSystem || distribution.IsSELinuxEnabled() != test.selinux {
+				t.Errorf(
+					"customization settings are %s / %s / %v, want %s / %s / %v",
+					distribution.GetPackageManager(),
+					distribution.GetInitSystem(),
+					distribution.IsSELinuxEnabled(),
+					test.packageManager,
+					test.initSystem,
+					test.selinux,
+				)
+			}
+		})
+	}
+}
+
+// TestBaseDistributionVariantSupport tests that architectures and formats are checked against the selected variant.
+func TestBaseDistributionVariantSupport(t *testing.T) {
+	tests := []struct {
+		name         string
+		baseImage    *bi.Configuration
+		architecture string
+		format       string
+		expected     bool
+	}{
+		{"rocky complete", &bi.Configuration{Distribution: "rocky", Release: "9"}, "aarch64", "qcow2", true},
+		{"rocky minimal", &bi.Configuration{Distribution: "rocky", Release: "9", Minimal: true}, "aarch64", "qcow2", true},
+		{"rocky unsupported format", &bi.Configuration{Distribution: "rocky", Release: "9"}, "x86_64", "img", false},
+		{"arch unsupported architecture", &bi.Configuration{Distribution: "arch", Release: "latest"}, "aarch64", "qcow2", false},
+		{"debian minimal variant", &bi.Configuration{Distribution: "debian", Release: "bookworm", Minimal: true}, "arm64", "qcow2", false},
+		{"debian selected variant", &bi.Configuration{Distribution: "debian", Release: "bookworm", Minimal: true, Variant: "nocloud"}, "arm64", "qcow2", true},
+		{"unknown variant", &bi.Configuration{Distribution: "alma", Release: "9", Variant: "nocloud"}, "x86_64", "qcow2", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			distribution := newNamedMap()[test.baseImage.GetDistribution()].Initialize(test.baseImage)
+
+			result := distribution.IsArchitectureSupported(test.architecture) && distribution.IsImageFormatSupported(test.format)
+			if result != test.expected {
+				t.Errorf("%s with %s is supported: %v, want %v", test.architecture, test.format, result, test.expected)
+			}
+		})
+	}
+}
+
+// TestBaseDistributionReleaseOrVersion tests the resolution of releases and versions.
+func TestBaseDistributionReleaseOrVersion(t *testing.T) {
+	tests := []struct {
+		name             string
+		distribution     string
+		releaseOrVersion string
+		release          string
+		version          string
+		expectErr        bool
+	}{
+		{"rocky release", "rocky", "blue-onyx", "blue-onyx", "9", false},
+		{"rocky version", "rocky", "8", "green-obsidian", "8", false},
+		{"rocky unsupported", "rocky", "7", "", "", true},
+		{"alma version", "alma", "8", "8", "8", false},
+		{"alma unsupported", "alma", "7", "", "", true},
+		{"centos version", "centos", "10", "10-stream", "10", false},
+		{"alpine point release", "alpine", "3.21.0", "3.21", "3.21.0", false},
+		{"alpine unsupported", "alpine", "3.18", "", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			distribution := newNamedMap()[test.distribution].Initialize(&bi.Configuration{Distribution: test.distribution})
+
+			release, err := distribution.GetReleaseFromReleaseOrVersion(test.releaseOrVersion)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("GetReleaseFromReleaseOrVersion(%s) error = %v, expectErr %v", test.releaseOrVersion, err, test.expectErr)
+			}
+
+			version, err := distribution.GetVersionFromReleaseOrVersion(test.releaseOrVersion)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("GetVersionFromReleaseOrVersion(%s) error = %v, expectErr %v", test.releaseOrVersion, err, test.expectErr)
+			}
+
+			if release != test.release || version != test.version {
+				t.Errorf("resolved %s / %s, want %s / %s", release, version, test.release, test.version)
+			}
+		})
+	}
+}
+
+// TestBaseDistributionGetUrl tests the URLs of the images of the distributions built on the shared base.
+func TestBaseDistributionGetUrl(t *testing.T) {
+	tests := []struct {
+		name      string
+		baseImage *bi.Configuration
+		expected  string
+		expectErr bool
+	}{
+		{
+			"rocky complete x86_64",
+			&bi.Configuration{Distribution: "rocky", Release: "9", Architecture: "x86_64", Format: "qcow2"},
+			"https://dl.rockylinux.org/pub/rocky/9/images/x86_64/Rocky-9-GenericCloud.latest.x86_64.qcow2",
+			false,
+		},
+		{
+			"rocky minimal aarch64 by release",
+			&bi.Configuration{Distribution: "rocky", Release: "green-obsidian", Minimal: true, Architecture: "aarch64", Format: "qcow2"},
+			"https://dl.rockylinux.org/pub/rocky/8/images/aarch64/Rocky-8-GenericCloud.latest.aarch64.qcow2",
+			false,
+		},
+		{
+			"rocky unsupported architecture",
+			&bi.Configuration{Distribution: "rocky", Release: "9", Architecture: "amd64", Format: "qcow2"},
+			"",
+			true,
+		},
+		{
+			"alma complete x86_64",
+			&bi.Configuration{Distribution: "alma", Release: "9", Architecture: "x86_64", Format: "qcow2"},
+			"https://repo.almalinux.org/almalinux/9/cloud/x86_64/images/AlmaLinux-9-GenericCloud-latest.x86_64.qcow2",
+			false,
+		},
+		{
+			"alma minimal aarch64",
+			&bi.Configuration{Distribution: "alma", Release: "8", Minimal: true, Architecture: "aarch64", Format: "qcow2"},
+			"https://repo.almalinux.org/almalinux/8/cloud/aarch64/images/AlmaLinux-8-GenericCloud-latest.aarch64.qcow2",
+			false,
+		},
+		{
+			"alma unsupported format",
+			&bi.Configuration{Distribution: "alma", Release: "9", Architecture: "x86_64", Format: "img"},
+			"",
+			true,
+		},
+		{
+			"centos complete by release",
+			&bi.Configuration{Distribution: "centos", Release: "9-stream", Architecture: "x86_64", Format: "qcow2", Serial: "20241118.0"},
+			"https://cloud.centos.org/centos/9-stream/x86_64/images/CentOS-Stream-GenericCloud-9-20241118.0.x86_64.qcow2",
+			false,
+		},
+		{
+			"centos minimal by version",
+			&bi.Configuration{Distribution: "centos", Release: "10", Minimal: true, Architecture: "aarch64", Format: "qcow2", Serial: "20250113.0"},
+			"https://cloud.centos.org/centos/10-stream/aarch64/images/CentOS-Stream-GenericCloud-10-20250113.0.aarch64.qcow2",
+			false,
+		},
+		{
+			"centos unsupported release",
+			&bi.Configuration{Distribution: "centos", Release: "8-stream", Architecture: "x86_64", Format: "qcow2"},
+			"",
+			true,
+		},
+		{
+			"centos compose not resolved",
+			&bi.Configuration{Distribution: "centos", Release: "9-stream", Architecture: "x86_64", Format: "qcow2"},
+			"",
+			true,
+		},
+		{
+			"fedora complete",
+			&bi.Configuration{Distribution: "fedora", Release: "41", Architecture: "x86_64", Format: "qcow2", Serial: "1.4"},
+			"https://download.fedoraproject.org/pub/fedora/linux/releases/41/Cloud/x86_64/images/Fedora-Cloud-Base-Generic-41-1.4.x86_64.qcow2",
+			false,
+		},
+		{
+			"fedora minimal",
+			&bi.Configuration{Distribution: "fedora", Release: "40", Minimal: true, Architecture: "aarch64", Format: "qcow2", Serial: "1.14"},
+			"https://download.fedoraproject.org/pub/fedora/linux/releases/40/Cloud/aarch64/images/Fedora-Cloud-Base-Generic.aarch64-40-1.14.qcow2",
+			false,
+		},
+		{
+			"fedora unsupported release",
+			&bi.Configuration{Distribution: "fedora", Release: "38", Architecture: "x86_64", Format: "qcow2"},
+			"",
+			true,
+		},
+		{
+			"fedora compose not resolved",
+			&bi.Configuration{Distribution: "fedora", Release: "41", Architecture: "x86_64", Format: "qcow2"},
+			"",
+			true,
+		},
+		{
+			"opensuse leap",
+			&bi.Configuration{Distribution: "opensuse", Release: "15.6", Architecture: "x86_64", Format: "qcow2"},
+			"https://download.opensuse.org/distribution/leap/15.6/appliances/openSUSE-Leap-15.6-Minimal-VM.x86_64-Cloud.qcow2",
+			false,
+		},
+		{
+			"opensuse leap aarch64",
+			&bi.Configuration{Distribution: "opensuse", Release: "15.6", Minimal: true, Architecture: "aarch64", Format: "qcow2"},
+			"https://download.opensuse.org/distribution/leap/15.6/appliances/openSUSE-Leap-15.6-Minimal-VM.aarch64-Cloud.qcow2",
+			false,
+		},
+		{
+			"opensuse tumbleweed",
+			&bi.Configuration{Distribution: "opensuse", Release: "tumbleweed", Architecture: "x86_64", Format: "qcow2"},
+			"https://download.opensuse.org/tumbleweed/appliances/openSUSE-Tumbleweed-Minimal-VM.x86_64-Cloud.qcow2",
+			false,
+		},
+		{
+			"opensuse tumbleweed aarch64",
+			&bi.Configuration{Distribution: "opensuse", Release: "tumbleweed", Architecture: "aarch64", Format: "qcow2"},
+			"https://download.opensuse.org/ports/aarch64/tumbleweed/appliances/openSUSE-Tumbleweed-Minimal-VM.aarch64-Cloud.qcow2",
+			false,
+		},
+		{
+			"opensuse unsupported release",
+			&bi.Configuration{Distribution: "opensuse", Release: "15.5", Architecture: "x86_64", Format: "qcow2"},
+			"",
+			true,
+		},
+		{
+			"alpine release x86_64",
+			&bi.Configuration{Distribution: "alpine", Release: "3.20", Minimal: true, Architecture: "x86_64", Format: "qcow2"},
+			"https://dl-cdn.alpinelinux.org/alpine/v3.20/releases/cloud/nocloud_alpine-3.20.3-x86_64-bios-cloudinit-r0.qcow2",
+			false,
+		},
+		{
+			"alpine version aarch64",
+			&bi.Configuration{Distribution: "alpine", Release: "3.21.0", Architecture: "aarch64", Format: "qcow2"},
+			"https://dl-cdn.alpinelinux.org/alpine/v3.21/releases/cloud/nocloud_alpine-3.21.0-aarch64-uefi-cloudinit-r0.qcow2",
+			false,
+		},
+		{
+			"alpine unsupported format",
+			&bi.Configuration{Distribution: "alpine", Release: "3.20", Minimal: true, Architecture: "x86_64", Format: "raw"},
+			"",
+			true,
+		},
+		{
+			"arch complete",
+			&bi.Configuration{Distribution: "arch", Release: "latest", Architecture: "x86_64", Format: "qcow2"},
+			"https://geo.mirror.pkgbuild.com/images/latest/Arch-Linux-x86_64-cloudimg.qcow2",
+			false,
+		},
+		{
+			"arch minimal",
+			&bi.Configuration{Distribution: "arch", Release: "latest", Minimal: true, Architecture: "x86_64", Format: "qcow2"},
+			"https://geo.mirror.pkgbuild.com/images/latest/Arch-Linux-x86_64-cloudimg.qcow2",
+			false,
+		},
+		{
+			"arch unsupported release",
+			&bi.Configuration{Distribution: "arch", Release: "2024.01.01", Architecture: "x86_64", Format: "qcow2"},
+			"",
+			true,
+		},
+		{
+			"arch unsupported architecture",
+			&bi.Configuration{Distribution: "arch", Release: "latest", Architecture: "aarch64", Format: "qcow2"},
+			"",
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := newNamedMap()[test.baseImage.Distribution].Initialize(test.baseImage).GetUrl()
+
+			if (err != nil) != test.expectErr {
+				t.Fatalf("GetUrl() error = %v, expectErr %v", err, test.expectErr)
+			}
+
+			if result != test.expected {
+				t.Errorf("GetUrl returned %s, want %s", result, test.expected)
+			}
+		})
+	}
+}
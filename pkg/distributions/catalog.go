@@ -0,0 +1,144 @@
+package distributions
+
+import (
+	"encoding/json"
+	"fmt"
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	"github.com/darki73/ptm/pkg/log"
+	"github.com/darki73/ptm/pkg/utils"
+	"regexp"
+	"time"
+)
+
+var (
+	// ubuntuCatalogPath is the path of the simplestreams index of released Ubuntu cloud images (relative to the base URL).
+	ubuntuCatalogPath = "streams/v1/com.ubuntu.cloud:released:download.json"
+	// debianReleasePattern is the pattern used to find release directories in the Debian cloud index.
+	debianReleasePattern = regexp.MustCompile(`href="([a-z]+)/"`)
+	// debianVersionPattern is the pattern used to find the version in the image names of a Debian release directory.
+	debianVersionPattern = regexp.MustCompile(`debian-(\d+)-generic`)
+)
+
+// simplestreamsIndex represents the part of a simplestreams index used to discover releases.
+type simplestreamsIndex struct {
+	// Products is a map of product names to products.
+	Products map[string]simplestreamsProduct `json:"products"`
+}
+
+// simplestreamsProduct represents a product of a simplestreams index.
+type simplestreamsProduct struct {
+	// Release is the name of the release (for example, noble).
+	Release string `json:"release"`
+	// Version is the version of the release (for example, 24.04).
+	Version string `json:"version"`
+}
+
+// FetchUbuntuReleases discovers Ubuntu releases and their versions from the simplestreams index.
+func FetchUbuntuReleases(baseUrl string, cacheDirectory string, ttl time.Duration) (map[string]string, error) {
+	url := fmt.Sprintf("%s/%s", baseUrl, ubuntuCatalogPath)
+
+	content, err := utils.FetchWithCache(url, cacheDirectory, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &simplestreamsIndex{}
+	if err := json.Unmarshal(content, index); err != nil {
+		return nil, fmt.Errorf("failed to parse simplestreams index `%s`: %v", url, err)
+	}
+
+	releases := make(map[string]string)
+	for _, product := range index.Products {
+		if product.Release == "" || product.Version == "" {
+			continue
+		}
+		releases[product.Release] = product.Version
+	}
+
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("simplestreams index `%s` does not list any releases", url)
+	}
+
+	return releases, nil
+}
+
+// FetchDebianReleases discovers Debian releases from the cloud index.
+// The version of each release is taken from the image names in its `latest` directory, releases without a numbered image (sid) are skipped.
+func FetchDebianReleases(baseUrl string, cacheDirectory string, ttl time.Duration) (map[string]string, error) {
+	url := fmt.Sprintf("%s/", baseUrl)
+
+	content, err := utils.FetchWithCache(url, cacheDirectory, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make(map[string]string)
+	for _, releaseMatch := range debianReleasePattern.FindAllStringSubmatch(string(content), -1) {
+		release := releaseMatch[1]
+		if _, ok := releases[release]; ok {
+			continue
+		}
+
+		listing, err := utils.FetchWithCache(fmt.Sprintf("%s/%s/latest/", baseUrl, release), cacheDirectory, ttl)
+		if err != nil {
+			continue
+		}
+
+		if versionMatch := debianVersionPattern.FindStringSubmatch(string(listing)); versionMatch != nil {
+			releases[release] = versionMatch[1]
+		}
+	}
+
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("cloud index `%s` does not list any releases", url)
+	}
+
+	return releases, nil
+}
+
+// discoverReleases returns the built-in releases merged with the releases discovered by the fetch function.
+// The built-in releases are returned on their own when the catalog is disabled or the index cannot be fetched (a warning is logged for the latter).
+func discoverReleases(
+	baseImage *bi.Configuration,
+	builtin map[string]string,
+	fetch func(cacheDirectory string, ttl time.Duration) (map[string]string, error),
+) map[string]string {
+	releases := make(map[string]string, len(builtin))
+	for release, version := range builtin {
+		releases[release] = version
+	}
+
+	if baseImage == nil || baseImage.GetCatalog() == nil || !baseImage.GetCatalog().GetEnabled() {
+		return releases
+	}
+
+	ttl, err := baseImage.GetCatalog().GetCacheTtl()
+	if err != nil {
+		warnCatalogFallback(baseImage, err)
+		return releases
+	}
+
+	discovered, err := fetch(baseImage.GetCatalog().GetCacheDirectory(), ttl)
+	if err != nil {
+		warnCatalogFallback(baseImage, err)
+		return releases
+	}
+
+	for release, version := range discovered {
+		releases[release] = version
+	}
+
+	return releases
+}
+
+// warnCatalogFallback logs the warning about the releases of the distribution that could not be discovered.
+func warnCatalogFallback(baseImage *bi.Configuration, err error) {
+	log.WarnfWithFields(
+		"unable to discover releases of %s, using the built-in releases: %v",
+		log.FieldsMap{
+			"source": "catalog",
+		},
+		baseImage.GetDistribution(),
+		err,
+	)
+}
@@ -0,0 +1,249 @@
+package distributions
+
+import (
+	"bytes"
+	"fmt"
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	"github.com/darki73/ptm/pkg/log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// catalogTestSimplestreamsIndex is the simplestreams index served by the test server.
+const catalogTestSimplestreamsIndex = `{
+	"format": "products:1.0",
+	"products": {
+		"com.ubuntu.cloud:server:24.04:amd64": {"release": "noble", "version": "24.04", "arch": "amd64"},
+		"com.ubuntu.cloud:server:24.04:arm64": {"release": "noble", "version": "24.04", "arch": "arm64"},
+		"com.ubuntu.cloud:server:25.10:amd64": {"release": "questing", "version": "25.10", "arch": "amd64"},
+		"com.ubuntu.cloud:server:broken:amd64": {"arch": "amd64"}
+	}
+}`
+
+// catalogTestDebianListings is the map of paths to the directory listings of the Debian cloud index served by the test server.
+var catalogTestDebianListings = map[string]string{
+	"/": `<a href="OpenStack/">OpenStack/</a>
+<a href="bookworm/">bookworm/</a>
+<a href="bookworm-backports/">bookworm-backports/</a>
+<a href="forky/">forky/</a>
+<a href="sid/">sid/</a>`,
+	"/bookworm/latest/": `<a href="debian-12-genericcloud-amd64.qcow2">debian-12-genericcloud-amd64.qcow2</a>`,
+	"/forky/latest/":    `<a href="debian-14-generic-amd64.qcow2">debian-14-generic-amd64.qcow2</a>`,
+	"/sid/latest/":      `<a href="debian-sid-generic-amd64.qcow2">debian-sid-generic-amd64.qcow2</a>`,
+}
+
+// newCatalogTestServer returns a test server that serves the Ubuntu and Debian indexes and counts the requests.
+func newCatalogTestServer(t *testing.T, requests *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		*requests++
+
+		if request.URL.Path == "/"+ubuntuCatalogPath {
+			fmt.Fprint(writer, catalogTestSimplestreamsIndex)
+			return
+		}
+
+		if listing, ok := catalogTestDebianListings[request.URL.Path]; ok {
+			fmt.Fprint(writer, listing)
+			return
+		}
+
+		http.NotFound(writer, request)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newCatalogTestBaseImage returns a base image configuration with the release catalog enabled.
+func newCatalogTestBaseImage(t *testing.T, distribution string, release string) *bi.Configuration {
+	return &bi.Configuration{
+		Distribution: distribution,
+		Release:      release,
+		Minimal:      false,
+		Architecture: "amd64",
+		Format:       "qcow2",
+		Catalog: &bi.Catalog{
+			Enabled:        true,
+			CacheDirectory: t.TempDir(),
+			CacheTtl:       "1h",
+		},
+	}
+}
+
+// TestFetchUbuntuReleases tests the FetchUbuntuReleases function.
+func TestFetchUbuntuReleases(t *testing.T) {
+	requests := 0
+	server := newCatalogTestServer(t, &requests)
+	cacheDirectory := t.TempDir()
+
+	result, err := FetchUbuntuReleases(server.URL, cacheDirectory, time.Hour)
+	if err != nil {
+		t.Fatalf("FetchUbuntuReleases returned error: %v", err)
+	}
+
+	expected := map[string]string{
+		"noble":    "24.04",
+		"questing": "25.10",
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("FetchUbuntuReleases returned %v, want %v", result, expected)
+	}
+
+	if _, err := FetchUbuntuReleases(server.URL, cacheDirectory, time.Hour); err != nil {
+		t.Fatalf("FetchUbuntuReleases returned error: %v", err)
+	}
+
+	if requests != 1 {
+		t.Errorf("FetchUbuntuReleases made %d requests, want 1 (second call should be cached)", requests)
+	}
+}
+
+// TestFetchDebianReleases tests the FetchDebianReleases function.
+func TestFetchDebianReleases(t *testing.T) {
+	requests := 0
+	server := newCatalogTestServer(t, &requests)
+
+	result, err := FetchDebianReleases(server.URL, t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("FetchDebianReleases returned error: %v", err)
+	}
+
+	expected := map[string]string{
+		"bookworm": "12",
+		"forky":    "14",
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("FetchDebianReleases returned %v, want %v", result, expected)
+	}
+}
+
+// TestFetchReleasesErrors tests the FetchUbuntuReleases and FetchDebianReleases functions with unusable indexes.
+func TestFetchReleasesErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, "not an index")
+	}))
+	defer server.Close()
+
+	if _, err := FetchUbuntuReleases(server.URL, t.TempDir(), time.Hour); err == nil {
+		t.Error("FetchUbuntuReleases returned no error for an invalid index")
+	}
+
+	if _, err := FetchDebianReleases(server.URL, t.TempDir(), time.Hour); err == nil {
+		t.Error("FetchDebianReleases returned no error for an index without releases")
+	}
+}
+
+// TestUbuntuInitializeWithCatalog tests that Initialize merges discovered releases over the built-in releases.
+func TestUbuntuInitializeWithCatalog(t *testing.T) {
+	requests := 0
+	server := newCatalogTestServer(t, &requests)
+
+	ubuntu := NewUbuntu()
+	ubuntu.completeVersionBaseUrl = server.URL
+	ubuntu.Initialize(newCatalogTestBaseImage(t, "ubuntu", "questing"))
+
+	tests := []struct {
+		release string
+		version string
+	}{
+		{"questing", "25.10"},
+		{"noble", "24.04"},
+		{"jammy", "22.04"},
+	}
+
+	for _, test := range tests {
+		result, err := ubuntu.GetVersionFromRelease(test.release)
+		if err != nil || result != test.version {
+			t.Errorf("GetVersionFromRelease(%s) returned %v, %v, want %v, nil", test.release, result, err, test.version)
+		}
+	}
+
+	result, err := ubuntu.GetReleaseFromVersion("25.10")
+	if err != nil || result != "questing" {
+		t.Errorf("GetReleaseFromVersion(25.10) returned %v, %v, want questing, nil", result, err)
+	}
+}
+
+// TestDebianInitializeWithCatalog tests that Initialize merges discovered releases over the built-in releases.
+func TestDebianInitializeWithCatalog(t *testing.T) {
+	requests := 0
+	server := newCatalogTestServer(t, &requests)
+
+	debian := NewDebian()
+	debian.completeVersionBaseUrl = server.URL
+	debian.Initialize(newCatalogTestBaseImage(t, "debian", "forky"))
+
+	result, err := debian.GetUrl()
+	if err != nil {
+		t.Fatalf("GetUrl returned error: %v", err)
+	}
+
+	expected := fmt.Sprintf("%s/forky/latest/debian-14-generic-amd64.qcow2", server.URL)
+	if result != expected {
+		t.Errorf("GetUrl returned %v, want %v", result, expected)
+	}
+
+	if !debian.IsReleaseSupported("trixie") {
+		t.Error("IsReleaseSupported(trixie) returned false, want true (built-in release)")
+	}
+}
+
+// TestDiscoverReleasesFallback tests that discoverReleases falls back to the built-in releases.
+func TestDiscoverReleasesFallback(t *testing.T) {
+	builtin := map[string]string{"bookworm": "12"}
+	failingFetch := func(cacheDirectory string, ttl time.Duration) (map[string]string, error) {
+		return nil, fmt.Errorf("offline")
+	}
+
+	disabled := newCatalogTestBaseImage(t, "debian", "bookworm")
+	disabled.Catalog.Enabled = false
+
+	invalidTtl := newCatalogTestBaseImage(t, "debian", "bookworm")
+	invalidTtl.Catalog.CacheTtl = "forever"
+
+	output := &bytes.Buffer{}
+	log.SetOutput(output)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+	})
+
+	tests := []struct {
+		name          string
+		baseImage     *bi.Configuration
+		expectWarning bool
+	}{
+		{"no catalog", &bi.Configuration{Distribution: "debian", Release: "bookworm"}, false},
+		{"disabled catalog", disabled, false},
+		{"invalid ttl", invalidTtl, true},
+		{"failing fetch", newCatalogTestBaseImage(t, "debian", "bookworm"), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output.Reset()
+
+			result := discoverReleases(test.baseImage, builtin, failingFetch)
+			if !reflect.DeepEqual(result, builtin) {
+				t.Errorf("discoverReleases returned %v, want %v", result, builtin)
+			}
+
+			warned := strings.Contains(output.String(), "using the built-in releases")
+			if warned != test.expectWarning {
+				t.Errorf("discoverReleases logged a warning: %v, want: %v (output: %s)", warned, test.expectWarning, output.String())
+			}
+		})
+	}
+
+	result := discoverReleases(disabled, builtin, failingFetch)
+	result["trixie"] = "13"
+	if _, ok := builtin["trixie"]; ok {
+		t.Error("discoverReleases returned the built-in map instead of a copy")
+	}
+}
@@ -0,0 +1,188 @@
+package distributions
+
+import (
+	"fmt"
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	"regexp"
+)
+
+// CentOS is the structure that holds configuration for CentOS Stream distributions.
+type CentOS struct {
+	// baseDistribution is the configuration shared by all distributions.
+	baseDistribution
+	// serial is the date of the compose the image is taken from (for example, `20250113.0`), empty means the latest compose.
+	serial string
+}
+
+// NewCentOS returns a new instance of CentOS Stream distribution configuration.
+func NewCentOS() *CentOS {
+	return &CentOS{
+		baseDistribution: baseDistribution{
+			completeVersionBaseUrl: "https://cloud.centos.org/centos",
+			minimalVersionBaseUrl:  "https://cloud.centos.org/centos",
+			variants: []*Variant{
+				NewVariant("genericcloud", []string{"x86_64", "aarch64"}, []string{"qcow2"}),
+			},
+			defaultVariant: "genericcloud",
+			packageManager: PackageManagerDnf,
+			initSystem:     InitSystemSystemd,
+			selinux:        true,
+		},
+		serial: "",
+	}
+}
+
+// Initialize initializes the CentOS Stream distribution.
+func (centos *CentOS) Initialize(baseImage *bi.Configuration) Distribution {
+	releaseToVersion := map[string]string{
+		"9-stream":  "9",
+		"10-stream": "10",
+	}
+
+	centos.serial = baseImage.GetSerial()
+	centos.initialize(baseImage, releaseToVersion)
+
+	return centos
+}
+
+// GetCompose returns the date of the compose the image of the version is taken from.
+func (centos *CentOS) GetCompose(version string) (string, error) {
+	if centos.serial == "" {
+		return "", fmt.Errorf("compose of CentOS Stream `%s` is not resolved, pin it with `base_image.serial` (see `ptm images serials centos %s-stream`)", version, version)
+	}
+
+	return centos.serial, nil
+}
+
+// GetImageName returns the image name.
+func (centos *CentOS) GetImageName() (string, error) {
+	version, err := centos.GetVersionFromReleaseOrVersion(centos.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	if err := validateVariant(centos, centos.baseImage); err != nil {
+		return "", err
+	}
+
+	compose, err := centos.GetCompose(version)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"CentOS-Stream-GenericCloud-%s-%s.%s.%s",
+		version,
+		compose,
+		centos.baseImage.GetArchitecture(),
+		centos.baseImage.GetFormat(),
+	), nil
+}
+
+// GetCompleteVersionUrl returns the complete version URL of the CentOS Stream.
+func (centos *CentOS) GetCompleteVersionUrl() (string, error) {
+	release, err := centos.GetReleaseFromReleaseOrVersion(centos.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := centos.GetImageName()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/%s/images/%s",
+		centos.GetCompleteVersionBaseUrl(),
+		release,
+		centos.baseImage.GetArchitecture(),
+		imageName,
+	), nil
+}
+
+// GetMinimalVersionUrl returns the minimal version URL of the CentOS Stream.
+func (centos *CentOS) GetMinimalVersionUrl() (string, error) {
+	release, err := centos.GetReleaseFromReleaseOrVersion(centos.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := centos.GetImageName()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/%s/images/%s",
+		centos.GetMinimalVersionBaseUrl(),
+		release,
+		centos.baseImage.GetArchitecture(),
+		imageName,
+	), nil
+}
+
+// GetUrl returns the URL of the CentOS Stream.
+func (centos *CentOS) GetUrl() (string, error) {
+	if centos.baseImage.GetMinimal() {
+		return centos.GetMinimalVersionUrl()
+	}
+
+	return centos.GetCompleteVersionUrl()
+}
+
+// GetChecksumUrl returns the URL of the `.SHA256SUM` file published for the CentOS Stream image.
+func (centos *CentOS) GetChecksumUrl() (string, error) {
+	url, err := centos.GetUrl()
+	if err != nil {
+		return "", err
+	}
+
+	return url + ".SHA256SUM", nil
+}
+
+// GetSignatureUrl returns an empty string as CentOS Stream does not publish a detached signature of the checksum file.
+func (centos *CentOS) GetSignatureUrl() (string, error) {
+	return "", nil
+}
+
+// GetSerial returns the date of the compose the CentOS Stream image is taken from (empty means the latest compose).
+func (centos *CentOS) GetSerial() string {
+	return centos.serial
+}
+
+// SetSerial sets the date of the compose the CentOS Stream image is taken from.
+func (centos *CentOS) SetSerial(serial string) {
+	centos.serial = serial
+}
+
+// GetSerialsUrl returns the URL of the directory listing the images of the CentOS Stream release.
+func (centos *CentOS) GetSerialsUrl() (string, error) {
+	release, err := centos.GetReleaseFromReleaseOrVersion(centos.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/%s/images/",
+		centos.GetCompleteVersionBaseUrl(),
+		release,
+		centos.baseImage.GetArchitecture(),
+	), nil
+}
+
+// ParseSerials returns the composes of the images listed in the directory of the CentOS Stream release (oldest first).
+func (centos *CentOS) ParseSerials(listing string) []string {
+	version, err := centos.GetVersionFromReleaseOrVersion(centos.baseImage.GetRelease())
+	if err != nil {
+		return []string{}
+	}
+
+	pattern := regexp.MustCompile(fmt.Sprintf(
+		`href="CentOS-Stream-GenericCloud-%s-(\d{8}\.\d+)\.%s\.%s"`,
+		regexp.QuoteMeta(version),
+		regexp.QuoteMeta(centos.baseImage.GetArchitecture()),
+		regexp.QuoteMeta(centos.baseImage.GetFormat()),
+	))
+
+	return parseSerials(pattern, listing)
+}
@@ -0,0 +1,8 @@
+package distributions
+
+import "strings"
+
+// replaceFileName replaces the file name at the end of the URL.
+func replaceFileName(url string, fileName string) string {
+	return url[:strings.LastIndex(url, "/")+1] + fileName
+}
@@ -0,0 +1,87 @@
+package distributions
+
+import (
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	"testing"
+)
+
+// TestGetChecksumUrl tests the GetChecksumUrl and GetSignatureUrl functions of the distributions.
+func TestGetChecksumUrl(t *testing.T) {
+	tests := []struct {
+		name              string
+		baseImage         *bi.Configuration
+		expectedChecksum  string
+		expectedSignature string
+	}{
+		{
+			"ubuntu minimal",
+			&bi.Configuration{Distribution: "ubuntu", Release: "jammy", Minimal: true, Architecture: "amd64", Format: "img"},
+			"https://cloud-images.ubuntu.com/minimal/releases/jammy/release/SHA256SUMS",
+			"https://cloud-images.ubuntu.com/minimal/releases/jammy/release/SHA256SUMS.gpg",
+		},
+		{
+			"debian",
+			&bi.Configuration{Distribution: "debian", Release: "bookworm", Minimal: false, Architecture: "amd64", Format: "qcow2"},
+			"https://cloud.debian.org/images/cloud/bookworm/latest/SHA512SUMS",
+			"",
+		},
+		{
+			"alma",
+			&bi.Configuration{Distribution: "alma", Release: "9", Architecture: "x86_64", Format: "qcow2"},
+			"https://repo.almalinux.org/almalinux/9/cloud/x86_64/images/CHECKSUM",
+			"",
+		},
+		{
+			"fedora",
+			&bi.Configuration{Distribution: "fedora", Release: "41", Architecture: "x86_64", Format: "qcow2", Serial: "1.4"},
+			"https://download.fedoraproject.org/pub/fedora/linux/releases/41/Cloud/x86_64/images/Fedora-Cloud-41-1.4-x86_64-CHECKSUM",
+			"",
+		},
+		{
+			"centos",
+			&bi.Configuration{Distribution: "centos", Release: "9-stream", Architecture: "x86_64", Format: "qcow2", Serial: "20241118.0"},
+			"https://cloud.centos.org/centos/9-stream/x86_64/images/CentOS-Stream-GenericCloud-9-20241118.0.x86_64.qcow2.SHA256SUM",
+			"",
+		},
+		{
+			"opensuse",
+			&bi.Configuration{Distribution: "opensuse", Release: "15.6", Architecture: "x86_64", Format: "qcow2"},
+			"https://download.opensuse.org/distribution/leap/15.6/appliances/openSUSE-Leap-15.6-Minimal-VM.x86_64-Cloud.qcow2.sha256",
+			"https://download.opensuse.org/distribution/leap/15.6/appliances/openSUSE-Leap-15.6-Minimal-VM.x86_64-Cloud.qcow2.sha256.asc",
+		},
+		{
+			"alpine",
+			&bi.Configuration{Distribution: "alpine", Release: "3.21", Architecture: "x86_64", Format: "qcow2"},
+			"https://dl-cdn.alpinelinux.org/alpine/v3.21/releases/cloud/nocloud_alpine-3.21.0-x86_64-bios-cloudinit-r0.qcow2.sha512",
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			distros, err := NewDistributions(test.baseImage, nil)
+			if err != nil {
+				t.Fatalf("NewDistributions returned error: %v", err)
+			}
+
+			result, err := distros.GetActiveDistribution().GetChecksumUrl()
+			if err != nil || result != test.expectedChecksum {
+				t.Errorf("GetChecksumUrl returned %v, %v, want %v, nil", result, err, test.expectedChecksum)
+			}
+
+			result, err = distros.GetActiveDistribution().GetSignatureUrl()
+			if err != nil || result != test.expectedSignature {
+				t.Errorf("GetSignatureUrl returned %v, %v, want %v, nil", result, err, test.expectedSignature)
+			}
+		})
+	}
+}
+
+// TestGetChecksumUrlUnsupportedRelease tests that GetChecksumUrl returns an error for unsupported releases.
+func TestGetChecksumUrlUnsupportedRelease(t *testing.T) {
+	rocky := NewRocky().Initialize(&bi.Configuration{Distribution: "rocky", Release: "7", Architecture: "x86_64", Format: "qcow2"})
+
+	if _, err := rocky.GetChecksumUrl(); err == nil {
+		t.Error("GetChecksumUrl returned no error for an unsupported release")
+	}
+}
@@ -0,0 +1,186 @@
+package distributions
+
+import (
+	"fmt"
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	dc "github.com/darki73/ptm/pkg/configuration/distribution"
+	"github.com/darki73/ptm/pkg/utils"
+	"strings"
+)
+
+var (
+	// packageManagers is the list of package managers images can be customized with.
+	packageManagers = []string{
+		PackageManagerApt,
+		PackageManagerDnf,
+		PackageManagerZypper,
+		PackageManagerApk,
+		PackageManagerPacman,
+	}
+	// initSystems is the list of init systems services can be enabled with.
+	initSystems = []string{
+		InitSystemSystemd,
+		InitSystemOpenRC,
+	}
+)
+
+// Custom is the structure that holds configuration for distributions defined in the configuration file.
+type Custom struct {
+	// baseDistribution is the configuration shared by all distributions.
+	baseDistribution
+	// configuration is the user configuration of the distribution.
+	configuration *dc.Configuration
+}
+
+// NewCustom returns a new instance of a distribution defined in the configuration file.
+func NewCustom(configuration *dc.Configuration) (*Custom, error) {
+	if _, err := configuration.IsConfigurationValid(); err != nil {
+		return nil, err
+	}
+
+	if !utils.SliceContains(packageManagers, configuration.GetPackageManager()) {
+		return nil, fmt.Errorf(
+			"distribution `%s` uses unsupported package manager `%s`, supported package managers: %s",
+			configuration.GetName(),
+			configuration.GetPackageManager(),
+			strings.Join(packageManagers, ", "),
+		)
+	}
+
+	if !utils.SliceContains(initSystems, configuration.GetInitSystem()) {
+		return nil, fmt.Errorf(
+			"distribution `%s` uses unsupported init system `%s`, supported init systems: %s",
+			configuration.GetName(),
+			configuration.GetInitSystem(),
+			strings.Join(initSystems, ", "),
+		)
+	}
+
+	return &Custom{
+		baseDistribution: baseDistribution{
+			completeVersionBaseUrl: configuration.GetUrl(),
+			minimalVersionBaseUrl:  configuration.GetUrl(),
+			variants: []*Variant{
+				NewVariant(configuration.GetVariant(), configuration.GetArchitectures(), configuration.GetFormats()),
+			},
+			defaultVariant: configuration.GetVariant(),
+			packageManager: configuration.GetPackageManager(),
+			initSystem:     configuration.GetInitSystem(),
+			selinux:        configuration.GetSELinux(),
+		},
+		configuration: configuration,
+	}, nil
+}
+
+// Initialize initializes the distribution.
+func (custom *Custom) Initialize(baseImage *bi.Configuration) Distribution {
+	custom.initialize(baseImage, custom.configuration.GetReleases())
+
+	return custom
+}
+
+// GetImageName returns the image name.
+func (custom *Custom) GetImageName() (string, error) {
+	if err := validateVariant(custom, custom.baseImage); err != nil {
+		return "", err
+	}
+
+	return custom.render(custom.configuration.GetImageName(), "")
+}
+
+// GetCompleteVersionUrl returns the URL of the image, as the distribution has no separate complete type.
+func (custom *Custom) GetCompleteVersionUrl() (string, error) {
+	return custom.GetUrl()
+}
+
+// GetMinimalVersionUrl returns the URL of the image, as the distribution has no separate minimal type.
+func (custom *Custom) GetMinimalVersionUrl() (string, error) {
+	return custom.GetUrl()
+}
+
+// GetUrl returns the URL of the image (the image name is appended if the template does not contain `{image}`).
+func (custom *Custom) GetUrl() (string, error) {
+	imageName, err := custom.GetImageName()
+	if err != nil {
+		return "", err
+	}
+
+	url := custom.configuration.GetUrl()
+	if !strings.Contains(url, "{image}") {
+		url = strings.TrimSuffix(url, "/") + "/{image}"
+	}
+
+	return custom.render(url, imageName)
+}
+
+// GetChecksumUrl returns the URL of the checksum file declared by the distribution.
+func (custom *Custom) GetChecksumUrl() (string, error) {
+	if custom.configuration.GetChecksum() == "" {
+		return "", fmt.Errorf(
+			"distribution `%s` does not declare a checksum file, set `downloader.verify_checksum` to false to download its images",
+			custom.configuration.GetName(),
+		)
+	}
+
+	return custom.resolveFileUrl(custom.configuration.GetChecksum())
+}
+
+// GetSignatureUrl returns the URL of the detached signature of the checksum file (empty if the distribution does not declare one).
+func (custom *Custom) GetSignatureUrl() (string, error) {
+	if custom.configuration.GetSignature() == "" {
+		return "", nil
+	}
+
+	return custom.resolveFileUrl(custom.configuration.GetSignature())
+}
+
+// resolveFileUrl renders the template of a file published with the image, file names are resolved next to the image.
+func (custom *Custom) resolveFileUrl(template string) (string, error) {
+	url, err := custom.GetUrl()
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := custom.GetImageName()
+	if err != nil {
+		return "", err
+	}
+
+	rendered, err := custom.render(template, imageName)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.Contains(rendered, "://") {
+		return rendered, nil
+	}
+
+	return replaceFileName(url, rendered), nil
+}
+
+// render replaces the placeholders of the template with the values of the base image.
+func (custom *Custom) render(template string, imageName string) (string, error) {
+	version, err := custom.GetVersionFromReleaseOrVersion(custom.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	release, err := custom.GetReleaseFromReleaseOrVersion(custom.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	variant, err := custom.GetVariant()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.NewReplacer(
+		"{release}", release,
+		"{version}", version,
+		"{architecture}", custom.baseImage.GetArchitecture(),
+		"{format}", custom.baseImage.GetFormat(),
+		"{variant}", variant.GetName(),
+		"{image}", imageName,
+	).Replace(template), nil
+}
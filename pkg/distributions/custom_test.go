@@ -0,0 +1,271 @@
+package distributions
+
+import (
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	dc "github.com/darki73/ptm/pkg/configuration/distribution"
+	"strings"
+	"testing"
+)
+
+// newCustomTestConfiguration returns the configuration of a distribution defined in the configuration file for testing.
+func newCustomTestConfiguration() *dc.Configuration {
+	return &dc.Configuration{
+		Name:           "oracle",
+		Url:            "https://yum.oracle.com/templates/OracleLinux/OL{version}/u4/{architecture}/",
+		ImageName:      "OL{version}U4_{architecture}-kvm-b234.{format}",
+		Checksum:       "{image}.sha256",
+		Releases:       map[string]string{"ol9": "9"},
+		Architectures:  []string{"x86_64", "aarch64"},
+		Formats:        []string{"qcow2"},
+		PackageManager: PackageManagerDnf,
+		SELinux:        true,
+	}
+}
+
+// TestCustomUrls tests the URLs rendered from the templates of a distribution defined in the configuration file.
+func TestCustomUrls(t *testing.T) {
+	baseImage := &bi.Configuration{Distribution: "oracle", Release: "9", Architecture: "x86_64", Format: "qcow2"}
+
+	distros, err := NewDistributions(baseImage, []*dc.Configuration{newCustomTestConfiguration()})
+	if err != nil {
+		t.Fatalf("NewDistributions returned error: %v", err)
+	}
+
+	distribution := distros.GetActiveDistribution()
+
+	tests := []struct {
+		name     string
+		function func() (string, error)
+		expected string
+	}{
+		{"GetImageName", distribution.GetImageName, "OL9U4_x86_64-kvm-b234.qcow2"},
+		{"GetUrl", distribution.GetUrl, "https://yum.oracle.com/templates/OracleLinux/OL9/u4/x86_64/OL9U4_x86_64-kvm-b234.qcow2"},
+		{"GetChecksumUrl", distribution.GetChecksumUrl, "https://yum.oracle.com/templates/OracleLinux/OL9/u4/x86_64/OL9U4_x86_64-kvm-b234.qcow2.sha256"},
+		{"GetSignatureUrl", distribution.GetSignatureUrl, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.function()
+			if err != nil {
+				t.Fatalf("%s returned error: %v", test.name, err)
+			}
+
+			if result != test.expected {
+				t.Errorf("%s returned %s, want %s", test.name, result, test.expected)
+			}
+		})
+	}
+
+	if distribution.GetPackageManager() != PackageManagerDnf || distribution.GetInitSystem() != InitSystemSystemd || !distribution.IsSELinuxEnabled() {
+		t.Errorf("customization settings are %s / %s / %v, want dnf / systemd / true", distribution.GetPackageManager(), distribution.GetInitSystem(), distribution.IsSELinuxEnabled())
+	}
+}
+
+// TestCustomOverridesBuiltin tests that a distribution defined in the configuration file replaces the built-in one with the same name.
+func TestCustomOverridesBuiltin(t *testing.T) {
+	configuration := newCustomTestConfiguration()
+	configuration.Name = "ubuntu"
+	configuration.Url = "https://mirror.example.com/ubuntu/{release}/{image}"
+	configuration.ImageName = "ubuntu-{version}-{variant}-{architecture}.{format}"
+	configuration.Checksum = "https://mirror.example.com/ubuntu/{release}/SHA256SUMS"
+	configuration.Signature = "SHA256SUMS.gpg"
+	configuration.Releases = map[string]string{"noble": "24.04"}
+	configuration.Architectures = []string{"amd64"}
+	configuration.Formats = []string{"img"}
+	configuration.Variant = "server"
+	configuration.PackageManager = PackageManagerApt
+
+	baseImage := &bi.Configuration{Distribution: "ubuntu", Release: "noble", Architecture: "amd64", Format: "img"}
+
+	distros, err := NewDistributions(baseImage, []*dc.Configuration{configuration})
+	if err != nil {
+		t.Fatalf("NewDistributions returned error: %v", err)
+	}
+
+	distribution := distros.GetActiveDistribution()
+	if _, ok := distribution.(*Custom); !ok {
+		t.Fatalf("active distribution is %T, want *Custom", distribution)
+	}
+
+	url, err := distribution.GetUrl()
+	if err != nil {
+		t.Fatalf("GetUrl returned error: %v", err)
+	}
+
+	if expected := "https://mirror.example.com/ubuntu/noble/ubuntu-24.04-server-amd64.img"; url != expected {
+		t.Errorf("GetUrl returned %s, want %s", url, expected)
+	}
+
+	checksumUrl, err := distribution.GetChecksumUrl()
+	if err != nil {
+		t.Fatalf("GetChecksumUrl returned error: %v", err)
+	}
+
+	if expected := "https://mirror.example.com/ubuntu/noble/SHA256SUMS"; checksumUrl != expected {
+		t.Errorf("GetChecksumUrl returned %s, want %s", checksumUrl, expected)
+	}
+
+	signatureUrl, err := distribution.GetSignatureUrl()
+	if err != nil {
+		t.Fatalf("GetSignatureUrl returned error: %v", err)
+	}
+
+	if expected := "https://mirror.example.com/ubuntu/noble/SHA256SUMS.gpg"; signatureUrl != expected {
+		t.Errorf("GetSignatureUrl returned %s, want %s", signatureUrl, expected)
+	}
+
+	if !distros.IsDistributionSupported("debian") {
+		t.Error("Expected built-in 'debian' to still be supported")
+	}
+}
+
+// TestCustomExtendsBuiltin tests that a distribution defined in the configuration file without `url` adds its releases to the built-in one with the same name.
+func TestCustomExtendsBuiltin(t *testing.T) {
+	tests := []struct {
+		name         string
+		releases     map[string]string
+		baseImage    *bi.Configuration
+		expectedUrl  string
+		expectSerial bool
+		expectError  bool
+	}{
+		{
+			"new release",
+			map[string]string{"red-quartz": "10"},
+			&bi.Configuration{Distribution: "rocky", Release: "red-quartz", Architecture: "x86_64", Format: "qcow2"},
+			"https://dl.rockylinux.org/pub/rocky/10/images/x86_64/Rocky-10-GenericCloud.latest.x86_64.qcow2",
+			false,
+			false,
+		},
+		{
+			"built-in release kept",
+			map[string]string{"red-quartz": "10"},
+			&bi.Configuration{Distribution: "rocky", Release: "blue-onyx", Architecture: "x86_64", Format: "qcow2"},
+			"https://dl.rockylinux.org/pub/rocky/9/images/x86_64/Rocky-9-GenericCloud.latest.x86_64.qcow2",
+			false,
+			false,
+		},
+		{
+			"serials kept",
+			map[string]string{"plucky": "25.04"},
+			&bi.Configuration{Distribution: "ubuntu", Release: "plucky", Architecture: "amd64", Format: "img", Serial: "20261001"},
+			"https://cloud-images.ubuntu.com/releases/plucky/release-20261001/ubuntu-25.04-server-cloudimg-amd64.img",
+			true,
+			false,
+		},
+		{
+			"missing releases",
+			map[string]string{},
+			&bi.Configuration{Distribution: "rocky", Release: "9", Architecture: "x86_64", Format: "qcow2"},
+			"",
+			false,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configuration := &dc.Configuration{Name: test.baseImage.GetDistribution(), Releases: test.releases}
+
+			distros, err := NewDistributions(test.baseImage, []*dc.Configuration{configuration})
+			if (err != nil) != test.expectError {
+				t.Fatalf("NewDistributions returned error %v, want error: %v", err, test.expectError)
+			}
+			if test.expectError {
+				return
+			}
+
+			distribution := distros.GetActiveDistribution()
+			if _, ok := distribution.(*Custom); ok {
+				t.Fatal("active distribution is *Custom, want the built-in one")
+			}
+
+			if IsSerialPublisher(distribution) != test.expectSerial {
+				t.Errorf("IsSerialPublisher returned %v, want %v", IsSerialPublisher(distribution), test.expectSerial)
+			}
+
+			url, err := distribution.GetUrl()
+			if err != nil || url != test.expectedUrl {
+				t.Errorf("GetUrl returned %s, %v, want %s, nil", url, err, test.expectedUrl)
+			}
+		})
+	}
+}
+
+// TestCustomErrors tests the errors returned for invalid distributions defined in the configuration file.
+func TestCustomErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(configuration *dc.Configuration)
+		baseImage *bi.Configuration
+		expected  string
+	}{
+		{
+			"missing image name",
+			func(configuration *dc.Configuration) { configuration.ImageName = "" },
+			&bi.Configuration{Distribution: "oracle", Release: "9", Architecture: "x86_64", Format: "qcow2"},
+			"distribution `oracle` is missing `image_name`",
+		},
+		{
+			"unsupported package manager",
+			func(configuration *dc.Configuration) { configuration.PackageManager = "yum" },
+			&bi.Configuration{Distribution: "oracle", Release: "9", Architecture: "x86_64", Format: "qcow2"},
+			"distribution `oracle` uses unsupported package manager `yum`",
+		},
+		{
+			"unsupported init system",
+			func(configuration *dc.Configuration) { configuration.InitSystem = "runit" },
+			&bi.Configuration{Distribution: "oracle", Release: "9", Architecture: "x86_64", Format: "qcow2"},
+			"distribution `oracle` uses unsupported init system `runit`",
+		},
+		{
+			"unsupported format",
+			func(configuration *dc.Configuration) {},
+			&bi.Configuration{Distribution: "oracle", Release: "9", Architecture: "x86_64", Format: "raw"},
+			"architecture `x86_64` with format `raw` is not supported by the `generic` variant of `oracle`, valid combinations: generic (x86_64, aarch64: qcow2)",
+		},
+		{
+			"missing checksum",
+			func(configuration *dc.Configuration) { configuration.Checksum = "" },
+			&bi.Configuration{Distribution: "oracle", Release: "9", Architecture: "x86_64", Format: "qcow2"},
+			"distribution `oracle` does not declare a checksum file",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configuration := newCustomTestConfiguration()
+			test.modify(configuration)
+
+			distros, err := NewDistributions(test.baseImage, []*dc.Configuration{configuration})
+			if err == nil {
+				_, err = distros.GetActiveDistribution().GetChecksumUrl()
+			}
+
+			if err == nil {
+				t.Fatal("expected an error, got none")
+			}
+
+			if !strings.HasPrefix(err.Error(), test.expected) {
+				t.Errorf("returned error %q, want %q", err.Error(), test.expected)
+			}
+		})
+	}
+}
+
+// TestGetPackageManagerForCustomDistribution tests that distributions defined in the configuration file take precedence.
+func TestGetPackageManagerForCustomDistribution(t *testing.T) {
+	custom := []*dc.Configuration{newCustomTestConfiguration()}
+
+	if result := GetPackageManagerForDistribution("oracle", custom); result != PackageManagerDnf {
+		t.Errorf("GetPackageManagerForDistribution(oracle) returned %s, want %s", result, PackageManagerDnf)
+	}
+
+	custom[0].Name = "debian"
+	custom[0].PackageManager = PackageManagerApk
+
+	if result := GetPackageManagerForDistribution("debian", custom); result != PackageManagerApk {
+		t.Errorf("GetPackageManagerForDistribution(debian) returned %s, want %s", result, PackageManagerApk)
+	}
+}
@@ -76,6 +76,7 @@ func TestDebianInitialize(t *testing.T) {
 
 	resultMapStringString := debian.GetReleaseToVersion()
 	expectedMapStringString := map[string]string{
+		"trixie":   "13",
 		"bookworm": "12",
 		"bullseye": "11",
 		"buster":   "10",
@@ -88,6 +89,7 @@ func TestDebianInitialize(t *testing.T) {
 
 	resultMapStringString = debian.GetVersionToRelease()
 	expectedMapStringString = map[string]string{
+		"13": "trixie",
 		"12": "bookworm",
 		"11": "bullseye",
 		"10": "buster",
@@ -101,6 +103,7 @@ func TestDebianInitialize(t *testing.T) {
 	resultSliceString := debian.GetSupportedVersions()
 	sort.Strings(resultSliceString)
 	expectedSliceString := []string{
+		"13",
 		"12",
 		"11",
 		"10",
@@ -115,6 +118,7 @@ func TestDebianInitialize(t *testing.T) {
 	resultSliceString = debian.GetSupportedReleases()
 	sort.Strings(resultSliceString)
 	expectedSliceString = []string{
+		"trixie",
 		"bookworm",
 		"bullseye",
 		"buster",
@@ -212,6 +216,7 @@ func TestDebianGetVersionToRelease(t *testing.T) {
 
 	result := debian.GetVersionToRelease()
 	expected := map[string]string{
+		"13": "trixie",
 		"12": "bookworm",
 		"11": "bullseye",
 		"10": "buster",
@@ -230,6 +235,7 @@ func TestDebianGetReleaseToVersion(t *testing.T) {
 
 	result := debian.GetReleaseToVersion()
 	expected := map[string]string{
+		"trixie":   "13",
 		"bookworm": "12",
 		"bullseye": "11",
 		"buster":   "10",
@@ -249,6 +255,7 @@ func TestDebianGetSupportedVersions(t *testing.T) {
 	result := debian.GetSupportedVersions()
 	sort.Strings(result)
 	expected := []string{
+		"13",
 		"12",
 		"11",
 		"10",
@@ -269,6 +276,7 @@ func TestDebianGetSupportedReleases(t *testing.T) {
 	result := debian.GetSupportedReleases()
 	sort.Strings(result)
 	expected := []string{
+		"trixie",
 		"bookworm",
 		"bullseye",
 		"buster",
@@ -34,6 +34,10 @@ type Distribution interface {
 	IsArchitectureSupported(architecture string) bool
 	// IsImageFormatSupported returns true if the image format is supported by the distribution.
 	IsImageFormatSupported(imageFormat string) bool
+	// GetSupportedVariants returns a list of supported variants of the distribution.
+	GetSupportedVariants() []*Variant
+	// GetVariant returns the variant selected by the base image (or the default variant of the distribution).
+	GetVariant() (*Variant, error)
 	// GetVersionFromRelease returns the version of the distribution from the release.
 	GetVersionFromRelease(release string) (string, error)
 	// GetReleaseFromVersion returns the release of the distribution from the version.
@@ -42,6 +46,12 @@ type Distribution interface {
 	GetReleaseFromReleaseOrVersion(releaseOrVersion string) (string, error)
 	// GetVersionFromReleaseOrVersion returns the version of the distribution from the release or version.
 	GetVersionFromReleaseOrVersion(releaseOrVersion string) (string, error)
+	// GetPackageManager returns the package manager used by the distribution.
+	GetPackageManager() string
+	// GetInitSystem returns the init system used by the distribution.
+	GetInitSystem() string
+	// IsSELinuxEnabled returns true if the images of the distribution ship with SELinux enabled.
+	IsSELinuxEnabled() bool
 	// GetImageName returns the image name.
 	GetImageName() (string, error)
 	// GetCompleteVersionUrl returns the complete version URL of the distribution.
@@ -50,4 +60,8 @@ type Distribution interface {
 	GetMinimalVersionUrl() (string, error)
 	// GetUrl returns the URL of the distribution.
 	GetUrl() (string, error)
+	// GetChecksumUrl returns the URL of the file with the checksum of the image.
+	GetChecksumUrl() (string, error)
+	// GetSignatureUrl returns the URL of the detached signature of the checksum file (empty if there is none).
+	GetSignatureUrl() (string, error)
 }
@@ -3,6 +3,7 @@ package distributions
 import (
 	"fmt"
 	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	dc "github.com/darki73/ptm/pkg/configuration/distribution"
 )
 
 // Distributions represents a structure that contains a named map of distributions.
@@ -13,16 +14,37 @@ type Distributions struct {
 	activeDistribution Distribution
 }
 
+// releaseExtender is the interface implemented by distributions which releases can be extended by the configuration file.
+type releaseExtender interface {
+	// extendReleases adds the releases to the releases of the distribution.
+	extendReleases(releases map[string]string)
+}
+
 // NewDistributions returns a new instance of Distributions.
-func NewDistributions(baseImage *bi.Configuration) (*Distributions, error) {
+// Distributions defined in the configuration file are registered next to the built-in ones, replacing those with the same name.
+// A distribution with the name of a built-in one and without `url` only adds its releases to the built-in one.
+func NewDistributions(baseImage *bi.Configuration, custom []*dc.Configuration) (*Distributions, error) {
 	distributions := &Distributions{
-		namedMap: map[string]Distribution{
-			"ubuntu": NewUbuntu(),
-			"debian": NewDebian(),
-		},
+		namedMap:           newNamedMap(),
 		activeDistribution: nil,
 	}
 
+	for _, configuration := range custom {
+		if builtin, ok := distributions.namedMap[configuration.GetName()].(releaseExtender); ok && configuration.GetUrl() == "" {
+			if len(configuration.GetReleases()) == 0 {
+				return nil, fmt.Errorf("distribution `%s` extends the built-in one but does not declare `releases`", configuration.GetName())
+			}
+			builtin.extendReleases(configuration.GetReleases())
+			continue
+		}
+
+		distribution, err := NewCustom(configuration)
+		if err != nil {
+			return nil, err
+		}
+		distributions.namedMap[configuration.GetName()] = distribution
+	}
+
 	if baseImage == nil || !distributions.IsDistributionSupported(baseImage.GetDistribution()) {
 		return nil, fmt.Errorf("the distribution '%s' is not supported", baseImage.GetDistribution())
 	}
@@ -32,6 +54,21 @@ func NewDistributions(baseImage *bi.Configuration) (*Distributions, error) {
 	return distributions, nil
 }
 
+// newNamedMap returns a named map of all built-in distributions.
+func newNamedMap() map[string]Distribution {
+	return map[string]Distribution{
+		"ubuntu":   NewUbuntu(),
+		"debian":   NewDebian(),
+		"rocky":    NewRocky(),
+		"alma":     NewAlma(),
+		"fedora":   NewFedora(),
+		"centos":   NewCentOS(),
+		"opensuse": NewOpenSUSE(),
+		"alpine":   NewAlpine(),
+		"arch":     NewArch(),
+	}
+}
+
 // IsDistributionSupported returns true if the distribution is supported, otherwise false.
 func (distributions *Distributions) IsDistributionSupported(distribution string) bool {
 	_, ok := distributions.namedMap[distribution]
@@ -6,7 +6,7 @@ import (
 
 // TestNewDistributions tests the NewDistributions function.
 func TestNewDistributions(t *testing.T) {
-	distributions, err := NewDistributions(ubuntuTestInitialBaseImage)
+	distributions, err := NewDistributions(ubuntuTestInitialBaseImage, nil)
 
 	if err != nil {
 		t.Errorf("NewDistributions() returned an error: %v", err)
@@ -23,7 +23,7 @@ func TestNewDistributions(t *testing.T) {
 
 // TestIsDistributionSupported tests the IsDistributionSupported function.
 func TestIsDistributionSupported(t *testing.T) {
-	distributions, err := NewDistributions(ubuntuTestInitialBaseImage)
+	distributions, err := NewDistributions(ubuntuTestInitialBaseImage, nil)
 
 	if err != nil {
 		t.Errorf("NewDistributions() returned an error: %v", err)
@@ -33,6 +33,12 @@ func TestIsDistributionSupported(t *testing.T) {
 		t.Error("Expected 'ubuntu' to be supported, but it's not.")
 	}
 
+	for _, distribution := range []string{"debian", "rocky", "alma", "fedora", "centos", "opensuse", "alpine", "arch"} {
+		if !distributions.IsDistributionSupported(distribution) {
+			t.Errorf("Expected '%s' to be supported, but it's not.", distribution)
+		}
+	}
+
 	if distributions.IsDistributionSupported("nonexistent") {
 		t.Error("Expected 'nonexistent' to not be supported, but it is.")
 	}
@@ -40,7 +46,7 @@ func TestIsDistributionSupported(t *testing.T) {
 
 // TestGetDistributionByName tests the GetDistributionByName function.
 func TestGetDistributionByName(t *testing.T) {
-	distributions, err := NewDistributions(ubuntuTestInitialBaseImage)
+	distributions, err := NewDistributions(ubuntuTestInitialBaseImage, nil)
 
 	if err != nil {
 		t.Errorf("NewDistributions() returned an error: %v", err)
@@ -59,7 +65,7 @@ func TestGetDistributionByName(t *testing.T) {
 
 // TestGetDistribution tests the GetDistribution function.
 func TestGetDistribution(t *testing.T) {
-	distributions, err := NewDistributions(ubuntuTestInitialBaseImage)
+	distributions, err := NewDistributions(ubuntuTestInitialBaseImage, nil)
 
 	if err != nil {
 		t.Errorf("NewDistributions() returned an error: %v", err)
@@ -78,7 +84,7 @@ func TestGetDistribution(t *testing.T) {
 
 // TestGetActiveDistribution tests the GetActiveDistribution function.
 func TestGetActiveDistribution(t *testing.T) {
-	distributions, err := NewDistributions(ubuntuTestInitialBaseImage)
+	distributions, err := NewDistributions(ubuntuTestInitialBaseImage, nil)
 
 	if err != nil {
 		t.Errorf("NewDistributions() returned an error: %v", err)
@@ -0,0 +1,225 @@
+package distributions
+
+import (
+	"fmt"
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	"regexp"
+	"strings"
+)
+
+// Fedora is the structure that holds configuration for Fedora Cloud distributions.
+type Fedora struct {
+	// baseDistribution is the configuration shared by all distributions.
+	baseDistribution
+	// serial is the identifier of the compose the image is taken from (for example, `1.4`), empty means the latest compose.
+	serial string
+	// imageNameTemplates is a map of Fedora versions to the templates of the image names (naming changed between versions).
+	imageNameTemplates map[string]string
+	// checksumNameTemplate is the template of the name of the checksum file of a compose.
+	checksumNameTemplate string
+}
+
+// NewFedora returns a new instance of Fedora Cloud distribution configuration.
+func NewFedora() *Fedora {
+	return &Fedora{
+		baseDistribution: baseDistribution{
+			completeVersionBaseUrl: "https://download.fedoraproject.org/pub/fedora/linux/releases",
+			minimalVersionBaseUrl:  "https://download.fedoraproject.org/pub/fedora/linux/releases",
+			variants: []*Variant{
+				NewVariant("generic", []string{"x86_64", "aarch64"}, []string{"qcow2"}),
+			},
+			defaultVariant: "generic",
+			packageManager: PackageManagerDnf,
+			initSystem:     InitSystemSystemd,
+			selinux:        true,
+		},
+		serial:               "",
+		imageNameTemplates:   map[string]string{},
+		checksumNameTemplate: "Fedora-Cloud-{version}-{compose}-{architecture}-CHECKSUM",
+	}
+}
+
+// Initialize initializes the Fedora Cloud distribution.
+func (fedora *Fedora) Initialize(baseImage *bi.Configuration) Distribution {
+	// NOTE: Fedora has no codenames, so releases are the versions themselves.
+	releaseToVersion := map[string]string{
+		"40": "40",
+		"41": "41",
+		"42": "42",
+	}
+
+	fedora.imageNameTemplates = map[string]string{
+		"40": "Fedora-Cloud-Base-Generic.{architecture}-{version}-{compose}.{format}",
+		"41": "Fedora-Cloud-Base-Generic-{version}-{compose}.{architecture}.{format}",
+		"42": "Fedora-Cloud-Base-Generic-{version}-{compose}.{architecture}.{format}",
+	}
+
+	fedora.serial = baseImage.GetSerial()
+	fedora.initialize(baseImage, releaseToVersion)
+
+	return fedora
+}
+
+// GetCompose returns the identifier of the compose the image of the version is taken from.
+func (fedora *Fedora) GetCompose(version string) (string, error) {
+	if fedora.serial == "" {
+		return "", fmt.Errorf("compose of Fedora `%s` is not resolved, pin it with `base_image.serial` (see `ptm images serials fedora %s`)", version, version)
+	}
+
+	return fedora.serial, nil
+}
+
+// GetImageName returns the image name.
+func (fedora *Fedora) GetImageName() (string, error) {
+	version, err := fedora.GetVersionFromReleaseOrVersion(fedora.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	if err := validateVariant(fedora, fedora.baseImage); err != nil {
+		return "", err
+	}
+
+	compose, err := fedora.GetCompose(version)
+	if err != nil {
+		return "", err
+	}
+
+	if _, ok := fedora.imageNameTemplates[version]; !ok {
+		return "", fmt.Errorf("image name for version %s is not known", version)
+	}
+
+	return strings.NewReplacer(
+		"{version}", version,
+		"{compose}", compose,
+		"{architecture}", fedora.baseImage.GetArchitecture(),
+		"{format}", fedora.baseImage.GetFormat(),
+	).Replace(fedora.imageNameTemplates[version]), nil
+}
+
+// GetCompleteVersionUrl returns the complete version URL of the Fedora Cloud.
+func (fedora *Fedora) GetCompleteVersionUrl() (string, error) {
+	version, err := fedora.GetVersionFromReleaseOrVersion(fedora.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := fedora.GetImageName()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/Cloud/%s/images/%s",
+		fedora.GetCompleteVersionBaseUrl(),
+		version,
+		fedora.baseImage.GetArchitecture(),
+		imageName,
+	), nil
+}
+
+// GetMinimalVersionUrl returns the minimal version URL of the Fedora Cloud.
+func (fedora *Fedora) GetMinimalVersionUrl() (string, error) {
+	version, err := fedora.GetVersionFromReleaseOrVersion(fedora.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := fedora.GetImageName()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/Cloud/%s/images/%s",
+		fedora.GetMinimalVersionBaseUrl(),
+		version,
+		fedora.baseImage.GetArchitecture(),
+		imageName,
+	), nil
+}
+
+// GetUrl returns the URL of the Fedora Cloud.
+func (fedora *Fedora) GetUrl() (string, error) {
+	if fedora.baseImage.GetMinimal() {
+		return fedora.GetMinimalVersionUrl()
+	}
+
+	return fedora.GetCompleteVersionUrl()
+}
+
+// GetChecksumUrl returns the URL of the `CHECKSUM` file of the compose the Fedora Cloud image is taken from.
+func (fedora *Fedora) GetChecksumUrl() (string, error) {
+	url, err := fedora.GetUrl()
+	if err != nil {
+		return "", err
+	}
+
+	version, err := fedora.GetVersionFromReleaseOrVersion(fedora.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	compose, err := fedora.GetCompose(version)
+	if err != nil {
+		return "", err
+	}
+
+	return replaceFileName(url, strings.NewReplacer(
+		"{version}", version,
+		"{compose}", compose,
+		"{architecture}", fedora.baseImage.GetArchitecture(),
+	).Replace(fedora.checksumNameTemplate)), nil
+}
+
+// GetSignatureUrl returns an empty string as the checksum file of Fedora Cloud is clearsigned.
+func (fedora *Fedora) GetSignatureUrl() (string, error) {
+	return "", nil
+}
+
+// GetSerial returns the identifier of the compose the Fedora Cloud image is taken from (empty means the latest compose).
+func (fedora *Fedora) GetSerial() string {
+	return fedora.serial
+}
+
+// SetSerial sets the identifier of the compose the Fedora Cloud image is taken from.
+func (fedora *Fedora) SetSerial(serial string) {
+	fedora.serial = serial
+}
+
+// GetSerialsUrl returns the URL of the directory listing the images of the Fedora Cloud release.
+func (fedora *Fedora) GetSerialsUrl() (string, error) {
+	version, err := fedora.GetVersionFromReleaseOrVersion(fedora.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/Cloud/%s/images/",
+		fedora.GetCompleteVersionBaseUrl(),
+		version,
+		fedora.baseImage.GetArchitecture(),
+	), nil
+}
+
+// ParseSerials returns the composes of the images listed in the directory of the Fedora Cloud release (oldest first).
+func (fedora *Fedora) ParseSerials(listing string) []string {
+	version, err := fedora.GetVersionFromReleaseOrVersion(fedora.baseImage.GetRelease())
+	if err != nil {
+		return []string{}
+	}
+
+	template, ok := fedora.imageNameTemplates[version]
+	if !ok {
+		return []string{}
+	}
+
+	pattern := strings.NewReplacer(
+		`\{version\}`, regexp.QuoteMeta(version),
+		`\{compose\}`, `(\d+\.\d+)`,
+		`\{architecture\}`, regexp.QuoteMeta(fedora.baseImage.GetArchitecture()),
+		`\{format\}`, regexp.QuoteMeta(fedora.baseImage.GetFormat()),
+	).Replace(regexp.QuoteMeta(template))
+
+	return parseSerials(regexp.MustCompile(`href="`+pattern+`"`), listing)
+}
@@ -0,0 +1,59 @@
+package distributions
+
+import (
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	"testing"
+)
+
+// TestFedoraGetCompose tests the GetCompose function.
+func TestFedoraGetCompose(t *testing.T) {
+	fedora := NewFedora()
+	fedora.Initialize(&bi.Configuration{Distribution: "fedora", Release: "41", Architecture: "x86_64", Format: "qcow2"})
+
+	if _, err := fedora.GetCompose("41"); err == nil {
+		t.Error("GetCompose(41) did not return an error for an unresolved compose")
+	}
+
+	fedora.SetSerial("1.4")
+
+	if compose, err := fedora.GetCompose("41"); err != nil || compose != "1.4" {
+		t.Errorf("GetCompose(41) returned %s, %v, want 1.4, nil", compose, err)
+	}
+}
+
+// TestFedoraGetImageName tests the GetImageName function.
+func TestFedoraGetImageName(t *testing.T) {
+	tests := []struct {
+		name         string
+		release      string
+		architecture string
+		serial       string
+		expected     string
+	}{
+		{"Fedora 40", "40", "x86_64", "1.14", "Fedora-Cloud-Base-Generic.x86_64-40-1.14.qcow2"},
+		{"Fedora 40 aarch64", "40", "aarch64", "1.14", "Fedora-Cloud-Base-Generic.aarch64-40-1.14.qcow2"},
+		{"Fedora 41", "41", "x86_64", "1.4", "Fedora-Cloud-Base-Generic-41-1.4.x86_64.qcow2"},
+		{"Fedora 42 aarch64", "42", "aarch64", "1.1", "Fedora-Cloud-Base-Generic-42-1.1.aarch64.qcow2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fedora := NewFedora().Initialize(&bi.Configuration{
+				Distribution: "fedora",
+				Release:      test.release,
+				Architecture: test.architecture,
+				Format:       "qcow2",
+				Serial:       test.serial,
+			})
+
+			result, err := fedora.GetImageName()
+			if err != nil {
+				t.Fatalf("GetImageName returned an error: %s", err)
+			}
+
+			if result != test.expected {
+				t.Errorf("GetImageName returned %s, want %s", result, test.expected)
+			}
+		})
+	}
+}
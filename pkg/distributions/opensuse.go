@@ -0,0 +1,141 @@
+package distributions
+
+import (
+	"fmt"
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+)
+
+// OpenSUSE is the structure that holds configuration for openSUSE distributions.
+type OpenSUSE struct {
+	// baseDistribution is the configuration shared by all distributions.
+	baseDistribution
+}
+
+// NewOpenSUSE returns a new instance of openSUSE distribution configuration.
+func NewOpenSUSE() *OpenSUSE {
+	return &OpenSUSE{
+		baseDistribution: baseDistribution{
+			completeVersionBaseUrl: "https://download.opensuse.org",
+			minimalVersionBaseUrl:  "https://download.opensuse.org",
+			variants: []*Variant{
+				NewVariant("cloud", []string{"x86_64", "aarch64"}, []string{"qcow2"}),
+			},
+			defaultVariant: "cloud",
+			packageManager: PackageManagerZypper,
+			initSystem:     InitSystemSystemd,
+			selinux:        false,
+		},
+	}
+}
+
+// Initialize initializes the openSUSE distribution.
+func (opensuse *OpenSUSE) Initialize(baseImage *bi.Configuration) Distribution {
+	// NOTE: Leap has no codenames and Tumbleweed is a rolling release, so releases are the versions themselves.
+	releaseToVersion := map[string]string{
+		"15.6":       "15.6",
+		"tumbleweed": "tumbleweed",
+	}
+
+	opensuse.initialize(baseImage, releaseToVersion)
+
+	return opensuse
+}
+
+// IsSELinuxEnabled returns true for Tumbleweed, which switched to SELinux, Leap images ship with AppArmor.
+func (opensuse *OpenSUSE) IsSELinuxEnabled() bool {
+	return opensuse.IsTumbleweed()
+}
+
+// IsTumbleweed returns true if the base image is the rolling Tumbleweed release.
+func (opensuse *OpenSUSE) IsTumbleweed() bool {
+	return opensuse.baseImage != nil && opensuse.baseImage.GetRelease() == "tumbleweed"
+}
+
+// GetImageName returns the image name.
+func (opensuse *OpenSUSE) GetImageName() (string, error) {
+	version, err := opensuse.GetVersionFromReleaseOrVersion(opensuse.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	if err := validateVariant(opensuse, opensuse.baseImage); err != nil {
+		return "", err
+	}
+
+	if opensuse.IsTumbleweed() {
+		return fmt.Sprintf(
+			"openSUSE-Tumbleweed-Minimal-VM.%s-Cloud.%s",
+			opensuse.baseImage.GetArchitecture(),
+			opensuse.baseImage.GetFormat(),
+		), nil
+	}
+
+	return fmt.Sprintf(
+		"openSUSE-Leap-%s-Minimal-VM.%s-Cloud.%s",
+		version,
+		opensuse.baseImage.GetArchitecture(),
+		opensuse.baseImage.GetFormat(),
+	), nil
+}
+
+// GetCompleteVersionUrl returns the complete version URL of the openSUSE.
+func (opensuse *OpenSUSE) GetCompleteVersionUrl() (string, error) {
+	return opensuse.buildUrl(opensuse.GetCompleteVersionBaseUrl())
+}
+
+// GetMinimalVersionUrl returns the minimal version URL of the openSUSE.
+func (opensuse *OpenSUSE) GetMinimalVersionUrl() (string, error) {
+	return opensuse.buildUrl(opensuse.GetMinimalVersionBaseUrl())
+}
+
+// GetUrl returns the URL of the openSUSE.
+func (opensuse *OpenSUSE) GetUrl() (string, error) {
+	if opensuse.baseImage.GetMinimal() {
+		return opensuse.GetMinimalVersionUrl()
+	}
+
+	return opensuse.GetCompleteVersionUrl()
+}
+
+// buildUrl builds the URL of the image (Tumbleweed images for architectures other than x86_64 are published under ports).
+func (opensuse *OpenSUSE) buildUrl(baseUrl string) (string, error) {
+	version, err := opensuse.GetVersionFromReleaseOrVersion(opensuse.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := opensuse.GetImageName()
+	if err != nil {
+		return "", err
+	}
+
+	if !opensuse.IsTumbleweed() {
+		return fmt.Sprintf("%s/distribution/leap/%s/appliances/%s", baseUrl, version, imageName), nil
+	}
+
+	if opensuse.baseImage.GetArchitecture() != "x86_64" {
+		return fmt.Sprintf("%s/ports/%s/tumbleweed/appliances/%s", baseUrl, opensuse.baseImage.GetArchitecture(), imageName), nil
+	}
+
+	return fmt.Sprintf("%s/tumbleweed/appliances/%s", baseUrl, imageName), nil
+}
+
+// GetChecksumUrl returns the URL of the `.sha256` file published for the openSUSE image.
+func (opensuse *OpenSUSE) GetChecksumUrl() (string, error) {
+	url, err := opensuse.GetUrl()
+	if err != nil {
+		return "", err
+	}
+
+	return url + ".sha256", nil
+}
+
+// GetSignatureUrl returns the URL of the detached signature of the checksum file of openSUSE.
+func (opensuse *OpenSUSE) GetSignatureUrl() (string, error) {
+	url, err := opensuse.GetChecksumUrl()
+	if err != nil {
+		return "", err
+	}
+
+	return url + ".asc", nil
+}
@@ -0,0 +1,99 @@
+package distributions
+
+import dc "github.com/darki73/ptm/pkg/configuration/distribution"
+
+const (
+	// PackageManagerApt is the package manager used by Debian based distributions.
+	PackageManagerApt = "apt"
+	// PackageManagerDnf is the package manager used by RHEL compatible distributions.
+	PackageManagerDnf = "dnf"
+	// PackageManagerZypper is the package manager used by openSUSE distributions.
+	PackageManagerZypper = "zypper"
+	// PackageManagerApk is the package manager used by Alpine Linux.
+	PackageManagerApk = "apk"
+	// PackageManagerPacman is the package manager used by Arch Linux.
+	PackageManagerPacman = "pacman"
+)
+
+const (
+	// InitSystemSystemd is the systemd init system.
+	InitSystemSystemd = "systemd"
+	// InitSystemOpenRC is the OpenRC init system (Alpine Linux).
+	InitSystemOpenRC = "openrc"
+)
+
+var (
+	// defaultBasePackages is the map of package managers to the packages installed into the image when none are configured.
+	defaultBasePackages = map[string][]string{
+		PackageManagerApt: {
+			"apt-transport-https",
+			"aptitude",
+			"ca-certificates",
+			"curl",
+			"htop",
+			"jq",
+			"mc",
+			"software-properties-common",
+			"qemu-guest-agent",
+			"wget",
+		},
+		PackageManagerDnf: {
+			"ca-certificates",
+			"curl",
+			"dnf-plugins-core",
+			"jq",
+			"mc",
+			"qemu-guest-agent",
+			"wget",
+		},
+		PackageManagerZypper: {
+			"ca-certificates",
+			"curl",
+			"htop",
+			"jq",
+			"mc",
+			"qemu-guest-agent",
+			"wget",
+		},
+		PackageManagerApk: {
+			"ca-certificates",
+			"curl",
+			"jq",
+			"qemu-guest-agent",
+		},
+		PackageManagerPacman: {
+			"curl",
+			"htop",
+			"jq",
+			"mc",
+			"qemu-guest-agent",
+			"wget",
+		},
+	}
+)
+
+// GetDefaultBasePackages returns the packages installed into the image when none are configured (apt packages for unknown package managers).
+func GetDefaultBasePackages(packageManager string) []string {
+	packages, ok := defaultBasePackages[packageManager]
+	if !ok {
+		packages = defaultBasePackages[PackageManagerApt]
+	}
+
+	return append([]string{}, packages...)
+}
+
+// GetPackageManagerForDistribution returns the package manager used by the distribution (apt for unknown distributions).
+// Distributions defined in the configuration file take precedence over the built-in ones.
+func GetPackageManagerForDistribution(distribution string, custom []*dc.Configuration) string {
+	for _, configuration := range custom {
+		if configuration.GetName() == distribution && configuration.GetPackageManager() != "" {
+			return configuration.GetPackageManager()
+		}
+	}
+
+	if distribution, ok := newNamedMap()[distribution]; ok {
+		return distribution.GetPackageManager()
+	}
+
+	return PackageManagerApt
+}
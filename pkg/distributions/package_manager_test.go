@@ -0,0 +1,63 @@
+package distributions
+
+import (
+	"github.com/darki73/ptm/pkg/utils"
+	"testing"
+)
+
+// TestGetDefaultBasePackages tests the GetDefaultBasePackages function.
+func TestGetDefaultBasePackages(t *testing.T) {
+	tests := []struct {
+		name           string
+		packageManager string
+		contains       string
+		notContains    string
+	}{
+		{"Apt", PackageManagerApt, "software-properties-common", "dnf-plugins-core"},
+		{"Dnf", PackageManagerDnf, "dnf-plugins-core", "software-properties-common"},
+		{"Zypper", PackageManagerZypper, "qemu-guest-agent", "software-properties-common"},
+		{"Apk", PackageManagerApk, "qemu-guest-agent", "mc"},
+		{"Unknown", "unknown", "software-properties-common", "dnf-plugins-core"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			packages := GetDefaultBasePackages(test.packageManager)
+
+			if !utils.SliceContains(packages, test.contains) {
+				t.Errorf("GetDefaultBasePackages(%s) returned %v, want it to contain %s", test.packageManager, packages, test.contains)
+			}
+
+			if utils.SliceContains(packages, test.notContains) {
+				t.Errorf("GetDefaultBasePackages(%s) returned %v, want it not to contain %s", test.packageManager, packages, test.notContains)
+			}
+		})
+	}
+}
+
+// TestGetPackageManagerForDistribution tests the GetPackageManagerForDistribution function.
+func TestGetPackageManagerForDistribution(t *testing.T) {
+	tests := []struct {
+		distribution string
+		expected     string
+	}{
+		{"ubuntu", PackageManagerApt},
+		{"debian", PackageManagerApt},
+		{"rocky", PackageManagerDnf},
+		{"alma", PackageManagerDnf},
+		{"fedora", PackageManagerDnf},
+		{"centos", PackageManagerDnf},
+		{"opensuse", PackageManagerZypper},
+		{"alpine", PackageManagerApk},
+		{"arch", PackageManagerPacman},
+		{"unknown", PackageManagerApt},
+	}
+
+	for _, test := range tests {
+		t.Run(test.distribution, func(t *testing.T) {
+			if result := GetPackageManagerForDistribution(test.distribution, nil); result != test.expected {
+				t.Errorf("GetPackageManagerForDistribution(%s) returned %s, want %s", test.distribution, result, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package distributions
+
+import (
+	"fmt"
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+)
+
+// Rocky is the structure that holds configuration for Rocky Linux distributions.
+type Rocky struct {
+	// baseDistribution is the configuration shared by all distributions.
+	baseDistribution
+}
+
+// NewRocky returns a new instance of Rocky Linux distribution configuration.
+func NewRocky() *Rocky {
+	return &Rocky{
+		baseDistribution: baseDistribution{
+			completeVersionBaseUrl: "https://dl.rockylinux.org/pub/rocky",
+			minimalVersionBaseUrl:  "https://dl.rockylinux.org/pub/rocky",
+			variants: []*Variant{
+				NewVariant("genericcloud", []string{"x86_64", "aarch64"}, []string{"qcow2"}),
+			},
+			defaultVariant: "genericcloud",
+			packageManager: PackageManagerDnf,
+			initSystem:     InitSystemSystemd,
+			selinux:        true,
+		},
+	}
+}
+
+// Initialize initializes the Rocky Linux distribution.
+func (rocky *Rocky) Initialize(baseImage *bi.Configuration) Distribution {
+	releaseToVersion := map[string]string{
+		"green-obsidian": "8",
+		"blue-onyx":      "9",
+	}
+
+	rocky.initialize(baseImage, releaseToVersion)
+
+	return rocky
+}
+
+// GetImageName returns the image name.
+func (rocky *Rocky) GetImageName() (string, error) {
+	version, err := rocky.GetVersionFromReleaseOrVersion(rocky.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	if err := validateVariant(rocky, rocky.baseImage); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"Rocky-%s-GenericCloud.latest.%s.%s",
+		version,
+		rocky.baseImage.GetArchitecture(),
+		rocky.baseImage.GetFormat(),
+	), nil
+}
+
+// GetCompleteVersionUrl returns the complete version URL of the Rocky Linux.
+func (rocky *Rocky) GetCompleteVersionUrl() (string, error) {
+	version, err := rocky.GetVersionFromReleaseOrVersion(rocky.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := rocky.GetImageName()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/images/%s/%s",
+		rocky.GetCompleteVersionBaseUrl(),
+		version,
+		rocky.baseImage.GetArchitecture(),
+		imageName,
+	), nil
+}
+
+// GetMinimalVersionUrl returns the minimal version URL of the Rocky Linux.
+func (rocky *Rocky) GetMinimalVersionUrl() (string, error) {
+	version, err := rocky.GetVersionFromReleaseOrVersion(rocky.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := rocky.GetImageName()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/images/%s/%s",
+		rocky.GetMinimalVersionBaseUrl(),
+		version,
+		rocky.baseImage.GetArchitecture(),
+		imageName,
+	), nil
+}
+
+// GetUrl returns the URL of the Rocky Linux.
+func (rocky *Rocky) GetUrl() (string, error) {
+	if rocky.baseImage.GetMinimal() {
+		return rocky.GetMinimalVersionUrl()
+	}
+
+	return rocky.GetCompleteVersionUrl()
+}
+
+// GetChecksumUrl returns the URL of the `CHECKSUM` file published next to the Rocky Linux image.
+func (rocky *Rocky) GetChecksumUrl() (string, error) {
+	url, err := rocky.GetUrl()
+	if err != nil {
+		return "", err
+	}
+
+	return replaceFileName(url, "CHECKSUM"), nil
+}
+
+// GetSignatureUrl returns an empty string as Rocky Linux does not publish a detached signature of the checksum file.
+func (rocky *Rocky) GetSignatureUrl() (string, error) {
+	return "", nil
+}
@@ -0,0 +1,115 @@
+package distributions
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/utils"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var (
+	// ubuntuSerialPattern is the pattern used to find serial directories (for example, `release-20261001/`) in the Ubuntu release index.
+	ubuntuSerialPattern = regexp.MustCompile(`href="release-(\d{8}(?:\.\d+)?)/"`)
+	// debianSerialPattern is the pattern used to find serial directories (for example, `20261001-1234/`) in the Debian release index.
+	debianSerialPattern = regexp.MustCompile(`href="(\d{8}-\d{4})/"`)
+	// serialNumberPattern is the pattern used to split serials into the numbers they are compared by.
+	serialNumberPattern = regexp.MustCompile(`\d+`)
+)
+
+// SerialPublisher is the interface implemented by distributions that publish images in dated serial directories.
+type SerialPublisher interface {
+	// GetSerial returns the serial of the image (empty means the latest serial).
+	GetSerial() string
+	// SetSerial sets the serial of the image.
+	SetSerial(serial string)
+	// GetSerialsUrl returns the URL of the index listing the serial directories of the release.
+	GetSerialsUrl() (string, error)
+	// ParseSerials returns the serials listed in the index of the release.
+	ParseSerials(listing string) []string
+}
+
+// IsSerialPublisher returns true if the distribution publishes images in dated serial directories.
+func IsSerialPublisher(distribution Distribution) bool {
+	_, ok := distribution.(SerialPublisher)
+	return ok
+}
+
+// FetchSerials returns the serials published for the release of the distribution (oldest first).
+func FetchSerials(distribution Distribution) ([]string, error) {
+	publisher, ok := distribution.(SerialPublisher)
+	if !ok {
+		return nil, fmt.Errorf("the distribution does not publish dated serials")
+	}
+
+	url, err := publisher.GetSerialsUrl()
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := utils.FetchUrl(url)
+	if err != nil {
+		return nil, err
+	}
+
+	serials := publisher.ParseSerials(string(content))
+	if len(serials) == 0 {
+		return nil, fmt.Errorf("no serials found at `%s`", url)
+	}
+
+	return serials, nil
+}
+
+// ResolveSerial pins the distribution to the latest published serial when no serial is configured and returns the serial in use.
+func ResolveSerial(distribution Distribution) (string, error) {
+	publisher, ok := distribution.(SerialPublisher)
+	if !ok {
+		return "", nil
+	}
+
+	if publisher.GetSerial() != "" {
+		return publisher.GetSerial(), nil
+	}
+
+	serials, err := FetchSerials(distribution)
+	if err != nil {
+		return "", err
+	}
+
+	publisher.SetSerial(serials[len(serials)-1])
+
+	return publisher.GetSerial(), nil
+}
+
+// parseSerials returns the unique serials matched by the pattern in the listing (sorted, oldest first).
+func parseSerials(pattern *regexp.Regexp, listing string) []string {
+	serials := make([]string, 0)
+	for _, match := range pattern.FindAllStringSubmatch(listing, -1) {
+		if !utils.SliceContains(serials, match[1]) {
+			serials = append(serials, match[1])
+		}
+	}
+
+	sort.Slice(serials, func(left int, right int) bool {
+		return isSerialOlder(serials[left], serials[right])
+	})
+
+	return serials
+}
+
+// isSerialOlder returns true if the left serial is older than the right one.
+// Serials are compared number by number, so `1.4` is older than `1.14`.
+func isSerialOlder(left string, right string) bool {
+	leftNumbers := serialNumberPattern.FindAllString(left, -1)
+	rightNumbers := serialNumberPattern.FindAllString(right, -1)
+
+	for index := 0; index < len(leftNumbers) && index < len(rightNumbers); index++ {
+		leftNumber, _ := strconv.Atoi(leftNumbers[index])
+		rightNumber, _ := strconv.Atoi(rightNumbers[index])
+		if leftNumber != rightNumber {
+			return leftNumber < rightNumber
+		}
+	}
+
+	return len(leftNumbers) < len(rightNumbers)
+}
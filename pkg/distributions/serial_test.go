@@ -0,0 +1,186 @@
+package distributions
+
+import (
+	"fmt"
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// serialTestListings is the map of paths to the directory listings served by the test server.
+var serialTestListings = map[string]string{
+	"/noble/": `<a href="release-20240821/">release-20240821/</a>
+<a href="release-20240423/">release-20240423/</a>
+<a href="release-20240423/">release-20240423/</a>
+<a href="release/">release/</a>`,
+	"/bookworm/": `<a href="20240507-1740/">20240507-1740/</a>
+<a href="20231013-1532/">20231013-1532/</a>
+<a href="latest/">latest/</a>
+<a href="daily/">daily/</a>`,
+	"/jammy/": `<a href="release/">release/</a>`,
+	"/40/Cloud/x86_64/images/": `<a href="Fedora-Cloud-Base-Generic.x86_64-40-1.4.qcow2">Fedora-Cloud-Base-Generic.x86_64-40-1.4.qcow2</a>
+<a href="Fedora-Cloud-Base-Generic.x86_64-40-1.14.qcow2">Fedora-Cloud-Base-Generic.x86_64-40-1.14.qcow2</a>
+<a href="Fedora-Cloud-Base-Generic.x86_64-40-1.14.raw.xz">Fedora-Cloud-Base-Generic.x86_64-40-1.14.raw.xz</a>
+<a href="Fedora-Cloud-40-1.14-x86_64-CHECKSUM">Fedora-Cloud-40-1.14-x86_64-CHECKSUM</a>`,
+	"/9-stream/x86_64/images/": `<a href="CentOS-Stream-GenericCloud-9-20250113.0.x86_64.qcow2">CentOS-Stream-GenericCloud-9-20250113.0.x86_64.qcow2</a>
+<a href="CentOS-Stream-GenericCloud-9-20250113.0.x86_64.qcow2.SHA256SUM">CentOS-Stream-GenericCloud-9-20250113.0.x86_64.qcow2.SHA256SUM</a>
+<a href="CentOS-Stream-GenericCloud-9-20241118.0.x86_64.qcow2">CentOS-Stream-GenericCloud-9-20241118.0.x86_64.qcow2</a>
+<a href="CentOS-Stream-GenericCloud-9-latest.x86_64.qcow2">CentOS-Stream-GenericCloud-9-latest.x86_64.qcow2</a>
+<a href="CentOS-Stream-GenericCloud-LVM-9-20250113.0.x86_64.qcow2">CentOS-Stream-GenericCloud-LVM-9-20250113.0.x86_64.qcow2</a>`,
+}
+
+// newSerialTestServer returns a test server that serves the release indexes of Ubuntu and Debian and the image directories of Fedora and CentOS.
+func newSerialTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if listing, ok := serialTestListings[request.URL.Path]; ok {
+			fmt.Fprint(writer, listing)
+			return
+		}
+
+		http.NotFound(writer, request)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// TestFetchSerials tests the FetchSerials function.
+func TestFetchSerials(t *testing.T) {
+	server := newSerialTestServer(t)
+
+	ubuntu := NewUbuntu()
+	ubuntu.completeVersionBaseUrl = server.URL
+	ubuntu.Initialize(&bi.Configuration{Distribution: "ubuntu", Release: "24.04", Minimal: false, Architecture: "amd64", Format: "img"})
+
+	debian := NewDebian()
+	debian.minimalVersionBaseUrl = server.URL
+	debian.Initialize(&bi.Configuration{Distribution: "debian", Release: "bookworm", Minimal: true, Architecture: "amd64", Format: "qcow2"})
+
+	fedora := NewFedora()
+	fedora.completeVersionBaseUrl = server.URL
+	fedora.Initialize(&bi.Configuration{Distribution: "fedora", Release: "40", Architecture: "x86_64", Format: "qcow2"})
+
+	centos := NewCentOS()
+	centos.completeVersionBaseUrl = server.URL
+	centos.Initialize(&bi.Configuration{Distribution: "centos", Release: "9", Architecture: "x86_64", Format: "qcow2"})
+
+	tests := []struct {
+		name         string
+		distribution Distribution
+		expected     []string
+		expectError  bool
+	}{
+		{"ubuntu", ubuntu, []string{"20240423", "20240821"}, false},
+		{"debian", debian, []string{"20231013-1532", "20240507-1740"}, false},
+		{"fedora", fedora, []string{"1.4", "1.14"}, false},
+		{"centos", centos, []string{"20241118.0", "20250113.0"}, false},
+		{"not a serial publisher", NewArch().Initialize(&bi.Configuration{Distribution: "arch", Release: "latest", Architecture: "x86_64", Format: "qcow2"}), nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := FetchSerials(test.distribution)
+			if (err != nil) != test.expectError {
+				t.Fatalf("FetchSerials returned error %v, want error: %v", err, test.expectError)
+			}
+			if !test.expectError && !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("FetchSerials returned %v, want %v", result, test.expected)
+			}
+		})
+	}
+
+	empty := NewUbuntu()
+	empty.completeVersionBaseUrl = server.URL
+	empty.Initialize(&bi.Configuration{Distribution: "ubuntu", Release: "jammy", Minimal: false, Architecture: "amd64", Format: "img"})
+
+	if _, err := FetchSerials(empty); err == nil {
+		t.Error("FetchSerials returned no error for an index without serials")
+	}
+}
+
+// TestResolveSerial tests the ResolveSerial function.
+func TestResolveSerial(t *testing.T) {
+	server := newSerialTestServer(t)
+
+	ubuntu := NewUbuntu()
+	ubuntu.completeVersionBaseUrl = server.URL
+	ubuntu.Initialize(&bi.Configuration{Distribution: "ubuntu", Release: "noble", Minimal: false, Architecture: "amd64", Format: "img"})
+
+	serial, err := ResolveSerial(ubuntu)
+	if err != nil || serial != "20240821" {
+		t.Fatalf("ResolveSerial returned %v, %v, want 20240821, nil", serial, err)
+	}
+
+	url, _ := ubuntu.GetUrl()
+	expected := fmt.Sprintf("%s/noble/release-20240821/ubuntu-24.04-server-cloudimg-amd64.img", server.URL)
+	if url != expected {
+		t.Errorf("GetUrl returned %v, want %v", url, expected)
+	}
+
+	fedora := NewFedora()
+	fedora.completeVersionBaseUrl = server.URL
+	fedora.Initialize(&bi.Configuration{Distribution: "fedora", Release: "40", Architecture: "x86_64", Format: "qcow2"})
+
+	if serial, err := ResolveSerial(fedora); err != nil || serial != "1.14" {
+		t.Fatalf("ResolveSerial returned %v, %v, want 1.14, nil", serial, err)
+	}
+
+	url, _ = fedora.GetUrl()
+	expected = fmt.Sprintf("%s/40/Cloud/x86_64/images/Fedora-Cloud-Base-Generic.x86_64-40-1.14.qcow2", server.URL)
+	if url != expected {
+		t.Errorf("GetUrl returned %v, want %v", url, expected)
+	}
+
+	pinned := NewUbuntu().Initialize(&bi.Configuration{Distribution: "ubuntu", Release: "noble", Minimal: false, Architecture: "amd64", Format: "img", Serial: "20240423"})
+	if serial, err := ResolveSerial(pinned); err != nil || serial != "20240423" {
+		t.Errorf("ResolveSerial returned %v, %v, want 20240423, nil", serial, err)
+	}
+
+	if serial, err := ResolveSerial(NewArch().Initialize(&bi.Configuration{Distribution: "arch", Release: "latest", Architecture: "x86_64", Format: "qcow2"})); err != nil || serial != "" {
+		t.Errorf("ResolveSerial returned %v, %v, want empty serial, nil", serial, err)
+	}
+}
+
+// TestGetUrlWithSerial tests the image URLs of pinned serials.
+func TestGetUrlWithSerial(t *testing.T) {
+	tests := []struct {
+		name             string
+		baseImage        *bi.Configuration
+		expectedUrl      string
+		expectedChecksum string
+	}{
+		{
+			"ubuntu minimal",
+			&bi.Configuration{Distribution: "ubuntu", Release: "noble", Minimal: true, Architecture: "amd64", Format: "img", Serial: "20261001"},
+			"https://cloud-images.ubuntu.com/minimal/releases/noble/release-20261001/ubuntu-24.04-minimal-cloudimg-amd64.img",
+			"https://cloud-images.ubuntu.com/minimal/releases/noble/release-20261001/SHA256SUMS",
+		},
+		{
+			"debian complete",
+			&bi.Configuration{Distribution: "debian", Release: "bookworm", Minimal: false, Architecture: "arm64", Format: "qcow2", Serial: "20240507-1740"},
+			"https://cloud.debian.org/images/cloud/bookworm/20240507-1740/debian-12-generic-arm64-20240507-1740.qcow2",
+			"https://cloud.debian.org/images/cloud/bookworm/20240507-1740/SHA512SUMS",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			distros, err := NewDistributions(test.baseImage, nil)
+			if err != nil {
+				t.Fatalf("NewDistributions returned error: %v", err)
+			}
+
+			result, err := distros.GetActiveDistribution().GetUrl()
+			if err != nil || result != test.expectedUrl {
+				t.Errorf("GetUrl returned %v, %v, want %v, nil", result, err, test.expectedUrl)
+			}
+
+			result, err = distros.GetActiveDistribution().GetChecksumUrl()
+			if err != nil || result != test.expectedChecksum {
+				t.Errorf("GetChecksumUrl returned %v, %v, want %v, nil", result, err, test.expectedChecksum)
+			}
+		})
+	}
+}
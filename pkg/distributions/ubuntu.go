@@ -3,205 +3,75 @@ package distributions
 import (
 	"fmt"
 	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	"time"
+)
+
+var (
+	// ubuntuBuiltinReleases is a map of Ubuntu releases to versions used when the release catalog cannot be fetched.
+	ubuntuBuiltinReleases = map[string]string{
+		"plucky":   "25.04",
+		"oracular": "24.10",
+		"noble":    "24.04",
+		"mantic":   "23.10",
+		"lunar":    "23.04",
+		"kinetic":  "22.10",
+		"jammy":    "22.04",
+		"impish":   "21.10",
+		"hirsute":  "21.04",
+		"groovy":   "20.10",
+		"focal":    "20.04",
+		"bionic":   "18.04",
+		"xenial":   "16.04",
+		"trusty":   "14.04",
+	}
+	// ubuntuImageNameTemplates is a map of Ubuntu variants to the templates of their image names.
+	ubuntuImageNameTemplates = map[string]string{
+		"server":   "ubuntu-%s-server-cloudimg-%s.%s",
+		"minimal":  "ubuntu-%s-minimal-cloudimg-%s.%s",
+		"disk-kvm": "ubuntu-%s-server-cloudimg-%s-disk-kvm.%s",
+	}
 )
 
 // Ubuntu is the structure that holds configuration for Ubuntu distributions.
 type Ubuntu struct {
-	// baseImage is the user configuration for base image.
-	baseImage *bi.Configuration
-	// completeVersionBaseUrl is the base URL for complete version of Ubuntu distributions.
-	completeVersionBaseUrl string
-	// minimalVersionBaseUrl is the base URL for minimal version of Ubuntu distributions.
-	minimalVersionBaseUrl string
-	// versionToRelease is a map of Ubuntu versions to releases.
-	versionToRelease map[string]string
-	// releaseToVersion is a map of Ubuntu releases to versions.
-	releaseToVersion map[string]string
-	// supportedVersions is a list of supported versions of Debian.
-	supportedVersions []string
-	// supportedReleases is a list of supported releases of Debian.
-	supportedReleases []string
-	// completeSupportedArchitectures is a list of supported architectures for complete type of Ubuntu.
-	completeSupportedArchitectures []string
-	// minimalSupportedArchitectures is a list of supported architectures for minimal type of Ubuntu.
-	minimalSupportedArchitectures []string
-	// completeSupportedImageFormats is a list of supported image formats for complete type of Ubuntu.
-	completeSupportedImageFormats []string
-	// minimalSupportedImageFormats is a list of supported image formats for minimal type of Ubuntu.
-	minimalSupportedImageFormats []string
+	// baseDistribution is the configuration shared by all distributions.
+	baseDistribution
+	// serial is the dated serial of the image (empty means the latest serial).
+	serial string
 }
 
 // NewUbuntu returns a new instance of Ubuntu distribution configuration.
 func NewUbuntu() *Ubuntu {
 	return &Ubuntu{
-		baseImage:              nil,
-		completeVersionBaseUrl: "https://cloud-images.ubuntu.com/releases",
-		minimalVersionBaseUrl:  "https://cloud-images.ubuntu.com/minimal/releases",
-		versionToRelease:       map[string]string{},
-		releaseToVersion:       map[string]string{},
-		supportedVersions:      []string{},
-		supportedReleases:      []string{},
-		completeSupportedArchitectures: []string{
-			"amd64",
-			"arm64",
-			"armhf",
-		},
-		completeSupportedImageFormats: []string{
-			"img",
-			"vmdk",
-		},
-		minimalSupportedArchitectures: []string{
-			"amd64",
-		},
-		minimalSupportedImageFormats: []string{
-			"img",
+		baseDistribution: baseDistribution{
+			completeVersionBaseUrl: "https://cloud-images.ubuntu.com/releases",
+			minimalVersionBaseUrl:  "https://cloud-images.ubuntu.com/minimal/releases",
+			variants: []*Variant{
+				NewVariant("server", []string{"amd64", "arm64", "armhf"}, []string{"img", "vmdk"}),
+				NewVariant("minimal", []string{"amd64"}, []string{"img"}),
+				NewVariant("disk-kvm", []string{"amd64"}, []string{"img"}),
+			},
+			defaultVariant: "server",
+			minimalVariant: "minimal",
+			packageManager: PackageManagerApt,
+			initSystem:     InitSystemSystemd,
+			selinux:        false,
 		},
+		serial: "",
 	}
 }
 
 // Initialize initializes the Ubuntu distribution.
 func (ubuntu *Ubuntu) Initialize(baseImage *bi.Configuration) Distribution {
-	ubuntu.baseImage = baseImage
-
-	ubuntu.releaseToVersion = map[string]string{
-		"mantic":  "23.10",
-		"lunar":   "23.04",
-		"kinetic": "22.10",
-		"jammy":   "22.04",
-		"impish":  "21.10",
-		"hirsute": "21.04",
-		"groovy":  "20.10",
-		"focal":   "20.04",
-		"bionic":  "18.04",
-		"xenial":  "16.04",
-		"trusty":  "14.04",
-	}
-
-	for key, value := range ubuntu.releaseToVersion {
-		ubuntu.supportedReleases = append(ubuntu.supportedReleases, key)
-		ubuntu.supportedVersions = append(ubuntu.supportedVersions, value)
-		ubuntu.versionToRelease[value] = key
-	}
-
-	return ubuntu
-}
-
-// GetCompleteVersionBaseUrl returns the base URL to the complete version of Ubuntu.
-func (ubuntu *Ubuntu) GetCompleteVersionBaseUrl() string {
-	return ubuntu.completeVersionBaseUrl
-}
-
-// GetMinimalVersionBaseUrl returns the base URL to the minimal version of Ubuntu.
-func (ubuntu *Ubuntu) GetMinimalVersionBaseUrl() string {
-	return ubuntu.minimalVersionBaseUrl
-}
-
-// GetVersionToRelease returns a map of Ubuntu versions to releases.
-func (ubuntu *Ubuntu) GetVersionToRelease() map[string]string {
-	return ubuntu.versionToRelease
-}
-
-// GetReleaseToVersion returns a map of Ubuntu releases to versions.
-func (ubuntu *Ubuntu) GetReleaseToVersion() map[string]string {
-	return ubuntu.releaseToVersion
-}
-
-// GetSupportedVersions returns a list of supported versions of Ubuntu.
-func (ubuntu *Ubuntu) GetSupportedVersions() []string {
-	return ubuntu.supportedVersions
-}
-
-// GetSupportedReleases returns a list of supported releases of Ubuntu.
-func (ubuntu *Ubuntu) GetSupportedReleases() []string {
-	return ubuntu.supportedReleases
-}
-
-// IsVersionSupported returns true if the version is supported by the Ubuntu.
-func (ubuntu *Ubuntu) IsVersionSupported(version string) bool {
-	for _, supportedVersion := range ubuntu.supportedVersions {
-		if supportedVersion == version {
-			return true
-		}
-	}
-	return false
-}
-
-// IsReleaseSupported returns true if the release is supported by the Ubuntu.
-func (ubuntu *Ubuntu) IsReleaseSupported(release string) bool {
-	for _, supportedRelease := range ubuntu.supportedReleases {
-		if supportedRelease == release {
-			return true
-		}
-	}
-	return false
-}
-
-// GetCompleteSupportedArchitectures returns a list of supported architectures for complete type of Ubuntu.
-func (ubuntu *Ubuntu) GetCompleteSupportedArchitectures() []string {
-	return ubuntu.completeSupportedArchitectures
-}
-
-// GetCompleteSupportedImageFormats returns a list of supported image formats for complete type of Ubuntu.
-func (ubuntu *Ubuntu) GetCompleteSupportedImageFormats() []string {
-	return ubuntu.completeSupportedImageFormats
-}
-
-// GetMinimalSupportedArchitectures returns a list of supported architectures for minimal type of Ubuntu.
-func (ubuntu *Ubuntu) GetMinimalSupportedArchitectures() []string {
-	return ubuntu.minimalSupportedArchitectures
-}
-
-// GetMinimalSupportedImageFormats returns a list of supported image formats for minimal type of Ubuntu.
-func (ubuntu *Ubuntu) GetMinimalSupportedImageFormats() []string {
-	return ubuntu.minimalSupportedImageFormats
-}
-
-// IsArchitectureSupported returns true if the architecture is supported by the Ubuntu.
-func (ubuntu *Ubuntu) IsArchitectureSupported(architecture string) bool {
-	architectureRange := ubuntu.completeSupportedArchitectures
-	if ubuntu.baseImage.GetMinimal() {
-		architectureRange = ubuntu.minimalSupportedArchitectures
-	}
-
-	for _, supportedArchitecture := range architectureRange {
-		if supportedArchitecture == architecture {
-			return true
-		}
-	}
-	return false
-}
-
-// IsImageFormatSupported returns true if the image format is supported by the Ubuntu.
-func (ubuntu *Ubuntu) IsImageFormatSupported(imageFormat string) bool {
-	imageFormatRange := ubuntu.completeSupportedImageFormats
-	if ubuntu.baseImage.GetMinimal() {
-		imageFormatRange = ubuntu.minimalSupportedImageFormats
-	}
-
-	for _, supportedImageFormat := range imageFormatRange {
-		if supportedImageFormat == imageFormat {
-			return true
-		}
-	}
-	return false
-}
-
-// GetVersionFromRelease returns the version of the Ubuntu from the release.
-func (ubuntu *Ubuntu) GetVersionFromRelease(release string) (string, error) {
-	if ubuntu.IsReleaseSupported(release) {
-		return ubuntu.releaseToVersion[release], nil
-	}
+	ubuntu.serial = baseImage.GetSerial()
 
-	return "", fmt.Errorf("release %s is not supported", release)
-}
+	releaseToVersion := discoverReleases(baseImage, ubuntuBuiltinReleases, func(cacheDirectory string, ttl time.Duration) (map[string]string, error) {
+		return FetchUbuntuReleases(ubuntu.GetCompleteVersionBaseUrl(), cacheDirectory, ttl)
+	})
 
-// GetReleaseFromVersion returns the release of the Ubuntu from the version.
-func (ubuntu *Ubuntu) GetReleaseFromVersion(version string) (string, error) {
-	if ubuntu.IsVersionSupported(version) {
-		return ubuntu.versionToRelease[version], nil
-	}
+	ubuntu.initialize(baseImage, releaseToVersion)
 
-	return "", fmt.Errorf("version %s is not supported", version)
+	return ubuntu
 }
 
 // GetReleaseFromReleaseOrVersion returns the release of the Ubuntu from the release or version.
@@ -237,25 +107,17 @@ func (ubuntu *Ubuntu) GetImageName() (string, error) {
 		return "", err
 	}
 
-	if !ubuntu.IsArchitectureSupported(ubuntu.baseImage.GetArchitecture()) {
-		return "", fmt.Errorf("architecture %s is not supported", ubuntu.baseImage.GetArchitecture())
-	}
-
-	if !ubuntu.IsImageFormatSupported(ubuntu.baseImage.GetFormat()) {
-		return "", fmt.Errorf("image format %s is not supported", ubuntu.baseImage.GetFormat())
+	if err := validateVariant(ubuntu, ubuntu.baseImage); err != nil {
+		return "", err
 	}
 
-	if ubuntu.baseImage.GetMinimal() {
-		return fmt.Sprintf(
-			"ubuntu-%s-minimal-cloudimg-%s.%s",
-			version,
-			ubuntu.baseImage.GetArchitecture(),
-			ubuntu.baseImage.GetFormat(),
-		), nil
+	variant, err := ubuntu.GetVariant()
+	if err != nil {
+		return "", err
 	}
 
 	return fmt.Sprintf(
-		"ubuntu-%s-cloudimg-%s.%s",
+		ubuntuImageNameTemplates[variant.GetName()],
 		version,
 		ubuntu.baseImage.GetArchitecture(),
 		ubuntu.baseImage.GetFormat(),
@@ -276,9 +138,10 @@ func (ubuntu *Ubuntu) GetCompleteVersionUrl() (string, error) {
 	}
 
 	return fmt.Sprintf(
-		"%s/%s/release/%s",
+		"%s/%s/%s/%s",
 		ubuntu.GetCompleteVersionBaseUrl(),
 		release,
+		ubuntu.getSerialDirectory(),
 		imageName,
 	), nil
 }
@@ -296,18 +159,88 @@ func (ubuntu *Ubuntu) GetMinimalVersionUrl() (string, error) {
 	}
 
 	return fmt.Sprintf(
-		"%s/%s/release/%s",
+		"%s/%s/%s/%s",
 		ubuntu.GetMinimalVersionBaseUrl(),
 		release,
+		ubuntu.getSerialDirectory(),
 		imageName,
 	), nil
 }
 
 // GetUrl returns the URL of the Ubuntu.
 func (ubuntu *Ubuntu) GetUrl() (string, error) {
-	if ubuntu.baseImage.GetMinimal() {
+	if ubuntu.isMinimalVariant() {
 		return ubuntu.GetMinimalVersionUrl()
 	}
 
 	return ubuntu.GetCompleteVersionUrl()
 }
+
+// GetChecksumUrl returns the URL of the `SHA256SUMS` file published next to the Ubuntu image.
+func (ubuntu *Ubuntu) GetChecksumUrl() (string, error) {
+	url, err := ubuntu.GetUrl()
+	if err != nil {
+		return "", err
+	}
+
+	return replaceFileName(url, "SHA256SUMS"), nil
+}
+
+// GetSignatureUrl returns the URL of the detached signature of the checksum file of Ubuntu.
+func (ubuntu *Ubuntu) GetSignatureUrl() (string, error) {
+	url, err := ubuntu.GetChecksumUrl()
+	if err != nil {
+		return "", err
+	}
+
+	return url + ".gpg", nil
+}
+
+// GetSerial returns the dated serial of the Ubuntu image (empty means the latest serial).
+func (ubuntu *Ubuntu) GetSerial() string {
+	return ubuntu.serial
+}
+
+// SetSerial sets the dated serial of the Ubuntu image.
+func (ubuntu *Ubuntu) SetSerial(serial string) {
+	ubuntu.serial = serial
+}
+
+// GetSerialsUrl returns the URL of the index listing the serial directories of the Ubuntu release.
+func (ubuntu *Ubuntu) GetSerialsUrl() (string, error) {
+	release, err := ubuntu.GetReleaseFromReleaseOrVersion(ubuntu.baseImage.GetRelease())
+	if err != nil {
+		return "", err
+	}
+
+	baseUrl := ubuntu.GetCompleteVersionBaseUrl()
+	if ubuntu.isMinimalVariant() {
+		baseUrl = ubuntu.GetMinimalVersionBaseUrl()
+	}
+
+	return fmt.Sprintf("%s/%s/", baseUrl, release), nil
+}
+
+// ParseSerials returns the serials listed in the index of the Ubuntu release (oldest first).
+func (ubuntu *Ubuntu) ParseSerials(listing string) []string {
+	return parseSerials(ubuntuSerialPattern, listing)
+}
+
+// getSerialDirectory returns the directory of the serial (`release` for the latest serial, `release-<serial>` otherwise).
+func (ubuntu *Ubuntu) getSerialDirectory() string {
+	if ubuntu.serial == "" {
+		return "release"
+	}
+
+	return "release-" + ubuntu.serial
+}
+
+// isMinimalVariant returns true if the minimal variant is selected (minimal images are published under a separate base URL).
+func (ubuntu *Ubuntu) isMinimalVariant() bool {
+	variant, err := ubuntu.GetVariant()
+	if err != nil {
+		return ubuntu.baseImage.GetMinimal()
+	}
+
+	return variant.GetName() == "minimal"
+}
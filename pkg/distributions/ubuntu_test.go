@@ -76,17 +76,20 @@ func TestUbuntuInitialize(t *testing.T) {
 
 	resultMapStringString := ubuntu.GetReleaseToVersion()
 	expectedMapStringString := map[string]string{
-		"mantic":  "23.10",
-		"lunar":   "23.04",
-		"kinetic": "22.10",
-		"jammy":   "22.04",
-		"impish":  "21.10",
-		"hirsute": "21.04",
-		"groovy":  "20.10",
-		"focal":   "20.04",
-		"bionic":  "18.04",
-		"xenial":  "16.04",
-		"trusty":  "14.04",
+		"plucky":   "25.04",
+		"oracular": "24.10",
+		"noble":    "24.04",
+		"mantic":   "23.10",
+		"lunar":    "23.04",
+		"kinetic":  "22.10",
+		"jammy":    "22.04",
+		"impish":   "21.10",
+		"hirsute":  "21.04",
+		"groovy":   "20.10",
+		"focal":    "20.04",
+		"bionic":   "18.04",
+		"xenial":   "16.04",
+		"trusty":   "14.04",
 	}
 
 	if !reflect.DeepEqual(resultMapStringString, expectedMapStringString) {
@@ -95,6 +98,9 @@ func TestUbuntuInitialize(t *testing.T) {
 
 	resultMapStringString = ubuntu.GetVersionToRelease()
 	expectedMapStringString = map[string]string{
+		"25.04": "plucky",
+		"24.10": "oracular",
+		"24.04": "noble",
 		"23.10": "mantic",
 		"23.04": "lunar",
 		"22.10": "kinetic",
@@ -115,6 +121,9 @@ func TestUbuntuInitialize(t *testing.T) {
 	resultSliceString := ubuntu.GetSupportedVersions()
 	sort.Strings(resultSliceString)
 	expectedSliceString := []string{
+		"25.04",
+		"24.10",
+		"24.04",
 		"23.10",
 		"23.04",
 		"22.10",
@@ -136,6 +145,9 @@ func TestUbuntuInitialize(t *testing.T) {
 	resultSliceString = ubuntu.GetSupportedReleases()
 	sort.Strings(resultSliceString)
 	expectedSliceString = []string{
+		"plucky",
+		"oracular",
+		"noble",
 		"mantic",
 		"lunar",
 		"kinetic",
@@ -240,6 +252,9 @@ func TestUbuntuGetVersionToRelease(t *testing.T) {
 
 	result := ubuntu.GetVersionToRelease()
 	expected := map[string]string{
+		"25.04": "plucky",
+		"24.10": "oracular",
+		"24.04": "noble",
 		"23.10": "mantic",
 		"23.04": "lunar",
 		"22.10": "kinetic",
@@ -265,17 +280,20 @@ func TestUbuntuGetReleaseToVersion(t *testing.T) {
 
 	result := ubuntu.GetReleaseToVersion()
 	expected := map[string]string{
-		"mantic":  "23.10",
-		"lunar":   "23.04",
-		"kinetic": "22.10",
-		"jammy":   "22.04",
-		"impish":  "21.10",
-		"hirsute": "21.04",
-		"groovy":  "20.10",
-		"focal":   "20.04",
-		"bionic":  "18.04",
-		"xenial":  "16.04",
-		"trusty":  "14.04",
+		"plucky":   "25.04",
+		"oracular": "24.10",
+		"noble":    "24.04",
+		"mantic":   "23.10",
+		"lunar":    "23.04",
+		"kinetic":  "22.10",
+		"jammy":    "22.04",
+		"impish":   "21.10",
+		"hirsute":  "21.04",
+		"groovy":   "20.10",
+		"focal":    "20.04",
+		"bionic":   "18.04",
+		"xenial":   "16.04",
+		"trusty":   "14.04",
 	}
 
 	if !reflect.DeepEqual(result, expected) {
@@ -291,6 +309,9 @@ func TestUbuntuGetSupportedVersions(t *testing.T) {
 	result := ubuntu.GetSupportedVersions()
 	sort.Strings(result)
 	expected := []string{
+		"25.04",
+		"24.10",
+		"24.04",
 		"23.10",
 		"23.04",
 		"22.10",
@@ -318,6 +339,9 @@ func TestUbuntuGetSupportedReleases(t *testing.T) {
 	result := ubuntu.GetSupportedReleases()
 	sort.Strings(result)
 	expected := []string{
+		"plucky",
+		"oracular",
+		"noble",
 		"mantic",
 		"lunar",
 		"kinetic",
@@ -492,7 +516,7 @@ func TestUbuntuGetCompleteVersionUrl(t *testing.T) {
 	})
 
 	result, err := ubuntu.GetCompleteVersionUrl()
-	expected := "https://cloud-images.ubuntu.com/releases/jammy/release/ubuntu-22.04-cloudimg-amd64.img"
+	expected := "https://cloud-images.ubuntu.com/releases/jammy/release/ubuntu-22.04-server-cloudimg-amd64.img"
 
 	if err != nil {
 		t.Errorf("GetCompleteVersionUrl returned an error: %s", err)
@@ -536,7 +560,7 @@ func TestUbuntuGetUrlForComplete(t *testing.T) {
 	})
 
 	result, err := ubuntu.GetUrl()
-	expected := "https://cloud-images.ubuntu.com/releases/jammy/release/ubuntu-22.04-cloudimg-amd64.img"
+	expected := "https://cloud-images.ubuntu.com/releases/jammy/release/ubuntu-22.04-server-cloudimg-amd64.img"
 
 	if err != nil {
 		t.Errorf("GetUrl returned an error: %s", err)
@@ -0,0 +1,109 @@
+package distributions
+
+import (
+	"fmt"
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	"github.com/darki73/ptm/pkg/utils"
+	"strings"
+)
+
+// Variant is the structure that holds the architectures and formats an image variant is published for.
+type Variant struct {
+	// name is the name of the variant (for example, minimal / genericcloud / nocloud).
+	name string
+	// architectures is a list of architectures the variant is published for.
+	architectures []string
+	// formats is a list of image formats the variant is published in.
+	formats []string
+}
+
+// NewVariant returns a new instance of Variant.
+func NewVariant(name string, architectures []string, formats []string) *Variant {
+	return &Variant{
+		name:          name,
+		architectures: architectures,
+		formats:       formats,
+	}
+}
+
+// GetName returns the name of the variant.
+func (variant *Variant) GetName() string {
+	return variant.name
+}
+
+// GetArchitectures returns a list of architectures the variant is published for.
+func (variant *Variant) GetArchitectures() []string {
+	return variant.architectures
+}
+
+// GetFormats returns a list of image formats the variant is published in.
+func (variant *Variant) GetFormats() []string {
+	return variant.formats
+}
+
+// IsArchitectureSupported returns true if the variant is published for the architecture.
+func (variant *Variant) IsArchitectureSupported(architecture string) bool {
+	return utils.SliceContains(variant.architectures, architecture)
+}
+
+// IsFormatSupported returns true if the variant is published in the image format.
+func (variant *Variant) IsFormatSupported(format string) bool {
+	return utils.SliceContains(variant.formats, format)
+}
+
+// String returns the variant with its architectures and formats (for example, `minimal (amd64: img)`).
+func (variant *Variant) String() string {
+	return fmt.Sprintf("%s (%s: %s)", variant.name, strings.Join(variant.architectures, ", "), strings.Join(variant.formats, ", "))
+}
+
+// DescribeVariants returns the valid combinations of variants, architectures and formats.
+func DescribeVariants(variants []*Variant) string {
+	descriptions := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		descriptions = append(descriptions, variant.String())
+	}
+
+	return strings.Join(descriptions, "; ")
+}
+
+// resolveVariant returns the variant selected by the base image, or the default variant when none is selected.
+func resolveVariant(baseImage *bi.Configuration, variants []*Variant, defaultVariant string) (*Variant, error) {
+	name := baseImage.GetVariant()
+	if name == "" {
+		name = defaultVariant
+	}
+
+	for _, variant := range variants {
+		if variant.GetName() == name {
+			return variant, nil
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"variant `%s` is not supported by `%s`, valid combinations: %s",
+		name,
+		baseImage.GetDistribution(),
+		DescribeVariants(variants),
+	)
+}
+
+// validateVariant returns an error if the variant of the distribution is not published for the architecture and format of the base image.
+func validateVariant(distribution Distribution, baseImage *bi.Configuration) error {
+	variant, err := distribution.GetVariant()
+	if err != nil {
+		return err
+	}
+
+	if variant.IsArchitectureSupported(baseImage.GetArchitecture()) && variant.IsFormatSupported(baseImage.GetFormat()) {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"architecture `%s` with format `%s` is not supported by the `%s` variant of `%s`, valid combinations: %s",
+		baseImage.GetArchitecture(),
+		baseImage.GetFormat(),
+		variant.GetName(),
+		baseImage.GetDistribution(),
+		DescribeVariants(distribution.GetSupportedVariants()),
+	)
+}
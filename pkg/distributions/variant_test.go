@@ -0,0 +1,119 @@
+package distributions
+
+import (
+	bi "github.com/darki73/ptm/pkg/configuration/base-image"
+	"strings"
+	"testing"
+)
+
+// TestVariantUrl tests the URLs of the images of the selected variants.
+func TestVariantUrl(t *testing.T) {
+	tests := []struct {
+		name      string
+		baseImage *bi.Configuration
+		expected  string
+	}{
+		{
+			"ubuntu default complete",
+			&bi.Configuration{Distribution: "ubuntu", Release: "jammy", Minimal: false, Architecture: "amd64", Format: "img"},
+			"https://cloud-images.ubuntu.com/releases/jammy/release/ubuntu-22.04-server-cloudimg-amd64.img",
+		},
+		{
+			"ubuntu default minimal",
+			&bi.Configuration{Distribution: "ubuntu", Release: "jammy", Minimal: true, Architecture: "amd64", Format: "img"},
+			"https://cloud-images.ubuntu.com/minimal/releases/jammy/release/ubuntu-22.04-minimal-cloudimg-amd64.img",
+		},
+		{
+			"ubuntu explicit minimal",
+			&bi.Configuration{Distribution: "ubuntu", Release: "jammy", Variant: "minimal", Architecture: "amd64", Format: "img"},
+			"https://cloud-images.ubuntu.com/minimal/releases/jammy/release/ubuntu-22.04-minimal-cloudimg-amd64.img",
+		},
+		{
+			"ubuntu disk-kvm",
+			&bi.Configuration{Distribution: "ubuntu", Release: "jammy", Variant: "disk-kvm", Architecture: "amd64", Format: "img"},
+			"https://cloud-images.ubuntu.com/releases/jammy/release/ubuntu-22.04-server-cloudimg-amd64-disk-kvm.img",
+		},
+		{
+			"debian default minimal",
+			&bi.Configuration{Distribution: "debian", Release: "bookworm", Minimal: true, Architecture: "amd64", Format: "qcow2"},
+			"https://cloud.debian.org/images/cloud/bookworm/latest/debian-12-genericcloud-amd64.qcow2",
+		},
+		{
+			"debian nocloud",
+			&bi.Configuration{Distribution: "debian", Release: "bookworm", Variant: "nocloud", Architecture: "arm64", Format: "raw"},
+			"https://cloud.debian.org/images/cloud/bookworm/latest/debian-12-nocloud-arm64.raw",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			distribution := newNamedMap()[test.baseImage.Distribution].Initialize(test.baseImage)
+
+			result, err := distribution.GetUrl()
+			if err != nil {
+				t.Fatalf("GetUrl returned error: %v", err)
+			}
+
+			if result != test.expected {
+				t.Errorf("GetUrl returned %s, want %s", result, test.expected)
+			}
+		})
+	}
+}
+
+// TestVariantValidation tests that invalid combinations of variant, architecture and format list the valid combinations.
+func TestVariantValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		baseImage *bi.Configuration
+		expected  string
+	}{
+		{
+			"unknown variant",
+			&bi.Configuration{Distribution: "ubuntu", Release: "jammy", Variant: "desktop", Architecture: "amd64", Format: "img"},
+			"variant `desktop` is not supported by `ubuntu`, valid combinations: server (amd64, arm64, armhf: img, vmdk); minimal (amd64: img); disk-kvm (amd64: img)",
+		},
+		{
+			"unsupported architecture",
+			&bi.Configuration{Distribution: "debian", Release: "bookworm", Variant: "genericcloud", Architecture: "arm64", Format: "qcow2"},
+			"architecture `arm64` with format `qcow2` is not supported by the `genericcloud` variant of `debian`, valid combinations: generic (amd64, arm64: qcow2, raw); genericcloud (amd64: qcow2, raw); nocloud (amd64, arm64: qcow2, raw)",
+		},
+		{
+			"unsupported format",
+			&bi.Configuration{Distribution: "ubuntu", Release: "jammy", Variant: "disk-kvm", Architecture: "amd64", Format: "vmdk"},
+			"architecture `amd64` with format `vmdk` is not supported by the `disk-kvm` variant of `ubuntu`",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			distribution := newNamedMap()[test.baseImage.Distribution].Initialize(test.baseImage)
+
+			_, err := distribution.GetImageName()
+			if err == nil {
+				t.Fatal("GetImageName returned no error")
+			}
+
+			if !strings.HasPrefix(err.Error(), test.expected) {
+				t.Errorf("GetImageName returned error %q, want %q", err.Error(), test.expected)
+			}
+		})
+	}
+}
+
+// TestGetSupportedVariants tests that every distribution declares at least one variant and selects a default one.
+func TestGetSupportedVariants(t *testing.T) {
+	for name, distribution := range newNamedMap() {
+		t.Run(name, func(t *testing.T) {
+			distribution.Initialize(&bi.Configuration{Distribution: name})
+
+			if len(distribution.GetSupportedVariants()) == 0 {
+				t.Error("GetSupportedVariants returned no variants")
+			}
+
+			if _, err := distribution.GetVariant(); err != nil {
+				t.Errorf("GetVariant returned error: %v", err)
+			}
+		})
+	}
+}
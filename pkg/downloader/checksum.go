@@ -0,0 +1,66 @@
+package downloader
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/darki73/ptm/pkg/utils"
+	"hash"
+)
+
+// Checksum is the structure that holds the expected checksum of the image and the hash computed while downloading.
+type Checksum struct {
+	// fileName is the name of the image file.
+	fileName string
+	// algorithm is the checksum algorithm (sha256 / sha512).
+	algorithm string
+	// expected is the checksum published by the distribution.
+	expected string
+	// hash is the hash the downloaded image is written to.
+	hash hash.Hash
+}
+
+// NewChecksum creates a new Checksum instance from the content of the checksum file of the distribution.
+func NewChecksum(content string, fileName string) (*Checksum, error) {
+	algorithm, expected, err := utils.FindChecksum(content, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	checksumHash, err := utils.NewChecksumHash(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Checksum{
+		fileName:  fileName,
+		algorithm: algorithm,
+		expected:  expected,
+		hash:      checksumHash,
+	}, nil
+}
+
+// GetAlgorithm returns the checksum algorithm.
+func (checksum *Checksum) GetAlgorithm() string {
+	return checksum.algorithm
+}
+
+// GetExpected returns the checksum published by the distribution.
+func (checksum *Checksum) GetExpected() string {
+	return checksum.expected
+}
+
+// Verify returns an error if the computed checksum does not match the expected one.
+func (checksum *Checksum) Verify() error {
+	computed := hex.EncodeToString(checksum.hash.Sum(nil))
+	if computed != checksum.expected {
+		return fmt.Errorf(
+			"%s checksum of `%s` does not match, expected `%s`, got `%s` (the image is corrupt or was tampered with)",
+			checksum.algorithm,
+			checksum.fileName,
+			checksum.expected,
+			computed,
+		)
+	}
+
+	return nil
+}
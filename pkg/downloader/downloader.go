@@ -5,11 +5,13 @@ import (
 	config "github.com/darki73/ptm/pkg/configuration"
 	bi "github.com/darki73/ptm/pkg/configuration/base-image"
 	"github.com/darki73/ptm/pkg/distributions"
+	"github.com/darki73/ptm/pkg/proxmox"
 	"github.com/schollz/progressbar/v3"
 	"io"
-	"net/http"
 	"os"
 	"path"
+	"strings"
+	"time"
 )
 
 // Downloader is the structure that holds the downloader configuration.
@@ -22,24 +24,36 @@ type Downloader struct {
 	distributions *distributions.Distributions
 	// distribution is the reference to the distribution configuration.
 	distribution distributions.Distribution
+	// verifyChecksum is a boolean value that indicates if the checksum of the downloaded image is verified.
+	verifyChecksum bool
+	// keyring is the path to the pinned GPG keyring the signature of the checksum file is verified against.
+	keyring string
 }
 
 // NewDownloader creates a new Downloader instance.
 func NewDownloader(configuration *config.Configuration) (*Downloader, error) {
-	distros, err := distributions.NewDistributions(configuration.GetBaseImage())
+	distros, err := distributions.NewDistributions(configuration.GetBaseImage(), configuration.GetDistributions())
 	if err != nil {
 		return nil, err
 	}
 	return &Downloader{
-		saveTo:        configuration.GetDownloader().GetSaveTo(),
-		image:         configuration.GetBaseImage(),
-		distributions: distros,
-		distribution:  distros.GetActiveDistribution(),
+		saveTo:         configuration.GetDownloader().GetSaveTo(),
+		image:          configuration.GetBaseImage(),
+		distributions:  distros,
+		distribution:   distros.GetActiveDistribution(),
+		verifyChecksum: configuration.GetDownloader().GetVerifyChecksum(),
+		keyring:        configuration.GetDownloader().GetKeyring(),
 	}, nil
 }
 
 // Download downloads the image.
+// The checksum is computed while the image is written to disk, the image is only moved to its final path when it matches.
 func (downloader *Downloader) Download() error {
+	serial, err := downloader.ResolveSerial()
+	if err != nil {
+		return err
+	}
+
 	alreadyDownloaded, err := downloader.IsAlreadyDownloaded()
 	if err != nil {
 		return err
@@ -59,33 +73,115 @@ func (downloader *Downloader) Download() error {
 		return err
 	}
 
-	request, err := http.NewRequest("GET", url, nil)
+	checksum, err := downloader.GetExpectedChecksum()
 	if err != nil {
 		return err
 	}
 
-	response, err := http.DefaultClient.Do(request)
-	defer response.Body.Close()
-
+	response, err := fetch(url)
 	if err != nil {
 		return err
 	}
+	defer response.Body.Close()
 
-	handle, err := os.OpenFile(savePath, os.O_CREATE|os.O_WRONLY, 0644)
+	partialPath := savePath + proxmox.ImagePartialExtension
+	handle, err := os.OpenFile(partialPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 	if err != nil {
 		return err
 	}
+	defer os.Remove(partialPath)
+	defer handle.Close()
 
 	fmt.Printf("Downloading %s\n", url)
 	bar := progressbar.DefaultBytes(
 		response.ContentLength,
 	)
 
-	if _, err = io.Copy(io.MultiWriter(handle, bar), response.Body); err != nil {
+	writers := []io.Writer{handle, bar}
+	if checksum != nil {
+		writers = append(writers, checksum.hash)
+	}
+
+	if _, err = io.Copy(io.MultiWriter(writers...), response.Body); err != nil {
+		return err
+	}
+
+	if err := handle.Close(); err != nil {
+		return err
+	}
+
+	if checksum != nil {
+		if err := checksum.Verify(); err != nil {
+			return err
+		}
+		fmt.Printf("Verified %s checksum of %s\n", checksum.algorithm, savePath)
+	}
+
+	if err := os.Rename(partialPath, savePath); err != nil {
 		return err
 	}
 
-	return nil
+	return downloader.saveMetadata(savePath, url, serial, checksum)
+}
+
+// ResolveSerial returns the serial of the image, pinning the distribution to the latest published serial when none is configured.
+// When the latest serial cannot be resolved, the unpinned image is downloaded and an empty serial is returned (unless the distribution has no unpinned image).
+func (downloader *Downloader) ResolveSerial() (string, error) {
+	if !distributions.IsSerialPublisher(downloader.distribution) {
+		if downloader.image.GetSerial() != "" {
+			return "", fmt.Errorf("the distribution `%s` does not publish dated serials", downloader.image.GetDistribution())
+		}
+		return "", nil
+	}
+
+	serial, err := distributions.ResolveSerial(downloader.distribution)
+	if err != nil {
+		if _, nameErr := downloader.distribution.GetImageName(); nameErr != nil {
+			return "", fmt.Errorf("failed to resolve the latest serial: %v", err)
+		}
+		fmt.Printf("Unable to resolve the latest serial, downloading the unpinned image: %v\n", err)
+		return "", nil
+	}
+
+	return serial, nil
+}
+
+// GetExpectedChecksum returns the checksum the downloaded image must match (nil when verification is disabled).
+// When a keyring is configured, the signature of the checksum file is verified first and the checksum is only looked up in the signed content.
+func (downloader *Downloader) GetExpectedChecksum() (*Checksum, error) {
+	if !downloader.verifyChecksum {
+		return nil, nil
+	}
+
+	checksumUrl, err := downloader.distribution.GetChecksumUrl()
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := fetchContent(checksumUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	if downloader.keyring != "" {
+		signatureUrl, err := downloader.distribution.GetSignatureUrl()
+		if err != nil {
+			return nil, err
+		}
+
+		content, err = VerifySignature(downloader.keyring, content, signatureUrl)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("Verified signature of %s\n", checksumUrl)
+	}
+
+	imageName, err := downloader.distribution.GetImageName()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChecksum(string(content), imageName)
 }
 
 // IsAlreadyDownloaded returns true if the image is already downloaded.
@@ -116,8 +212,48 @@ func (downloader *Downloader) GetFullImagePath() (string, error) {
 		}
 	}
 
+	if publisher, ok := downloader.distribution.(distributions.SerialPublisher); ok {
+		imageName = withSerial(imageName, publisher.GetSerial())
+	}
+
 	return path.Join(
 		downloader.saveTo,
 		imageName,
 	), nil
 }
+
+// saveMetadata records the source, serial and checksum of the downloaded image next to it.
+func (downloader *Downloader) saveMetadata(savePath string, url string, serial string, checksum *Checksum) error {
+	metadata := &proxmox.ImageMetadata{
+		Distribution: downloader.image.GetDistribution(),
+		Release:      downloader.image.GetRelease(),
+		Architecture: downloader.image.GetArchitecture(),
+		Format:       downloader.image.GetFormat(),
+		Minimal:      downloader.image.GetMinimal(),
+		Serial:       serial,
+		Url:          url,
+		DownloadedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if variant, err := downloader.distribution.GetVariant(); err == nil {
+		metadata.Variant = variant.GetName()
+	}
+
+	if checksum != nil {
+		metadata.ChecksumAlgorithm = checksum.GetAlgorithm()
+		metadata.Checksum = checksum.GetExpected()
+	}
+
+	return metadata.Save(savePath)
+}
+
+// withSerial returns the image name with the serial inserted before the extension (unless the name already contains it).
+func withSerial(imageName string, serial string) string {
+	if serial == "" || strings.Contains(imageName, serial) {
+		return imageName
+	}
+
+	extension := path.Ext(imageName)
+
+	return fmt.Sprintf("%s-%s%s", strings.TrimSuffix(imageName, extension), serial, extension)
+}
@@ -0,0 +1,43 @@
+package downloader
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// fetch sends a GET request to the URL and returns the response if it was successful.
+func fetch(url string) (*http.Response, error) {
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, fmt.Errorf("failed to fetch `%s`: unexpected status %s", url, response.Status)
+	}
+
+	return response, nil
+}
+
+// fetchContent returns the content of the URL.
+func fetchContent(url string) ([]byte, error) {
+	response, err := fetch(url)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read `%s`: %v", url, err)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,73 @@
+package downloader
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/utils"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// clearsignHeader is the header of PGP clearsigned files.
+	clearsignHeader = "-----BEGIN PGP SIGNED MESSAGE-----"
+)
+
+// VerifySignature verifies the signature of the checksum file against the pinned keyring with `gpgv` and returns the verified content.
+// Uses the detached signature when the distribution publishes one, otherwise the checksum file must be clearsigned.
+// Only the signed text of a clearsigned file is returned, so lines placed outside of the signed block are never trusted.
+func VerifySignature(keyring string, content []byte, signatureUrl string) ([]byte, error) {
+	keyring, err := filepath.Abs(keyring)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(keyring); err != nil {
+		return nil, fmt.Errorf("keyring `%s` is not accessible: %v", keyring, err)
+	}
+
+	directory, err := os.MkdirTemp("", "ptm-signature-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(directory)
+
+	contentPath := filepath.Join(directory, "checksums")
+	if err := os.WriteFile(contentPath, content, 0644); err != nil {
+		return nil, err
+	}
+
+	verifiedPath := filepath.Join(directory, "checksums.verified")
+	arguments := []string{"--keyring", keyring}
+
+	if signatureUrl != "" {
+		signature, err := fetchContent(signatureUrl)
+		if err != nil {
+			return nil, err
+		}
+
+		signaturePath := filepath.Join(directory, "checksums.sig")
+		if err := os.WriteFile(signaturePath, signature, 0644); err != nil {
+			return nil, err
+		}
+
+		arguments = append(arguments, signaturePath)
+	} else if strings.Contains(string(content), clearsignHeader) {
+		arguments = append(arguments, "--output", verifiedPath)
+	} else {
+		return nil, fmt.Errorf("the distribution does not sign its checksum file, remove the `keyring` to skip signature verification")
+	}
+
+	arguments = append(arguments, contentPath)
+
+	if output, err := utils.ExecuteCommand("gpgv", arguments...); err != nil {
+		return nil, fmt.Errorf("signature verification of the checksum file failed: %v %s", err, strings.TrimSpace(output))
+	}
+
+	// NOTE: The detached signature covers the whole file, the clearsignature only covers the text gpgv writes to the output.
+	if signatureUrl != "" {
+		return content, nil
+	}
+
+	return os.ReadFile(verifiedPath)
+}
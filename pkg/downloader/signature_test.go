@@ -0,0 +1,110 @@
+package downloader
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// signatureTestImage is the name of the image the checksum files are created for.
+const signatureTestImage = "ubuntu-24.04-server-cloudimg-amd64.img"
+
+// signatureTestChecksum is the checksum of the image listed in the signed block.
+const signatureTestChecksum = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+// signatureTestForgedChecksum is the checksum of the image placed outside of the signed block.
+const signatureTestForgedChecksum = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+
+// newSignatureTestKeyring creates a signing key in a temporary GnuPG home and returns the home and the exported keyring.
+func newSignatureTestKeyring(t *testing.T) (string, string) {
+	for _, command := range []string{"gpg", "gpgv"} {
+		if _, err := exec.LookPath(command); err != nil {
+			t.Skipf("%s is not installed", command)
+		}
+	}
+
+	directory := t.TempDir()
+	home := filepath.Join(directory, "gnupg")
+	if err := os.Mkdir(home, 0700); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = exec.Command("gpgconf", "--homedir", home, "--kill", "gpg-agent").Run()
+	})
+
+	runSignatureTestGpg(t, home, "--passphrase", "", "--quick-generate-key", "ptm test <ptm@example.com>", "default", "default", "never")
+
+	keyring := filepath.Join(directory, "keyring.gpg")
+	runSignatureTestGpg(t, home, "--output", keyring, "--export", "ptm@example.com")
+
+	return home, keyring
+}
+
+// runSignatureTestGpg runs gpg in batch mode with the GnuPG home.
+func runSignatureTestGpg(t *testing.T, home string, arguments ...string) {
+	command := exec.Command("gpg", append([]string{"--homedir", home, "--batch", "--yes", "--pinentry-mode", "loopback"}, arguments...)...)
+	if output, err := command.CombinedOutput(); err != nil {
+		t.Fatalf("gpg %s failed: %v %s", strings.Join(arguments, " "), err, output)
+	}
+}
+
+// clearsignSignatureTestContent returns the content clearsigned with the key of the GnuPG home.
+func clearsignSignatureTestContent(t *testing.T, home string, content string) string {
+	directory := t.TempDir()
+	input := filepath.Join(directory, "checksums")
+	if err := os.WriteFile(input, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output := filepath.Join(directory, "checksums.asc")
+	runSignatureTestGpg(t, home, "--output", output, "--clearsign", input)
+
+	signed, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(signed)
+}
+
+// TestVerifySignatureClearsigned tests that only the signed block of a clearsigned checksum file is trusted.
+func TestVerifySignatureClearsigned(t *testing.T) {
+	home, keyring := newSignatureTestKeyring(t)
+	forged := signatureTestForgedChecksum + " *" + signatureTestImage + "\n"
+	signed := clearsignSignatureTestContent(t, home, signatureTestChecksum+" *"+signatureTestImage+"\n")
+
+	tests := []struct {
+		name        string
+		content     string
+		expectError bool
+	}{
+		{"signed", signed, false},
+		{"forged line before the signed block", forged + signed, false},
+		{"forged line after the signed block", signed + forged, false},
+		{"tampered signed block", strings.Replace(signed, signatureTestChecksum, signatureTestForgedChecksum, 1), true},
+		{"not signed", forged, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			verified, err := VerifySignature(keyring, []byte(test.content), "")
+			if (err != nil) != test.expectError {
+				t.Fatalf("VerifySignature returned error %v, want error: %v", err, test.expectError)
+			}
+			if test.expectError {
+				return
+			}
+
+			checksum, err := NewChecksum(string(verified), signatureTestImage)
+			if err != nil {
+				t.Fatalf("NewChecksum returned error: %v", err)
+			}
+
+			if checksum.GetExpected() != signatureTestChecksum {
+				t.Errorf("NewChecksum returned %s, want %s", checksum.GetExpected(), signatureTestChecksum)
+			}
+		})
+	}
+}
@@ -5,13 +5,27 @@ import (
 	"github.com/cqroot/prompt/choose"
 	"github.com/cqroot/prompt/input"
 	config "github.com/darki73/ptm/pkg/configuration"
+	cic "github.com/darki73/ptm/pkg/configuration/cloud-init"
 	"github.com/darki73/ptm/pkg/prompter"
 	"github.com/darki73/ptm/pkg/proxmox"
 	"github.com/darki73/ptm/pkg/qemu"
 	ci "github.com/darki73/ptm/pkg/qemu/cloud-init"
+	"github.com/darki73/ptm/pkg/qemu/hookscript"
+	nc "github.com/darki73/ptm/pkg/qemu/network-config"
 	"github.com/darki73/ptm/pkg/utils"
 )
 
+var (
+	// cloudInitAddressModeNotes is the map of cloud-init address modes to the notes shown when selecting them.
+	cloudInitAddressModeNotes = map[string]string{
+		ci.AddressModeStatic: "Static address and gateway",
+		ci.AddressModeDhcp:   "Address is assigned by a DHCP server",
+		ci.AddressModeDhcpv6: "Address is assigned by a DHCPv6 server",
+		ci.AddressModeSlaac:  "Address is derived from router advertisements",
+		ci.AddressModeNone:   "Address family is not configured",
+	}
+)
+
 // Maker represents the maker struct.
 type Maker struct {
 	// configuration represents the reference to the configuration.
@@ -24,6 +38,8 @@ type Maker struct {
 	availableCoreCount int
 	// availableMemory represents the amount of available memory.
 	availableMemory uint64
+	// hostCapabilities represents the reference to the host capabilities.
+	hostCapabilities *qemu.HostCapabilities
 	// images represents the reference to the images.
 	images *proxmox.Images
 	// storage represents the reference to the storage.
@@ -46,7 +62,23 @@ func NewMaker(configuration *config.Configuration, qemuConfiguration *qemu.Qemu)
 		return nil, err
 	}
 
-	keys, err := proxmox.NewShellKeys()
+	keySources := configuration.GetCloudInit().GetSshKeySources()
+	if keySources == nil {
+		keySources = cic.InitializeCloudInitSshKeySourcesWithDefaults()
+	}
+
+	keysCacheTtl, err := keySources.GetCacheTtl()
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := proxmox.NewShellKeys(
+		keySources.GetDirectories(),
+		keySources.GetAuthorizedKeysFiles(),
+		keySources.GetUrls(),
+		keySources.GetCacheDirectory(),
+		keysCacheTtl,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +97,7 @@ func NewMaker(configuration *config.Configuration, qemuConfiguration *qemu.Qemu)
 		cloudInitConfiguration: cloudInitConfiguration,
 		availableCoreCount:     utils.GetCoreCount(),
 		availableMemory:        utils.GetTotalMemory(),
+		hostCapabilities:       qemu.NewHostCapabilities(),
 		images:                 images,
 		storage:                storage,
 		keys:                   keys,
@@ -77,10 +110,22 @@ func (maker *Maker) Run() error {
 		return err
 	}
 
+	if err := maker.handleGuestAgentVerificationLogic(); err != nil {
+		return err
+	}
+
 	if err := maker.handleCloudInitConfigurationLogic(); err != nil {
 		return err
 	}
 
+	if err := maker.handleCloudInitCustomSnippetsLogic(); err != nil {
+		return err
+	}
+
+	if err := maker.handleHookscriptLogic(); err != nil {
+		return err
+	}
+
 	cli := qemu.NewCommandLineInterface(maker.qemuConfiguration)
 
 	return cli.Execute()
@@ -367,9 +412,7 @@ func (maker *Maker) askForCloudInitPassword() error {
 		return err
 	}
 
-	maker.cloudInitConfiguration.SetPassword(result)
-
-	return nil
+	return maker.cloudInitConfiguration.SetPassword(result)
 }
 
 // askWhetherToSetCloudInitSSHKeys asks whether to set the cloud-init SSH keys.
@@ -412,7 +455,7 @@ func (maker *Maker) askForCloudInitSSHKeys() error {
 
 	for _, selectedShellKey := range result {
 		keyReference := maker.keys.FindKeyByName(selectedShellKey)
-		shellKeys = append(shellKeys, keyReference.GetFullPath())
+		shellKeys = append(shellKeys, keyReference.GetContent())
 	}
 
 	maker.cloudInitConfiguration.SetKeys(shellKeys)
@@ -422,32 +465,34 @@ func (maker *Maker) askForCloudInitSSHKeys() error {
 
 // askWhetherToConfigureCloudInitIPv4 asks whether to configure the cloud-init IPv4.
 func (maker *Maker) askWhetherToConfigureCloudInitIPv4() error {
-	result, err := prompter.PromptChoiceYesNo(
-		"Would you like to configure the cloud-init IPv4?",
+	mode, err := maker.askForCloudInitAddressMode(
+		"Please select the IPv4 address mode for the cloud-init configuration",
+		ci.GetIPv4AddressModes(),
 	)
 
 	if err != nil {
 		return err
 	}
 
-	if result {
-		if err := maker.askForCloudInitIPv4Address(); err != nil {
-			return err
-		}
-		if err := maker.askForCloudInitIPv4Gateway(); err != nil {
-			return err
-		}
-	} else {
-		maker.cloudInitConfiguration.AutoConfigureIPv4()
+	if mode != ci.AddressModeStatic {
+		maker.cloudInitConfiguration.SetIPv4Mode(mode)
+		return nil
 	}
 
-	return nil
+	if err := maker.askForCloudInitIPv4Address(); err != nil {
+		return err
+	}
+
+	return maker.askForCloudInitIPv4Gateway()
 }
 
 // askWhetherToReconfigureCloudInitIPv4 asks whether to reconfigure the cloud-init IPv4.
 func (maker *Maker) askWhetherToReconfigureCloudInitIPv4() error {
 	result, err := prompter.PromptChoiceYesNo(
-		"Would you like to reconfigure the cloud-init IPv4?",
+		fmt.Sprintf(
+			"Would you like to reconfigure the cloud-init IPv4 (current mode: %s)?",
+			maker.cloudInitConfiguration.GetIPv4Mode(),
+		),
 	)
 
 	if err != nil {
@@ -455,12 +500,7 @@ func (maker *Maker) askWhetherToReconfigureCloudInitIPv4() error {
 	}
 
 	if result {
-		if err := maker.askForCloudInitIPv4Address(); err != nil {
-			return err
-		}
-		if err := maker.askForCloudInitIPv4Gateway(); err != nil {
-			return err
-		}
+		return maker.askWhetherToConfigureCloudInitIPv4()
 	}
 
 	return nil
@@ -477,8 +517,8 @@ func (maker *Maker) askForCloudInitIPv4Address() error {
 		return err
 	}
 
-	if !utils.IsValidIPWithSubnet(result) {
-		fmt.Println("The specified IPv4 address is invalid.")
+	if _, err := ci.ValidateIPv4Address(result); err != nil {
+		fmt.Println(err.Error())
 		return maker.askForCloudInitIPv4Address()
 	}
 
@@ -498,14 +538,14 @@ func (maker *Maker) askForCloudInitIPv4Gateway() error {
 		return err
 	}
 
-	if !utils.IsValidIP(result) {
-		fmt.Println("The specified IPv4 gateway is invalid.")
-		return maker.askForCloudInitIPv4Gateway()
+	prefix, err := ci.ValidateIPv4Address(maker.cloudInitConfiguration.GetIPv4())
+	if err != nil {
+		return err
 	}
 
-	if !utils.IsInSameNetwork(result, maker.cloudInitConfiguration.GetIPv4()) {
-		fmt.Println("The specified IPv4 gateway is not in the same network as the IPv4 address.")
-		return maker.askForCloudInitIPv4Address()
+	if err := ci.ValidateIPv4Gateway(prefix, result); err != nil {
+		fmt.Println(err.Error())
+		return maker.askForCloudInitIPv4Gateway()
 	}
 
 	maker.cloudInitConfiguration.SetIPv4Gateway(result)
@@ -515,32 +555,34 @@ func (maker *Maker) askForCloudInitIPv4Gateway() error {
 
 // askWhetherToConfigureCloudInitIPv6 asks whether to configure the cloud-init IPv6.
 func (maker *Maker) askWhetherToConfigureCloudInitIPv6() error {
-	result, err := prompter.PromptChoiceYesNo(
-		"Would you like to configure the cloud-init IPv6?",
+	mode, err := maker.askForCloudInitAddressMode(
+		"Please select the IPv6 address mode for the cloud-init configuration",
+		ci.GetIPv6AddressModes(),
 	)
 
 	if err != nil {
 		return err
 	}
 
-	if result {
-		if err := maker.askForCloudInitIPv6Address(); err != nil {
-			return err
-		}
-		if err := maker.askForCloudInitIPv6Gateway(); err != nil {
-			return err
-		}
-	} else {
-		maker.cloudInitConfiguration.AutoConfigureIPv6()
+	if mode != ci.AddressModeStatic {
+		maker.cloudInitConfiguration.SetIPv6Mode(mode)
+		return nil
 	}
 
-	return nil
+	if err := maker.askForCloudInitIPv6Address(); err != nil {
+		return err
+	}
+
+	return maker.askForCloudInitIPv6Gateway()
 }
 
 // askWhetherToReconfigureCloudInitIPv6 asks whether to reconfigure the cloud-init IPv6.
 func (maker *Maker) askWhetherToReconfigureCloudInitIPv6() error {
 	result, err := prompter.PromptChoiceYesNo(
-		"Would you like to reconfigure the cloud-init IPv6?",
+		fmt.Sprintf(
+			"Would you like to reconfigure the cloud-init IPv6 (current mode: %s)?",
+			maker.cloudInitConfiguration.GetIPv6Mode(),
+		),
 	)
 
 	if err != nil {
@@ -548,12 +590,7 @@ func (maker *Maker) askWhetherToReconfigureCloudInitIPv6() error {
 	}
 
 	if result {
-		if err := maker.askForCloudInitIPv6Address(); err != nil {
-			return err
-		}
-		if err := maker.askForCloudInitIPv6Gateway(); err != nil {
-			return err
-		}
+		return maker.askWhetherToConfigureCloudInitIPv6()
 	}
 
 	return nil
@@ -570,8 +607,8 @@ func (maker *Maker) askForCloudInitIPv6Address() error {
 		return err
 	}
 
-	if !utils.IsValidIPWithSubnet(result) {
-		fmt.Println("The specified IPv6 address is invalid.")
+	if _, err := ci.ValidateIPv6Address(result); err != nil {
+		fmt.Println(err.Error())
 		return maker.askForCloudInitIPv6Address()
 	}
 
@@ -591,14 +628,14 @@ func (maker *Maker) askForCloudInitIPv6Gateway() error {
 		return err
 	}
 
-	if !utils.IsValidIP(result) {
-		fmt.Println("The specified IPv6 gateway is invalid.")
-		return maker.askForCloudInitIPv6Gateway()
+	prefix, err := ci.ValidateIPv6Address(maker.cloudInitConfiguration.GetIPv6())
+	if err != nil {
+		return err
 	}
 
-	if !utils.IsInSameNetwork(result, maker.cloudInitConfiguration.GetIPv6()) {
-		fmt.Println("The specified IPv6 gateway is not in the same network as the IPv6 address.")
-		return maker.askForCloudInitIPv6Address()
+	if err := ci.ValidateIPv6Gateway(prefix, result); err != nil {
+		fmt.Println(err.Error())
+		return maker.askForCloudInitIPv6Gateway()
 	}
 
 	maker.cloudInitConfiguration.SetIPv6Gateway(result)
@@ -606,6 +643,89 @@ func (maker *Maker) askForCloudInitIPv6Gateway() error {
 	return nil
 }
 
+// askForCloudInitAddressMode asks for the cloud-init address mode out of the given modes.
+func (maker *Maker) askForCloudInitAddressMode(message string, modes []string) (string, error) {
+	choices := make([]choose.Choice, 0)
+
+	for _, mode := range modes {
+		choice := choose.Choice{
+			Text: mode,
+			Note: cloudInitAddressModeNotes[mode],
+		}
+		choices = append(choices, choice)
+	}
+
+	return prompter.PromptChoiceString(message, choices)
+}
+
+// askWhetherToSetCloudInitDns asks whether to set the cloud-init DNS servers and search domains.
+func (maker *Maker) askWhetherToSetCloudInitDns() error {
+	result, err := prompter.PromptChoiceYesNo(
+		"Would you like to set the cloud-init DNS servers and search domains?",
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if result {
+		if err := maker.askForCloudInitNameservers(); err != nil {
+			return err
+		}
+		if err := maker.askForCloudInitSearchDomains(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// askForCloudInitNameservers asks for the cloud-init DNS servers.
+func (maker *Maker) askForCloudInitNameservers() error {
+	result, err := prompter.PromptString(
+		"Please enter the comma-separated list of DNS servers for the cloud-init configuration (leave empty to skip)",
+		"",
+	)
+
+	if err != nil {
+		return err
+	}
+
+	nameservers := ci.ParseList(result)
+
+	if err := ci.ValidateNameservers(nameservers); err != nil {
+		fmt.Println(err.Error())
+		return maker.askForCloudInitNameservers()
+	}
+
+	maker.cloudInitConfiguration.SetNameservers(nameservers)
+
+	return nil
+}
+
+// askForCloudInitSearchDomains asks for the cloud-init DNS search domains.
+func (maker *Maker) askForCloudInitSearchDomains() error {
+	result, err := prompter.PromptString(
+		"Please enter the comma-separated list of DNS search domains for the cloud-init configuration (leave empty to skip)",
+		"",
+	)
+
+	if err != nil {
+		return err
+	}
+
+	searchDomains := ci.ParseList(result)
+
+	if err := ci.ValidateSearchDomains(searchDomains); err != nil {
+		fmt.Println(err.Error())
+		return maker.askForCloudInitSearchDomains()
+	}
+
+	maker.cloudInitConfiguration.SetSearchDomains(searchDomains)
+
+	return nil
+}
+
 // handleQemuConfigurationLogic handles the QEMU configuration logic.
 func (maker *Maker) handleQemuConfigurationLogic() error {
 	if maker.qemuConfiguration == nil {
@@ -641,6 +761,10 @@ func (maker *Maker) handleQemuConfigurationFromFlags() error {
 		return err
 	}
 
+	if err := maker.handleHostCapabilitiesValidationLogic(); err != nil {
+		return err
+	}
+
 	if err := maker.handleStorageSelectionLogic(maker.qemuConfiguration.GetStorage()); err != nil {
 		return err
 	}
@@ -670,6 +794,10 @@ func (maker *Maker) handleQemuConfigurationFromConfigurationFile() error {
 		return err
 	}
 
+	if err := maker.handleHostCapabilitiesValidationLogic(); err != nil {
+		return err
+	}
+
 	if err := maker.handleStorageSelectionLogic(maker.qemuConfiguration.GetStorage()); err != nil {
 		return err
 	}
@@ -719,6 +847,10 @@ func (maker *Maker) handleQemuConfigurationFromPrompt() error {
 		return err
 	}
 
+	if err := maker.handleHostCapabilitiesValidationLogic(); err != nil {
+		return err
+	}
+
 	if err := maker.askForNetworkDriver(); err != nil {
 		return err
 	}
@@ -800,6 +932,12 @@ func (maker *Maker) handleCloudInitConfigurationFromConfigurationFile() error {
 		return err
 	}
 
+	if !cloudInitConfiguration.HasDns() {
+		if err := maker.askWhetherToSetCloudInitDns(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -835,6 +973,10 @@ func (maker *Maker) handleCloudInitConfigurationFromPrompt() error {
 		if err := maker.askWhetherToConfigureCloudInitIPv6(); err != nil {
 			return err
 		}
+
+		if err := maker.askWhetherToSetCloudInitDns(); err != nil {
+			return err
+		}
 	}
 
 	maker.qemuConfiguration.SetCloudInit(maker.cloudInitConfiguration)
@@ -862,9 +1004,44 @@ func (maker *Maker) handleImageSelectionLogic(imageName string) error {
 		return fmt.Errorf("image `%s` could not be found", imageName)
 	}
 
+	if err := maker.handleImageArchitectureLogic(imageReference); err != nil {
+		return err
+	}
+
 	maker.qemuConfiguration.SetImage(imageName)
 	maker.qemuConfiguration.SetImageSize(imageReference.GetSize())
 
+	if metadata := imageReference.GetMetadata(); metadata != nil {
+		maker.qemuConfiguration.SetDescription(metadata.Describe())
+	}
+
+	return nil
+}
+
+// handleImageArchitectureLogic handles the image architecture logic.
+func (maker *Maker) handleImageArchitectureLogic(imageReference *proxmox.Image) error {
+	imageArchitecture, err := imageReference.GetArchitecture()
+	if err != nil {
+		fmt.Println("Unable to detect image architecture, skipping architecture check (host architecture is used if none is set):", err)
+	}
+
+	if err := maker.qemuConfiguration.ValidateArchitecture(imageArchitecture); err != nil {
+		if maker.isPromptConfigurationFlow() {
+			fmt.Println(err.Error())
+			return maker.askForTargetImage()
+		}
+
+		return err
+	}
+
+	if maker.qemuConfiguration.GetArchitecture() != utils.GetHostArchitecture() {
+		fmt.Printf(
+			"Virtual machine architecture `%s` differs from host architecture `%s`, it will run using software emulation.\n",
+			maker.qemuConfiguration.GetArchitecture(),
+			utils.GetHostArchitecture(),
+		)
+	}
+
 	return nil
 }
 
@@ -965,6 +1142,219 @@ func (maker *Maker) handleMemorySelectionLogicFromInt(memory int) error {
 	return nil
 }
 
+// handleHostCapabilitiesValidationLogic validates the CPU and memory topology and devices against the host capabilities.
+func (maker *Maker) handleHostCapabilitiesValidationLogic() error {
+	if err := maker.qemuConfiguration.ValidateAgainstHost(maker.hostCapabilities); err != nil {
+		return err
+	}
+
+	return maker.qemuConfiguration.ValidateDevices()
+}
+
+// handleGuestAgentVerificationLogic verifies that the guest agent is installed and enabled in the image (when enabled).
+func (maker *Maker) handleGuestAgentVerificationLogic() error {
+	if !maker.qemuConfiguration.GetGuestAgentEnabled() {
+		return nil
+	}
+
+	inspection, err := qemu.InspectGuestAgent(maker.qemuConfiguration.GetImage())
+	if err != nil {
+		fmt.Println("Unable to verify guest agent in the image, skipping verification:", err)
+		return nil
+	}
+
+	if inspection.IsOperational() {
+		return nil
+	}
+
+	warningMessage := "Warning: guest agent is enabled, but `qemu-guest-agent` is not enabled in the image, the agent will never respond."
+	if !inspection.IsInstalled() {
+		warningMessage = "Warning: guest agent is enabled, but `qemu-guest-agent` is not installed in the image, the agent will never respond."
+	}
+	fmt.Println(warningMessage)
+	fmt.Println("Run `ptm customize` (qemu-guest-agent is one of the base packages) or disable the guest agent.")
+
+	if !maker.isPromptConfigurationFlow() {
+		return nil
+	}
+
+	proceed, err := prompter.PromptChoiceYesNo("Would you like to continue anyway?")
+	if err != nil {
+		return err
+	}
+
+	if !proceed {
+		return fmt.Errorf("template creation aborted: guest agent is not operational in the image")
+	}
+
+	return nil
+}
+
+// handleHookscriptLogic renders the hookscript, uploads it to the snippets storage and attaches it to the template.
+func (maker *Maker) handleHookscriptLogic() error {
+	hookscriptConfiguration := maker.configuration.GetHookscript()
+	if hookscriptConfiguration == nil || !hookscriptConfiguration.IsConfigured() {
+		return nil
+	}
+
+	name, err := hookscript.GetHookscriptName(hookscriptConfiguration, maker.qemuConfiguration.GetName())
+	if err != nil {
+		return err
+	}
+
+	script, err := hookscript.BuildHookscript(hookscriptConfiguration)
+	if err != nil {
+		return err
+	}
+
+	snippets, err := proxmox.NewSnippets(maker.storage, hookscriptConfiguration.GetStorage())
+	if err != nil {
+		return err
+	}
+
+	volume, err := snippets.Upload(name, script, 0755)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Hookscript uploaded to:", volume)
+	maker.qemuConfiguration.SetHookscript(volume)
+
+	return nil
+}
+
+// handleCloudInitCustomSnippetsLogic validates the custom cloud-init snippets, uploads them to the snippets storage and attaches them to the template.
+func (maker *Maker) handleCloudInitCustomSnippetsLogic() error {
+	cloudInitConfiguration := maker.qemuConfiguration.GetCloudInit()
+	if cloudInitConfiguration == nil {
+		return nil
+	}
+
+	if !cloudInitConfiguration.HasCustomSnippets() && maker.isCloudInitConfigurationFileFlow(cloudInitConfiguration) {
+		maker.loadCloudInitCustomSnippetsFromConfigurationFile(cloudInitConfiguration)
+	}
+
+	if err := maker.handleCloudInitNetworkConfigLogic(cloudInitConfiguration); err != nil {
+		return err
+	}
+
+	if err := maker.handleCloudInitUsersLogic(cloudInitConfiguration); err != nil {
+		return err
+	}
+
+	if !cloudInitConfiguration.HasCustomSnippets() {
+		return nil
+	}
+
+	snippets, err := proxmox.NewSnippets(maker.storage, cloudInitConfiguration.GetSnippetsStorage())
+	if err != nil {
+		return err
+	}
+
+	for _, snippetType := range ci.GetSnippetTypes() {
+		value := cloudInitConfiguration.GetCustomSnippet(snippetType)
+		if value == "" {
+			continue
+		}
+
+		content, err := ci.ResolveCustomSnippet(value)
+		if err != nil {
+			return err
+		}
+
+		if err := ci.ValidateCustomSnippet(snippetType, content); err != nil {
+			return err
+		}
+
+		volume, err := snippets.Upload(ci.GetCustomSnippetFileName(maker.qemuConfiguration.GetIdentifier(), snippetType), content, 0644)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Custom cloud-init %s-data uploaded to: %s\n", snippetType, volume)
+		cloudInitConfiguration.SetCustomVolume(snippetType, volume)
+	}
+
+	if cloudInitConfiguration.GetCustomVolume(ci.SnippetTypeUser) != "" {
+		fmt.Println("Note: custom user-data replaces the user, password and SSH keys generated by Proxmox VE")
+	}
+
+	return nil
+}
+
+// handleCloudInitNetworkConfigLogic renders interfaces, bonds and VLANs from the configuration file into the custom network-config snippet.
+// Flags and prompt answers configure `ipconfig0` instead, so the network-config is only rendered in the configuration file flow.
+func (maker *Maker) handleCloudInitNetworkConfigLogic(cloudInitConfiguration *ci.CloudInit) error {
+	if !maker.isCloudInitConfigurationFileFlow(cloudInitConfiguration) {
+		return nil
+	}
+
+	if cloudInitConfiguration.GetCustomSnippet(ci.SnippetTypeNetwork) != "" {
+		return nil
+	}
+
+	if maker.configuration == nil || maker.configuration.GetCloudInit() == nil {
+		return nil
+	}
+
+	network := maker.configuration.GetCloudInit().GetNetwork()
+	if network == nil || !network.HasAdvancedConfiguration() {
+		return nil
+	}
+
+	content, err := nc.BuildNetworkConfig(network)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Note: network-config rendered from the configuration file replaces the `ipconfig0` settings")
+	cloudInitConfiguration.SetCustomSnippet(ci.SnippetTypeNetwork, content)
+
+	return nil
+}
+
+// handleCloudInitUsersLogic renders the additional users from the configuration file into the custom vendor-data snippet.
+// Additional users have no flags or prompts, so they are only taken from the configuration file in its flow.
+func (maker *Maker) handleCloudInitUsersLogic(cloudInitConfiguration *ci.CloudInit) error {
+	if !maker.isCloudInitConfigurationFileFlow(cloudInitConfiguration) {
+		return nil
+	}
+
+	if !cloudInitConfiguration.HasUsers() && maker.configuration != nil && maker.configuration.GetCloudInit() != nil {
+		cloudInitConfiguration.SetUsers(ci.NewUsersFromConfiguration(maker.configuration.GetCloudInit().GetUsers()))
+	}
+
+	if !cloudInitConfiguration.HasUsers() {
+		return nil
+	}
+
+	return cloudInitConfiguration.ApplyUsersVendorData()
+}
+
+// loadCloudInitCustomSnippetsFromConfigurationFile loads the custom cloud-init snippets from the configuration file (configuration file flow only).
+func (maker *Maker) loadCloudInitCustomSnippetsFromConfigurationFile(cloudInitConfiguration *ci.CloudInit) {
+	if maker.configuration == nil || maker.configuration.GetCloudInit() == nil {
+		return
+	}
+
+	cic := maker.configuration.GetCloudInit()
+
+	cloudInitConfiguration.SetCustomSnippet(ci.SnippetTypeUser, cic.GetUserData())
+	cloudInitConfiguration.SetCustomSnippet(ci.SnippetTypeVendor, cic.GetVendorData())
+	cloudInitConfiguration.SetCustomSnippet(ci.SnippetTypeNetwork, cic.GetNetworkData())
+	cloudInitConfiguration.SetCustomSnippet(ci.SnippetTypeMeta, cic.GetMetaData())
+
+	if cloudInitConfiguration.GetSnippetsStorage() == "" {
+		cloudInitConfiguration.SetSnippetsStorage(cic.GetSnippetsStorage())
+	}
+}
+
+// isCloudInitConfigurationFileFlow checks whether the cloud-init configuration was loaded from the configuration file.
+// Settings which have no flags or prompts (snippets, network-config, users) are only taken from the configuration file in this flow.
+func (maker *Maker) isCloudInitConfigurationFileFlow(cloudInitConfiguration *ci.CloudInit) bool {
+	return cloudInitConfiguration.GetConfigurationSource() == ci.ConfigurationSourceConfigurationFile
+}
+
 // isPromptConfigurationFlow checks whether we are using the prompt configuration flow.
 func (maker *Maker) isPromptConfigurationFlow() bool {
 	return maker.qemuConfiguration.GetConfigurationSource() == qemu.ConfigurationSourcePrompt
@@ -18,6 +18,10 @@ type Image struct {
 	virtualSize int64
 	// qemuImage is the QemuImage struct.
 	qemuImage *QemuImage
+	// architecture is the architecture of the image (detected on demand).
+	architecture string
+	// metadata is the metadata recorded when the image was downloaded (nil if there is none).
+	metadata *ImageMetadata
 }
 
 // NewImage creates a new Image instance.
@@ -34,6 +38,12 @@ func NewImage(name string, path string, size int64) (*Image, error) {
 		return nil, err
 	}
 
+	metadata, err := LoadImageMetadata(image.GetFullPath())
+	if err != nil {
+		return nil, err
+	}
+	image.metadata = metadata
+
 	return image, nil
 }
 
@@ -67,6 +77,27 @@ func (image *Image) GetQemuImageInformation() *QemuImage {
 	return image.qemuImage
 }
 
+// GetMetadata returns the metadata recorded when the image was downloaded (nil if there is none).
+func (image *Image) GetMetadata() *ImageMetadata {
+	return image.metadata
+}
+
+// GetArchitecture returns the architecture of the image (detected with virt-inspector on first call).
+func (image *Image) GetArchitecture() (string, error) {
+	if image.architecture != "" {
+		return image.architecture, nil
+	}
+
+	architecture, err := utils.GetImageArchitecture(image.GetFullPath())
+	if err != nil {
+		return "", err
+	}
+
+	image.architecture = architecture
+
+	return image.architecture, nil
+}
+
 // loadQemuInfo loads the Qemu image information.
 func (image *Image) loadQemuInfo() error {
 	output, err := utils.ExecuteCommand("qemu-img", "info", "--output=json", path.Join(image.GetPath(), image.GetName()))
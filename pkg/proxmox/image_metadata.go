@@ -0,0 +1,104 @@
+package proxmox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// ImageMetadataExtension is the extension of the metadata file written next to a downloaded image.
+	ImageMetadataExtension = ".json"
+	// ImagePartialExtension is the extension of an image which is still being downloaded.
+	ImagePartialExtension = ".part"
+)
+
+// ImageMetadata represents the metadata recorded when an image is downloaded.
+type ImageMetadata struct {
+	// Distribution is the name of the image distribution.
+	Distribution string `json:"distribution"`
+	// Release is the name of the image release.
+	Release string `json:"release"`
+	// Architecture is the name of the image architecture.
+	Architecture string `json:"architecture"`
+	// Format is the name of the image format.
+	Format string `json:"format"`
+	// Minimal is a boolean value that indicates if the image is minimal.
+	Minimal bool `json:"minimal"`
+	// Variant is the name of the image variant (for example, minimal / genericcloud / nocloud).
+	Variant string `json:"variant,omitempty"`
+	// Serial is the dated serial the image was taken from (empty if the distribution does not publish serials).
+	Serial string `json:"serial,omitempty"`
+	// Url is the URL the image was downloaded from.
+	Url string `json:"url"`
+	// ChecksumAlgorithm is the algorithm of the verified checksum (empty if the checksum was not verified).
+	ChecksumAlgorithm string `json:"checksum_algorithm,omitempty"`
+	// Checksum is the verified checksum of the image.
+	Checksum string `json:"checksum,omitempty"`
+	// DownloadedAt is the time the image was downloaded at (RFC 3339).
+	DownloadedAt string `json:"downloaded_at"`
+}
+
+// GetImageMetadataPath returns the path of the metadata file of the image.
+func GetImageMetadataPath(imagePath string) string {
+	return imagePath + ImageMetadataExtension
+}
+
+// LoadImageMetadata loads the metadata recorded for the image (nil if there is none).
+func LoadImageMetadata(imagePath string) (*ImageMetadata, error) {
+	content, err := os.ReadFile(GetImageMetadataPath(imagePath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &ImageMetadata{}
+	if err := json.Unmarshal(content, metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata of `%s`: %v", imagePath, err)
+	}
+
+	return metadata, nil
+}
+
+// Save writes the metadata next to the image.
+func (metadata *ImageMetadata) Save(imagePath string) error {
+	content, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(GetImageMetadataPath(imagePath), append(content, '\n'), 0644)
+}
+
+// Describe returns the description of the image used for templates created from it.
+func (metadata *ImageMetadata) Describe() string {
+	lines := []string{
+		fmt.Sprintf("Base image: %s %s (%s, %s)", metadata.Distribution, metadata.Release, metadata.Architecture, metadata.Format),
+	}
+
+	if metadata.Variant != "" {
+		lines = append(lines, fmt.Sprintf("Variant: %s", metadata.Variant))
+	}
+
+	if metadata.Serial != "" {
+		lines = append(lines, fmt.Sprintf("Serial: %s", metadata.Serial))
+	}
+
+	lines = append(lines, fmt.Sprintf("Source: %s", metadata.Url))
+
+	if metadata.Checksum != "" {
+		lines = append(lines, fmt.Sprintf("Checksum: %s:%s", metadata.ChecksumAlgorithm, metadata.Checksum))
+	}
+
+	lines = append(lines, fmt.Sprintf("Downloaded: %s", metadata.DownloadedAt))
+
+	return strings.Join(lines, "\n")
+}
+
+// isImageFile returns true if the file is an image (and not metadata or an unfinished download).
+func isImageFile(name string) bool {
+	return !strings.HasSuffix(name, ImageMetadataExtension) && !strings.HasSuffix(name, ImagePartialExtension)
+}
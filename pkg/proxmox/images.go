@@ -54,7 +54,7 @@ func (images *Images) listAvailableISOs() error {
 		return err
 	}
 	for _, item := range items {
-		if item.IsDir() {
+		if item.IsDir() || !isImageFile(item.Name()) {
 			continue
 		}
 
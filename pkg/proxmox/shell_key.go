@@ -1,23 +1,34 @@
 package proxmox
 
-import "path"
+import (
+	"github.com/darki73/ptm/pkg/utils"
+	"path"
+)
 
 // ShellKey represents the shell key.
 type ShellKey struct {
 	// name is the name of the key.
 	name string
-	// path is the path of the key.
+	// path is the path of the key (directory, authorized_keys file or URL the key was loaded from).
 	path string
 	// content is the content of the key.
 	content string
+	// fingerprint is the SHA256 fingerprint of the key.
+	fingerprint string
 }
 
 // NewShellKey creates a new ShellKey instance.
 func NewShellKey(name string, path string, content string) *ShellKey {
+	fingerprint := ""
+	if key, err := utils.ParseSshPublicKey(content); err == nil {
+		fingerprint = key.GetFingerprint()
+	}
+
 	return &ShellKey{
-		name:    name,
-		path:    path,
-		content: content,
+		name:        name,
+		path:        path,
+		content:     content,
+		fingerprint: fingerprint,
 	}
 }
 
@@ -40,3 +51,8 @@ func (key *ShellKey) GetFullPath() string {
 func (key *ShellKey) GetContent() string {
 	return key.content
 }
+
+// GetFingerprint returns the SHA256 fingerprint of the key.
+func (key *ShellKey) GetFingerprint() string {
+	return key.fingerprint
+}
@@ -1,24 +1,43 @@
 package proxmox
 
 import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/log"
+	"github.com/darki73/ptm/pkg/utils"
 	"os"
 	"path"
 	"strings"
+	"time"
 )
 
 // ShellKeys represents the container of available ssh keys.
 type ShellKeys struct {
-	// path is the path to ssh keys.
-	path string
+	// directories is the list of directories scanned for `*.pub` files.
+	directories []string
+	// files is the list of authorized_keys files.
+	files []string
+	// urls is the list of URLs serving keys in the authorized_keys format.
+	urls []string
+	// cacheDirectory is the directory where keys fetched from URLs are cached.
+	cacheDirectory string
+	// cacheTtl is the duration keys fetched from URLs are cached for.
+	cacheTtl time.Duration
 	// keys is the list of available ssh keys.
 	keys []*ShellKey
+	// fingerprints is the set of fingerprints of the available ssh keys.
+	fingerprints map[string]bool
 }
 
 // NewShellKeys creates a new ShellKeys instance.
-func NewShellKeys() (*ShellKeys, error) {
+func NewShellKeys(directories []string, files []string, urls []string, cacheDirectory string, cacheTtl time.Duration) (*ShellKeys, error) {
 	shellKeys := &ShellKeys{
-		path: "/root/.ssh",
-		keys: make([]*ShellKey, 0),
+		directories:    directories,
+		files:          files,
+		urls:           urls,
+		cacheDirectory: cacheDirectory,
+		cacheTtl:       cacheTtl,
+		keys:           make([]*ShellKey, 0),
+		fingerprints:   make(map[string]bool),
 	}
 
 	if err := shellKeys.listAvailableShellKeys(); err != nil {
@@ -44,12 +63,47 @@ func (shellKeys *ShellKeys) FindKeyByName(name string) *ShellKey {
 	return nil
 }
 
-// listAvailableShellKeys lists the available shell keys.
+// listAvailableShellKeys lists the available shell keys from all sources.
 func (shellKeys *ShellKeys) listAvailableShellKeys() error {
-	items, err := os.ReadDir(shellKeys.path)
+	for _, directory := range shellKeys.directories {
+		if err := shellKeys.listDirectoryShellKeys(directory); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range shellKeys.files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			shellKeys.warn(file, err)
+			continue
+		}
+
+		shellKeys.addShellKeys(file, file, string(content))
+	}
+
+	for _, url := range shellKeys.urls {
+		content, err := utils.FetchWithCache(url, shellKeys.cacheDirectory, shellKeys.cacheTtl)
+		if err != nil {
+			shellKeys.warn(url, err)
+			continue
+		}
+
+		shellKeys.addShellKeys(url, url, string(content))
+	}
+
+	return nil
+}
+
+// listDirectoryShellKeys lists the `*.pub` files in the directory.
+func (shellKeys *ShellKeys) listDirectoryShellKeys(directory string) error {
+	items, err := os.ReadDir(directory)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
 		return err
 	}
+
 	for _, item := range items {
 		if item.IsDir() {
 			continue
@@ -59,13 +113,53 @@ func (shellKeys *ShellKeys) listAvailableShellKeys() error {
 			continue
 		}
 
-		bytes, err := os.ReadFile(path.Join(shellKeys.path, item.Name()))
+		bytes, err := os.ReadFile(path.Join(directory, item.Name()))
 		if err != nil {
 			return err
 		}
 
-		shellKeys.keys = append(shellKeys.keys, NewShellKey(item.Name(), shellKeys.path, string(bytes)))
+		shellKeys.addShellKeys(item.Name(), directory, string(bytes))
 	}
 
 	return nil
 }
+
+// addShellKeys parses the keys from the content and adds the ones that are valid and not yet known.
+func (shellKeys *ShellKeys) addShellKeys(name string, source string, content string) {
+	keys, errors := utils.ParseAuthorizedKeys(content)
+
+	location := source
+	if name != source {
+		location = path.Join(source, name)
+	}
+
+	for _, err := range errors {
+		shellKeys.warn(location, err)
+	}
+
+	for _, key := range keys {
+		if shellKeys.fingerprints[key.GetFingerprint()] {
+			continue
+		}
+		shellKeys.fingerprints[key.GetFingerprint()] = true
+
+		keyName := name
+		if len(keys) > 1 || name == source {
+			keyName = fmt.Sprintf("%s (%s)", name, strings.TrimSpace(key.GetComment()+" "+key.GetFingerprint()))
+		}
+
+		shellKeys.keys = append(shellKeys.keys, NewShellKey(keyName, source, key.String()))
+	}
+}
+
+// warn logs the warning about the key source that could not be used.
+func (shellKeys *ShellKeys) warn(source string, err error) {
+	log.WarnfWithFields(
+		"skipping ssh key from %s: %v",
+		log.FieldsMap{
+			"source": "shell-keys",
+		},
+		source,
+		err,
+	)
+}
@@ -0,0 +1,69 @@
+package proxmox
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/utils"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Snippets represents the snippets directory of a Proxmox VE storage.
+type Snippets struct {
+	// target is the storage target snippets are stored on.
+	target *StorageTarget
+}
+
+// NewSnippets creates a new Snippets instance for the storage with the specified name (first available if empty).
+func NewSnippets(storage *Storage, name string) (*Snippets, error) {
+	target, err := storage.FindSnippetsTarget(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Snippets{
+		target: target,
+	}, nil
+}
+
+// GetTarget returns the storage target snippets are stored on.
+func (snippets *Snippets) GetTarget() *StorageTarget {
+	return snippets.target
+}
+
+// GetVolumeIdentifier returns the volume identifier of the snippet (for example, local:snippets/user-data.yaml).
+func (snippets *Snippets) GetVolumeIdentifier(fileName string) string {
+	return fmt.Sprintf("%s:snippets/%s", snippets.target.GetName(), fileName)
+}
+
+// GetPath returns the path of the snippet on the filesystem.
+func (snippets *Snippets) GetPath(fileName string) (string, error) {
+	output, err := utils.ExecuteCommand("pvesm", "path", snippets.GetVolumeIdentifier(fileName))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path for snippet `%s`: %v", fileName, err)
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// Upload writes the snippet to the storage and returns its volume identifier.
+func (snippets *Snippets) Upload(fileName string, content string, permissions os.FileMode) (string, error) {
+	snippetPath, err := snippets.GetPath(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(snippetPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create snippets directory for `%s`: %v", fileName, err)
+	}
+
+	if err := os.WriteFile(snippetPath, []byte(content), permissions); err != nil {
+		return "", fmt.Errorf("failed to write snippet `%s`: %v", fileName, err)
+	}
+
+	if err := os.Chmod(snippetPath, permissions); err != nil {
+		return "", fmt.Errorf("failed to set permissions on snippet `%s`: %v", fileName, err)
+	}
+
+	return snippets.GetVolumeIdentifier(fileName), nil
+}
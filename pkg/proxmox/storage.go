@@ -1,6 +1,7 @@
 package proxmox
 
 import (
+	"fmt"
 	"github.com/darki73/ptm/pkg/utils"
 	"strings"
 )
@@ -13,6 +14,8 @@ type Storage struct {
 	arguments []string
 	// targets are the list of available storage targets.
 	targets []*StorageTarget
+	// snippetsTargets are the list of available storage targets with snippets content enabled.
+	snippetsTargets []*StorageTarget
 }
 
 // NewStorage creates a new Storage instance.
@@ -26,6 +29,10 @@ func NewStorage() (*Storage, error) {
 		return nil, err
 	}
 
+	if err := storage.listAvailableSnippetsTargets(); err != nil {
+		return nil, err
+	}
+
 	return storage, nil
 }
 
@@ -45,6 +52,26 @@ func (storage *Storage) FindTargetByName(name string) *StorageTarget {
 	return nil
 }
 
+// GetSnippetsTargets returns the list of available storage targets with snippets content enabled.
+func (storage *Storage) GetSnippetsTargets() []*StorageTarget {
+	return storage.snippetsTargets
+}
+
+// FindSnippetsTarget returns the snippets storage target with the specified name (or the first one if name is empty).
+func (storage *Storage) FindSnippetsTarget(name string) (*StorageTarget, error) {
+	for _, target := range storage.snippetsTargets {
+		if name == "" || target.GetName() == name {
+			return target, nil
+		}
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("no storage with `snippets` content type is available, please enable it on one of the storages (pvesm set <storage> --content snippets,...)")
+	}
+
+	return nil, fmt.Errorf("storage `%s` could not be found or does not have `snippets` content type enabled", name)
+}
+
 // listAvailableStorageTargets lists the available storage targets.
 func (storage *Storage) listAvailableStorageTargets() error {
 	output, err := utils.ExecuteCommand("pvesm", "status")
@@ -64,3 +91,23 @@ func (storage *Storage) listAvailableStorageTargets() error {
 
 	return nil
 }
+
+// listAvailableSnippetsTargets lists the available storage targets with snippets content enabled.
+func (storage *Storage) listAvailableSnippetsTargets() error {
+	output, err := utils.ExecuteCommand("pvesm", "status", "--content", "snippets")
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(output, "\n")
+	for _, line := range lines {
+		if len(line) != 0 && !strings.Contains(line, "Status") {
+			storageTarget := NewStorageTarget(line)
+			if storageTarget.IsActive() {
+				storage.snippetsTargets = append(storage.snippetsTargets, storageTarget)
+			}
+		}
+	}
+
+	return nil
+}
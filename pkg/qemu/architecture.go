@@ -0,0 +1,66 @@
+package qemu
+
+import (
+	"fmt"
+	ci "github.com/darki73/ptm/pkg/qemu/cloud-init"
+	"github.com/darki73/ptm/pkg/utils"
+	"strings"
+)
+
+var (
+	// supportedArchitectures is the list of virtual machine architectures supported by Proxmox VE.
+	supportedArchitectures = []string{
+		utils.ArchitectureX86,
+		utils.ArchitectureArm,
+	}
+)
+
+// ValidateArchitecture validates the virtual machine architecture against the architecture of the image.
+// If the virtual machine architecture is not set, it is taken from the image (or the host if the image architecture is unknown).
+func (qemu *Qemu) ValidateArchitecture(imageArchitecture string) error {
+	imageArchitecture = utils.NormalizeArchitecture(imageArchitecture)
+
+	if qemu.architecture == "" {
+		qemu.architecture = imageArchitecture
+	}
+
+	if qemu.architecture == "" {
+		qemu.architecture = utils.GetHostArchitecture()
+	}
+
+	if !utils.SliceContains(supportedArchitectures, qemu.architecture) {
+		return fmt.Errorf(
+			"architecture `%s` is not supported, supported architectures: %s",
+			qemu.architecture,
+			strings.Join(supportedArchitectures, ", "),
+		)
+	}
+
+	if imageArchitecture != "" && imageArchitecture != qemu.architecture {
+		return fmt.Errorf(
+			"image architecture `%s` does not match virtual machine architecture `%s`",
+			imageArchitecture,
+			qemu.architecture,
+		)
+	}
+
+	return nil
+}
+
+// ValidateCloudInitDevice validates that the cloud-init drive bus is supported by the virtual machine architecture.
+func (qemu *Qemu) ValidateCloudInitDevice() error {
+	if !qemu.IsArm() {
+		return nil
+	}
+
+	bus, _, err := ci.ParseDrive(qemu.GetCloudInitDevice())
+	if err != nil {
+		return err
+	}
+
+	if bus == "ide" {
+		return fmt.Errorf("cloud-init drive `%s` cannot be used on `%s` virtual machines as they do not have an IDE controller", qemu.GetCloudInitDevice(), qemu.architecture)
+	}
+
+	return nil
+}
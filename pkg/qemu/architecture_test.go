@@ -0,0 +1,84 @@
+package qemu
+
+import (
+	ci "github.com/darki73/ptm/pkg/qemu/cloud-init"
+	"github.com/darki73/ptm/pkg/utils"
+	"testing"
+)
+
+// TestValidateArchitecture tests the ValidateArchitecture function.
+func TestValidateArchitecture(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		architecture         string
+		imageArchitecture    string
+		expectedArchitecture string
+		expectErr            bool
+	}{
+		{"Taken From Image", "", "arm64", "aarch64", false},
+		{"Matching Aliases", "amd64", "x86_64", "x86_64", false},
+		{"Unknown Image Architecture", "arm64", "", "aarch64", false},
+		{"Detection Failed Without Architecture", "", "", utils.GetHostArchitecture(), false},
+		{"Mismatch", "amd64", "aarch64", "x86_64", true},
+		{"Unsupported", "", "armhf", "armhf", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			qemu := NewQemuConfiguration().SetArchitecture(tc.architecture)
+
+			err := qemu.ValidateArchitecture(tc.imageArchitecture)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("ValidateArchitecture() error = %v, expectErr %v", err, tc.expectErr)
+			}
+
+			if qemu.GetArchitecture() != tc.expectedArchitecture {
+				t.Errorf("GetArchitecture() = %s, want %s", qemu.GetArchitecture(), tc.expectedArchitecture)
+			}
+		})
+	}
+}
+
+// TestGetCloudInitDevice tests the GetCloudInitDevice function.
+func TestGetCloudInitDevice(t *testing.T) {
+	if device := NewQemuConfiguration().SetArchitecture("amd64").GetCloudInitDevice(); device != "ide2" {
+		t.Errorf("GetCloudInitDevice() = %s, want %s", device, "ide2")
+	}
+
+	if device := NewQemuConfiguration().SetArchitecture("arm64").GetCloudInitDevice(); device != "scsi1" {
+		t.Errorf("GetCloudInitDevice() = %s, want %s", device, "scsi1")
+	}
+
+	cloudInit := ci.NewCloudInitConfiguration().SetDrive("sata0")
+	if device := NewQemuConfiguration().SetArchitecture("amd64").SetCloudInit(cloudInit).GetCloudInitDevice(); device != "sata0" {
+		t.Errorf("GetCloudInitDevice() = %s, want %s", device, "sata0")
+	}
+}
+
+// TestValidateCloudInitDevice tests the ValidateCloudInitDevice function.
+func TestValidateCloudInitDevice(t *testing.T) {
+	testCases := []struct {
+		name         string
+		architecture string
+		drive        string
+		expectErr    bool
+	}{
+		{"Default x86_64", "amd64", "", false},
+		{"Default aarch64", "arm64", "", false},
+		{"IDE on x86_64", "amd64", "ide0", false},
+		{"SATA on aarch64", "arm64", "sata1", false},
+		{"IDE on aarch64", "arm64", "ide2", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			qemu := NewQemuConfiguration().
+				SetArchitecture(tc.architecture).
+				SetCloudInit(ci.NewCloudInitConfiguration().SetDrive(tc.drive))
+
+			if err := qemu.ValidateCloudInitDevice(); (err != nil) != tc.expectErr {
+				t.Errorf("ValidateCloudInitDevice() error = %v, expectErr %v", err, tc.expectErr)
+			}
+		})
+	}
+}
@@ -66,17 +66,77 @@ func (cli *CommandLineInterface) buildCommandsList() error {
 	identifier := configuration.GetIdentifier()
 
 	cli.addCommand(command.NewNameCommand(identifier, configuration.GetName()))
-	cli.addCommand(command.NewResourcesCommand(identifier, configuration.GetCores(), configuration.GetMemory(), configuration.GetCpuType()))
+
+	if configuration.GetDescription() != "" {
+		cli.addCommand(command.NewDescriptionCommand(identifier, configuration.GetDescription()))
+	}
+
+	if configuration.IsArm() {
+		cli.addCommand(command.NewArchitectureCommand(identifier, configuration.GetArchitecture()))
+		cli.addCommand(command.NewEfiDiskCommand(identifier, configuration.GetStorage()))
+	}
+
+	cli.addCommand(command.NewResourcesCommand(identifier, configuration.GetCores(), configuration.GetMemory(), configuration.GetCpuDefinition()))
+
+	if configuration.HasCpuTopology() {
+		cli.addCommand(command.NewCpuTopologyCommand(
+			identifier,
+			configuration.GetSockets(),
+			configuration.GetVCpus(),
+			configuration.GetNuma(),
+			configuration.GetCpuLimit(),
+			configuration.GetCpuUnits(),
+			configuration.GetAffinity(),
+		))
+	}
+
+	if configuration.HasMemoryOptions() {
+		cli.addCommand(command.NewMemoryOptionsCommand(
+			identifier,
+			configuration.GetBallooning(),
+			configuration.GetMinimumMemory(),
+			configuration.GetHugepages(),
+		))
+	}
+
+	if len(configuration.GetHotplug()) > 0 {
+		cli.addCommand(command.NewHotplugCommand(identifier, configuration.GetHotplug()))
+	}
+
 	cli.addCommand(command.NewGraphicsCommand(identifier))
+
+	if configuration.HasRng() {
+		cli.addCommand(command.NewRngCommand(
+			identifier,
+			configuration.GetRngSource(),
+			configuration.GetRngMaxBytes(),
+			configuration.GetRngPeriod(),
+		))
+	}
+
+	if configuration.HasWatchdog() {
+		cli.addCommand(command.NewWatchdogCommand(identifier, configuration.GetWatchdogModel(), configuration.GetWatchdogAction()))
+	}
+
 	cli.addCommand(command.NewNetworkCommand(identifier, configuration.GetNetworkDriver(), configuration.GetNetworkBridge()))
 	cli.addCommand(command.NewMainStorageCommand(identifier, configuration.GetStorage(), configuration.GetImage()))
 	cli.addCommand(command.NewBootOrderCommand(identifier, "scsi0", "virtio-scsi-single"))
-	cli.addCommand(command.NewGuestAgentCommand(identifier, true, true))
+	cli.addCommand(command.NewGuestAgentOptionsCommand(
+		identifier,
+		configuration.GetGuestAgentEnabled(),
+		configuration.GetGuestAgentTrim(),
+		configuration.GetGuestAgentFreezeFs(),
+		configuration.GetGuestAgentType(),
+	))
 
 	cloudInit := configuration.GetCloudInit()
 
 	if cloudInit != nil {
-		cli.addCommand(command.NewCloudStorageCommand(identifier, configuration.GetStorage()))
+		if err := configuration.ValidateCloudInitDevice(); err != nil {
+			return err
+		}
+
+		cli.addCommand(command.NewCloudStorageDeviceCommand(identifier, configuration.GetCloudInitDevice(), configuration.GetStorage()))
 
 		username := cloudInit.GetUsername()
 		if username != "" {
@@ -101,7 +161,34 @@ func (cli *CommandLineInterface) buildCommandsList() error {
 			cli.addCommand(command.NewCloudKeysCommand(identifier, cloudInit))
 		}
 
-		cli.addCommand(command.NewNetworkCloudCommand(identifier, cloudInit))
+		if cloudInit.GetIPv4Mode() != ci.AddressModeNone || cloudInit.GetIPv6Mode() != ci.AddressModeNone {
+			cli.addCommand(command.NewNetworkCloudCommand(identifier, cloudInit))
+		}
+
+		if cloudInit.HasDns() {
+			cli.addCommand(command.NewDnsCloudCommand(identifier, cloudInit))
+		}
+
+		if cicustom := cloudInit.GetCicustom(); cicustom != "" {
+			cli.addCommand(command.NewCloudCustomCommand(identifier, cicustom))
+		}
+
+		if cloudInit.GetDriveType() != "" {
+			cli.addCommand(command.NewCloudTypeCommand(identifier, cloudInit.GetDriveType()))
+		}
+
+		// NOTE: `ciupgrade` is only passed when disabled, as Proxmox VE versions before 8.0 do not know the option and upgrade by default.
+		if !cloudInit.GetUpgrade() {
+			cli.addCommand(command.NewCloudUpgradeCommand(identifier, cloudInit.GetUpgrade()))
+		}
+
+		if cloudInit.GetRegenerate() {
+			cli.addCommand(command.NewCloudUpdateCommand(identifier))
+		}
+	}
+
+	if configuration.GetHookscript() != "" {
+		cli.addCommand(command.NewHookscriptCommand(identifier, configuration.GetHookscript()))
 	}
 
 	if configuration.IsResizingRequired() {
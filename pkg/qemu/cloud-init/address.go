@@ -0,0 +1,357 @@
+package cloud_init
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/utils"
+	"net/netip"
+	"strings"
+)
+
+const (
+	// AddressModeStatic is the address mode for a manually configured address and gateway.
+	AddressModeStatic = "static"
+	// AddressModeDhcp is the address mode for an IPv4 address obtained through DHCP.
+	AddressModeDhcp = "dhcp"
+	// AddressModeDhcpv6 is the address mode for an IPv6 address obtained through DHCPv6.
+	AddressModeDhcpv6 = "dhcpv6"
+	// AddressModeSlaac is the address mode for an IPv6 address obtained through stateless address autoconfiguration.
+	AddressModeSlaac = "slaac"
+	// AddressModeNone is the address mode which leaves the address family unconfigured.
+	AddressModeNone = "none"
+)
+
+var (
+	// ipv4AddressModes is the list of supported IPv4 address modes.
+	ipv4AddressModes = []string{
+		AddressModeStatic,
+		AddressModeDhcp,
+		AddressModeNone,
+	}
+	// ipv6AddressModes is the list of supported IPv6 address modes.
+	ipv6AddressModes = []string{
+		AddressModeStatic,
+		AddressModeDhcpv6,
+		AddressModeSlaac,
+		AddressModeNone,
+	}
+)
+
+// GetIPv4AddressModes returns the list of supported IPv4 address modes.
+func GetIPv4AddressModes() []string {
+	return ipv4AddressModes
+}
+
+// GetIPv6AddressModes returns the list of supported IPv6 address modes.
+func GetIPv6AddressModes() []string {
+	return ipv6AddressModes
+}
+
+// GetIPv4Mode returns the IPv4 address mode (static / dhcp / none).
+func (cloudInit *CloudInit) GetIPv4Mode() string {
+	switch cloudInit.ipv4 {
+	case "dhcp":
+		return AddressModeDhcp
+	case "":
+		return AddressModeNone
+	default:
+		return AddressModeStatic
+	}
+}
+
+// SetIPv4Mode sets the IPv4 address mode (the address and gateway are kept only for the static mode).
+func (cloudInit *CloudInit) SetIPv4Mode(mode string) *CloudInit {
+	switch mode {
+	case AddressModeDhcp:
+		cloudInit.ipv4 = "dhcp"
+		cloudInit.gateway4 = ""
+	case AddressModeNone:
+		cloudInit.ipv4 = ""
+		cloudInit.gateway4 = ""
+	case AddressModeStatic:
+		if cloudInit.ipv4 == "dhcp" {
+			cloudInit.ipv4 = ""
+		}
+	}
+	return cloudInit
+}
+
+// GetIPv6Mode returns the IPv6 address mode (static / dhcpv6 / slaac / none).
+func (cloudInit *CloudInit) GetIPv6Mode() string {
+	switch cloudInit.ipv6 {
+	case "dhcp":
+		return AddressModeDhcpv6
+	case "auto":
+		return AddressModeSlaac
+	case "":
+		return AddressModeNone
+	default:
+		return AddressModeStatic
+	}
+}
+
+// SetIPv6Mode sets the IPv6 address mode (the address and gateway are kept only for the static mode).
+func (cloudInit *CloudInit) SetIPv6Mode(mode string) *CloudInit {
+	switch mode {
+	case AddressModeDhcpv6:
+		cloudInit.ipv6 = "dhcp"
+		cloudInit.gateway6 = ""
+	case AddressModeSlaac:
+		cloudInit.ipv6 = "auto"
+		cloudInit.gateway6 = ""
+	case AddressModeNone:
+		cloudInit.ipv6 = ""
+		cloudInit.gateway6 = ""
+	case AddressModeStatic:
+		if cloudInit.ipv6 == "dhcp" || cloudInit.ipv6 == "auto" {
+			cloudInit.ipv6 = ""
+		}
+	}
+	return cloudInit
+}
+
+// ConfigureIPv4 resolves the IPv4 address mode, validates the address and gateway and applies them.
+func (cloudInit *CloudInit) ConfigureIPv4(mode string, address string, gateway string, autoConfigure bool) error {
+	resolvedMode, err := ResolveIPv4Mode(mode, address, autoConfigure)
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateIPv4Configuration(resolvedMode, address, gateway); err != nil {
+		return err
+	}
+
+	if resolvedMode == AddressModeStatic {
+		cloudInit.SetIPv4(address).SetIPv4Gateway(gateway)
+		return nil
+	}
+
+	cloudInit.SetIPv4Mode(resolvedMode)
+	return nil
+}
+
+// ConfigureIPv6 resolves the IPv6 address mode, validates the address and gateway and applies them.
+func (cloudInit *CloudInit) ConfigureIPv6(mode string, address string, gateway string, autoConfigure bool) error {
+	resolvedMode, err := ResolveIPv6Mode(mode, address, autoConfigure)
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateIPv6Configuration(resolvedMode, address, gateway); err != nil {
+		return err
+	}
+
+	if resolvedMode == AddressModeStatic {
+		cloudInit.SetIPv6(address).SetIPv6Gateway(gateway)
+		return nil
+	}
+
+	cloudInit.SetIPv6Mode(resolvedMode)
+	return nil
+}
+
+// ResolveIPv4Mode resolves the IPv4 address mode from the explicit mode, the address and the auto configuration flag.
+// An explicit mode always wins, an address implies the static mode, otherwise auto configuration selects DHCP.
+func ResolveIPv4Mode(mode string, address string, autoConfigure bool) (string, error) {
+	return resolveAddressMode("IPv4", ipv4AddressModes, normalizeIPv4Mode(mode), address, autoConfigure, AddressModeDhcp)
+}
+
+// ResolveIPv6Mode resolves the IPv6 address mode from the explicit mode, the address and the auto configuration flag.
+// An explicit mode always wins, an address implies the static mode, otherwise auto configuration selects SLAAC.
+func ResolveIPv6Mode(mode string, address string, autoConfigure bool) (string, error) {
+	return resolveAddressMode("IPv6", ipv6AddressModes, normalizeIPv6Mode(mode), address, autoConfigure, AddressModeSlaac)
+}
+
+// ValidateIPv4Configuration validates the IPv4 address and gateway for the given address mode.
+func ValidateIPv4Configuration(mode string, address string, gateway string) error {
+	if mode != AddressModeStatic {
+		if gateway != "" {
+			return fmt.Errorf("cloud-init IPv4 gateway `%s` can only be set when IPv4 mode is `%s`", gateway, AddressModeStatic)
+		}
+		return nil
+	}
+
+	if address == "" {
+		return fmt.Errorf("cloud-init configuration requires IPv4 address when IPv4 mode is `%s`", AddressModeStatic)
+	}
+
+	if gateway == "" {
+		return fmt.Errorf("cloud-init configuration requires IPv4 gateway when IPv4 mode is `%s`", AddressModeStatic)
+	}
+
+	prefix, err := ValidateIPv4Address(address)
+	if err != nil {
+		return err
+	}
+
+	return ValidateIPv4Gateway(prefix, gateway)
+}
+
+// ValidateIPv6Configuration validates the IPv6 address and gateway for the given address mode.
+func ValidateIPv6Configuration(mode string, address string, gateway string) error {
+	if mode != AddressModeStatic {
+		if gateway != "" {
+			return fmt.Errorf("cloud-init IPv6 gateway `%s` can only be set when IPv6 mode is `%s`", gateway, AddressModeStatic)
+		}
+		return nil
+	}
+
+	if address == "" {
+		return fmt.Errorf("cloud-init configuration requires IPv6 address when IPv6 mode is `%s`", AddressModeStatic)
+	}
+
+	if gateway == "" {
+		return fmt.Errorf("cloud-init configuration requires IPv6 gateway when IPv6 mode is `%s`", AddressModeStatic)
+	}
+
+	prefix, err := ValidateIPv6Address(address)
+	if err != nil {
+		return err
+	}
+
+	return ValidateIPv6Gateway(prefix, gateway)
+}
+
+// ValidateIPv4Address validates the IPv4 address in CIDR notation and rejects the network and broadcast addresses of the subnet.
+func ValidateIPv4Address(address string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(address)
+	if err != nil || !prefix.Addr().Is4() {
+		return netip.Prefix{}, fmt.Errorf("cloud-init IPv4 address `%s` is not valid, expected address with prefix length (for example, 10.0.0.10/24)", address)
+	}
+
+	if prefix.Bits() <= 30 {
+		if prefix.Addr() == prefix.Masked().Addr() {
+			return netip.Prefix{}, fmt.Errorf("cloud-init IPv4 address `%s` is the network address of the `%s` subnet", address, prefix.Masked())
+		}
+
+		if prefix.Addr() == getBroadcastAddress(prefix) {
+			return netip.Prefix{}, fmt.Errorf("cloud-init IPv4 address `%s` is the broadcast address of the `%s` subnet", address, prefix.Masked())
+		}
+	}
+
+	return prefix, nil
+}
+
+// ValidateIPv4Gateway validates that the IPv4 gateway is a usable address inside the subnet of the address.
+func ValidateIPv4Gateway(prefix netip.Prefix, gateway string) error {
+	gatewayAddress, err := netip.ParseAddr(gateway)
+	if err != nil || !gatewayAddress.Is4() {
+		return fmt.Errorf("cloud-init IPv4 gateway `%s` is not a valid IPv4 address", gateway)
+	}
+
+	if !prefix.Masked().Contains(gatewayAddress) {
+		return fmt.Errorf("cloud-init IPv4 gateway `%s` is not in the `%s` subnet", gateway, prefix.Masked())
+	}
+
+	if gatewayAddress == prefix.Addr() {
+		return fmt.Errorf("cloud-init IPv4 gateway `%s` is the same as the IPv4 address", gateway)
+	}
+
+	if prefix.Bits() <= 30 && (gatewayAddress == prefix.Masked().Addr() || gatewayAddress == getBroadcastAddress(prefix)) {
+		return fmt.Errorf("cloud-init IPv4 gateway `%s` is the network or broadcast address of the `%s` subnet", gateway, prefix.Masked())
+	}
+
+	return nil
+}
+
+// ValidateIPv6Address validates the IPv6 address in CIDR notation and rejects the subnet-router anycast (network) address of the subnet.
+func ValidateIPv6Address(address string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(address)
+	if err != nil || !prefix.Addr().Is6() || prefix.Addr().Is4In6() {
+		return netip.Prefix{}, fmt.Errorf("cloud-init IPv6 address `%s` is not valid, expected address with prefix length (for example, 2001:db8::10/64)", address)
+	}
+
+	if prefix.Bits() <= 126 && prefix.Addr() == prefix.Masked().Addr() {
+		return netip.Prefix{}, fmt.Errorf("cloud-init IPv6 address `%s` is the network address of the `%s` subnet", address, prefix.Masked())
+	}
+
+	return prefix, nil
+}
+
+// ValidateIPv6Gateway validates that the IPv6 gateway is a usable address inside the subnet of the address or a link-local address.
+func ValidateIPv6Gateway(prefix netip.Prefix, gateway string) error {
+	gatewayAddress, err := netip.ParseAddr(gateway)
+	if err != nil || !gatewayAddress.Is6() || gatewayAddress.Is4In6() {
+		return fmt.Errorf("cloud-init IPv6 gateway `%s` is not a valid IPv6 address", gateway)
+	}
+
+	if gatewayAddress.WithZone("") == prefix.Addr() {
+		return fmt.Errorf("cloud-init IPv6 gateway `%s` is the same as the IPv6 address", gateway)
+	}
+
+	if gatewayAddress.IsLinkLocalUnicast() && !prefix.Addr().IsLinkLocalUnicast() {
+		return nil
+	}
+
+	if !prefix.Masked().Contains(gatewayAddress.WithZone("")) {
+		return fmt.Errorf("cloud-init IPv6 gateway `%s` is not in the `%s` subnet (or a link-local address)", gateway, prefix.Masked())
+	}
+
+	if prefix.Bits() <= 126 && gatewayAddress.WithZone("") == prefix.Masked().Addr() {
+		return fmt.Errorf("cloud-init IPv6 gateway `%s` is the network address of the `%s` subnet", gateway, prefix.Masked())
+	}
+
+	return nil
+}
+
+// resolveAddressMode resolves the address mode of the address family.
+func resolveAddressMode(family string, modes []string, mode string, address string, autoConfigure bool, autoMode string) (string, error) {
+	if mode == "" {
+		switch {
+		case address != "":
+			return AddressModeStatic, nil
+		case autoConfigure:
+			return autoMode, nil
+		default:
+			return AddressModeNone, nil
+		}
+	}
+
+	if !utils.SliceContains(modes, mode) {
+		return "", fmt.Errorf("cloud-init %s mode `%s` is not supported, supported modes: %s", family, mode, strings.Join(modes, ", "))
+	}
+
+	if mode != AddressModeStatic && address != "" {
+		return "", fmt.Errorf("cloud-init %s address `%s` can only be set when %s mode is `%s`, not `%s`", family, address, family, AddressModeStatic, mode)
+	}
+
+	return mode, nil
+}
+
+// normalizeIPv4Mode normalizes the IPv4 address mode (accepts the `auto` alias used by Proxmox VE for DHCP).
+func normalizeIPv4Mode(mode string) string {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "auto" {
+		return AddressModeDhcp
+	}
+	return mode
+}
+
+// normalizeIPv6Mode normalizes the IPv6 address mode (accepts the `auto` / `dhcp` values used by Proxmox VE).
+func normalizeIPv6Mode(mode string) string {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	switch mode {
+	case "auto":
+		return AddressModeSlaac
+	case "dhcp":
+		return AddressModeDhcpv6
+	default:
+		return mode
+	}
+}
+
+// getBroadcastAddress returns the broadcast (last) address of the IPv4 subnet.
+func getBroadcastAddress(prefix netip.Prefix) netip.Addr {
+	address := prefix.Masked().Addr().As4()
+	hostBits := 32 - prefix.Bits()
+
+	for index := 3; index >= 0 && hostBits > 0; index-- {
+		bits := hostBits
+		if bits > 8 {
+			bits = 8
+		}
+		address[index] |= byte(1<<bits - 1)
+		hostBits -= bits
+	}
+
+	return netip.AddrFrom4(address)
+}
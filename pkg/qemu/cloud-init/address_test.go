@@ -0,0 +1,223 @@
+package cloud_init
+
+import (
+	"net/netip"
+	"testing"
+)
+
+// TestGetAndSetIPv4Mode tests the GetIPv4Mode and SetIPv4Mode functions.
+func TestGetAndSetIPv4Mode(t *testing.T) {
+	cloudInit := NewCloudInitConfiguration()
+
+	if cloudInit.GetIPv4Mode() != AddressModeDhcp {
+		t.Errorf("GetIPv4Mode returned %s, want %s", cloudInit.GetIPv4Mode(), AddressModeDhcp)
+	}
+
+	cloudInit.SetIPv4("10.0.0.10/24").SetIPv4Gateway("10.0.0.1")
+	if cloudInit.GetIPv4Mode() != AddressModeStatic {
+		t.Errorf("GetIPv4Mode returned %s, want %s", cloudInit.GetIPv4Mode(), AddressModeStatic)
+	}
+
+	cloudInit.SetIPv4Mode(AddressModeNone)
+	if cloudInit.GetIPv4Mode() != AddressModeNone || cloudInit.GetIPv4() != "" || cloudInit.GetIPv4Gateway() != "" {
+		t.Errorf("SetIPv4Mode(%s) did not clear the address and gateway", AddressModeNone)
+	}
+
+	cloudInit.SetIPv4Mode(AddressModeDhcp)
+	if cloudInit.GetIPv4() != "dhcp" {
+		t.Errorf("GetIPv4 returned %s, want %s", cloudInit.GetIPv4(), "dhcp")
+	}
+}
+
+// TestGetAndSetIPv6Mode tests the GetIPv6Mode and SetIPv6Mode functions.
+func TestGetAndSetIPv6Mode(t *testing.T) {
+	tests := []struct {
+		mode     string
+		expected string
+	}{
+		{AddressModeSlaac, "auto"},
+		{AddressModeDhcpv6, "dhcp"},
+		{AddressModeNone, ""},
+	}
+
+	for _, test := range tests {
+		cloudInit := NewCloudInitConfiguration().SetIPv6("2001:db8::10/64").SetIPv6Gateway("2001:db8::1")
+		cloudInit.SetIPv6Mode(test.mode)
+
+		if cloudInit.GetIPv6() != test.expected || cloudInit.GetIPv6Gateway() != "" {
+			t.Errorf("SetIPv6Mode(%s) set address %q and gateway %q, want %q and empty gateway", test.mode, cloudInit.GetIPv6(), cloudInit.GetIPv6Gateway(), test.expected)
+		}
+
+		if cloudInit.GetIPv6Mode() != test.mode {
+			t.Errorf("GetIPv6Mode returned %s, want %s", cloudInit.GetIPv6Mode(), test.mode)
+		}
+	}
+}
+
+// TestResolveIPv4Mode tests the ResolveIPv4Mode function.
+func TestResolveIPv4Mode(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          string
+		address       string
+		autoConfigure bool
+		expected      string
+		expectErr     bool
+	}{
+		{"Auto Configure", "", "", true, AddressModeDhcp, false},
+		{"Address Wins Over Auto Configure", "", "10.0.0.10/24", true, AddressModeStatic, false},
+		{"Nothing Configured", "", "", false, AddressModeNone, false},
+		{"Explicit Mode Wins Over Auto Configure", "none", "", true, AddressModeNone, false},
+		{"Explicit Static", "STATIC", "10.0.0.10/24", false, AddressModeStatic, false},
+		{"Auto Alias", "auto", "", false, AddressModeDhcp, false},
+		{"Address With DHCP", "dhcp", "10.0.0.10/24", true, "", true},
+		{"IPv6 Only Mode", "slaac", "", false, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mode, err := ResolveIPv4Mode(test.mode, test.address, test.autoConfigure)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("ResolveIPv4Mode() error = %v, expectErr %v", err, test.expectErr)
+			}
+
+			if mode != test.expected {
+				t.Errorf("ResolveIPv4Mode() = %s, want %s", mode, test.expected)
+			}
+		})
+	}
+}
+
+// TestResolveIPv6Mode tests the ResolveIPv6Mode function.
+func TestResolveIPv6Mode(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          string
+		address       string
+		autoConfigure bool
+		expected      string
+		expectErr     bool
+	}{
+		{"Auto Configure", "", "", true, AddressModeSlaac, false},
+		{"Address Wins Over Auto Configure", "", "2001:db8::10/64", true, AddressModeStatic, false},
+		{"DHCPv6", "dhcpv6", "", false, AddressModeDhcpv6, false},
+		{"DHCP Alias", "dhcp", "", false, AddressModeDhcpv6, false},
+		{"Auto Alias", "auto", "", false, AddressModeSlaac, false},
+		{"Address With SLAAC", "slaac", "2001:db8::10/64", false, "", true},
+		{"Unknown Mode", "eui64", "", false, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mode, err := ResolveIPv6Mode(test.mode, test.address, test.autoConfigure)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("ResolveIPv6Mode() error = %v, expectErr %v", err, test.expectErr)
+			}
+
+			if mode != test.expected {
+				t.Errorf("ResolveIPv6Mode() = %s, want %s", mode, test.expected)
+			}
+		})
+	}
+}
+
+// TestValidateIPv4Configuration tests the ValidateIPv4Configuration function.
+func TestValidateIPv4Configuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      string
+		address   string
+		gateway   string
+		expectErr bool
+	}{
+		{"Valid Static", AddressModeStatic, "10.0.0.10/24", "10.0.0.1", false},
+		{"Valid Point To Point", AddressModeStatic, "10.0.0.0/31", "10.0.0.1", false},
+		{"DHCP", AddressModeDhcp, "", "", false},
+		{"Gateway With DHCP", AddressModeDhcp, "", "10.0.0.1", true},
+		{"Missing Address", AddressModeStatic, "", "10.0.0.1", true},
+		{"Missing Gateway", AddressModeStatic, "10.0.0.10/24", "", true},
+		{"Missing Prefix", AddressModeStatic, "10.0.0.10", "10.0.0.1", true},
+		{"IPv6 Address", AddressModeStatic, "2001:db8::10/64", "10.0.0.1", true},
+		{"Network Address", AddressModeStatic, "10.0.0.0/24", "10.0.0.1", true},
+		{"Broadcast Address", AddressModeStatic, "10.0.0.255/24", "10.0.0.1", true},
+		{"Gateway Outside Subnet", AddressModeStatic, "10.0.0.10/24", "10.0.1.1", true},
+		{"Gateway Is Address", AddressModeStatic, "10.0.0.10/24", "10.0.0.10", true},
+		{"Gateway Is Broadcast", AddressModeStatic, "10.0.0.10/24", "10.0.0.255", true},
+		{"Gateway Is IPv6", AddressModeStatic, "10.0.0.10/24", "2001:db8::1", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := ValidateIPv4Configuration(test.mode, test.address, test.gateway); (err != nil) != test.expectErr {
+				t.Errorf("ValidateIPv4Configuration() error = %v, expectErr %v", err, test.expectErr)
+			}
+		})
+	}
+}
+
+// TestValidateIPv6Configuration tests the ValidateIPv6Configuration function.
+func TestValidateIPv6Configuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      string
+		address   string
+		gateway   string
+		expectErr bool
+	}{
+		{"Valid Static", AddressModeStatic, "2001:db8::10/64", "2001:db8::1", false},
+		{"Link-Local Gateway", AddressModeStatic, "2001:db8::10/64", "fe80::1", false},
+		{"SLAAC", AddressModeSlaac, "", "", false},
+		{"Gateway With DHCPv6", AddressModeDhcpv6, "", "2001:db8::1", true},
+		{"Missing Gateway", AddressModeStatic, "2001:db8::10/64", "", true},
+		{"IPv4 Address", AddressModeStatic, "10.0.0.10/24", "2001:db8::1", true},
+		{"Network Address", AddressModeStatic, "2001:db8::/64", "2001:db8::1", true},
+		{"Gateway Outside Subnet", AddressModeStatic, "2001:db8::10/64", "2001:db9::1", true},
+		{"Gateway Is Address", AddressModeStatic, "2001:db8::10/64", "2001:db8::10", true},
+		{"Gateway Is Network Address", AddressModeStatic, "2001:db8::10/64", "2001:db8::", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := ValidateIPv6Configuration(test.mode, test.address, test.gateway); (err != nil) != test.expectErr {
+				t.Errorf("ValidateIPv6Configuration() error = %v, expectErr %v", err, test.expectErr)
+			}
+		})
+	}
+}
+
+// TestConfigureIPv4 tests the ConfigureIPv4 function.
+func TestConfigureIPv4(t *testing.T) {
+	cloudInit := NewCloudInitConfiguration()
+
+	if err := cloudInit.ConfigureIPv4("", "10.0.0.10/24", "10.0.0.1", true); err != nil {
+		t.Fatalf("ConfigureIPv4 returned error: %v", err)
+	}
+
+	if cloudInit.GetIPv4() != "10.0.0.10/24" || cloudInit.GetIPv4Gateway() != "10.0.0.1" {
+		t.Errorf("ConfigureIPv4 set %s / %s, want %s / %s", cloudInit.GetIPv4(), cloudInit.GetIPv4Gateway(), "10.0.0.10/24", "10.0.0.1")
+	}
+
+	if err := cloudInit.ConfigureIPv4("static", "10.0.0.0/24", "10.0.0.1", false); err == nil {
+		t.Errorf("ConfigureIPv4 expected error for network address")
+	}
+
+	if err := cloudInit.ConfigureIPv6("dhcpv6", "", "", true); err != nil || cloudInit.GetIPv6() != "dhcp" {
+		t.Errorf("ConfigureIPv6 returned error %v and address %s, want nil and %s", err, cloudInit.GetIPv6(), "dhcp")
+	}
+}
+
+// TestGetBroadcastAddress tests the getBroadcastAddress function.
+func TestGetBroadcastAddress(t *testing.T) {
+	tests := map[string]string{
+		"10.0.0.10/24":  "10.0.0.255",
+		"10.0.0.10/22":  "10.0.3.255",
+		"172.16.5.4/12": "172.31.255.255",
+		"10.0.0.10/30":  "10.0.0.11",
+	}
+
+	for address, expected := range tests {
+		if result := getBroadcastAddress(netip.MustParsePrefix(address)); result.String() != expected {
+			t.Errorf("getBroadcastAddress(%s) = %s, want %s", address, result, expected)
+		}
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"github.com/darki73/ptm/pkg/utils"
 	"log"
 	"os"
-	"regexp"
 	"strings"
 )
 
@@ -22,10 +21,12 @@ const (
 type CloudInit struct {
 	// username is the username to use for the cloud-init configuration.
 	username string
-	// password is the password to use for the cloud-init configuration.
+	// password is the SHA-512 crypt hash of the password to use for the cloud-init configuration.
 	password string
 	// keys is a list of SSH keys to use for the cloud-init configuration.
 	keys []string
+	// users is the list of additional users to create (rendered into custom vendor-data).
+	users []*User
 	// ipv4 is the IPv4 address to use for the cloud-init configuration.
 	ipv4 string
 	// ipv6 is the IPv6 address to use for the cloud-init configuration.
@@ -34,6 +35,24 @@ type CloudInit struct {
 	gateway4 string
 	// gateway6 is the IPv6 gateway to use for the cloud-init configuration.
 	gateway6 string
+	// nameservers is the list of DNS servers to use for the cloud-init configuration.
+	nameservers []string
+	// searchDomains is the list of DNS search domains to use for the cloud-init configuration.
+	searchDomains []string
+	// customSnippets is the map of custom snippet types (user / network / meta / vendor) to their inline content or file path.
+	customSnippets map[string]string
+	// customVolumes is the map of custom snippet types to the volume identifiers of the uploaded snippets.
+	customVolumes map[string]string
+	// snippetsStorage is the name of the storage custom snippets are uploaded to.
+	snippetsStorage string
+	// driveType is the cloud-init drive type (nocloud / configdrive2).
+	driveType string
+	// upgrade is the flag that makes cloud-init upgrade packages on the first boot.
+	upgrade bool
+	// drive is the bus and slot the cloud-init drive is attached to.
+	drive string
+	// regenerate is the flag that regenerates the cloud-init drive after changes.
+	regenerate bool
 	// sshKeysTemporaryFilePath is the temporary file path for the SSH keys.
 	sshKeysTemporaryFilePath string
 	// configurationSource is the source of the configuration.
@@ -46,10 +65,20 @@ func NewCloudInitConfiguration() *CloudInit {
 		username:                 "",
 		password:                 "",
 		keys:                     []string{},
+		users:                    []*User{},
 		ipv4:                     "dhcp",
 		ipv6:                     "auto",
 		gateway4:                 "",
 		gateway6:                 "",
+		nameservers:              []string{},
+		searchDomains:            []string{},
+		customSnippets:           map[string]string{},
+		customVolumes:            map[string]string{},
+		snippetsStorage:          "",
+		driveType:                "",
+		upgrade:                  true,
+		drive:                    "",
+		regenerate:               true,
 		sshKeysTemporaryFilePath: "/tmp/ptm-ssh-keys",
 		configurationSource:      ConfigurationSourcePrompt,
 	}
@@ -66,15 +95,26 @@ func (cloudInit *CloudInit) SetUsername(username string) *CloudInit {
 	return cloudInit
 }
 
-// GetPassword returns the password to use for the cloud-init configuration.
+// GetPassword returns the SHA-512 crypt hash of the password to use for the cloud-init configuration.
 func (cloudInit *CloudInit) GetPassword() string {
 	return cloudInit.password
 }
 
-// SetPassword sets the password to use for the cloud-init configuration.
-func (cloudInit *CloudInit) SetPassword(password string) *CloudInit {
-	cloudInit.password = password
-	return cloudInit
+// SetPassword sets the password to use for the cloud-init configuration (plain text passwords are hashed with SHA-512 crypt).
+// The password is left unchanged if it cannot be hashed, so the template is never created without the requested password.
+func (cloudInit *CloudInit) SetPassword(password string) error {
+	if password == "" || utils.IsSha512CryptHash(password) {
+		cloudInit.password = password
+		return nil
+	}
+
+	hash, err := utils.HashPasswordSha512(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash cloud-init password: %v", err)
+	}
+
+	cloudInit.password = hash
+	return nil
 }
 
 // GetKeys returns the list of SSH keys to use for the cloud-init configuration.
@@ -87,61 +127,53 @@ func (cloudInit *CloudInit) HasKeys() bool {
 	return len(cloudInit.keys) > 0
 }
 
-// IsValidSshKey checks if a given SSH key string is valid.
+// IsValidSshKey checks if a given SSH key string is a valid key of an allowed algorithm and size.
 func (cloudInit *CloudInit) IsValidSshKey(key string) bool {
-	var patterns = []string{
-		`^ssh-rsa [A-Za-z0-9+\/]{100,}(={0,2})?( .+)?$`,
-		`^ssh-dss [A-Za-z0-9+\/]{100,}(={0,2})?( .+)?$`,
-		`^ecdsa-sha2-nistp256 [A-Za-z0-9+\/]{60,}(={0,2})?( .+)?$`,
-		`^ecdsa-sha2-nistp384 [A-Za-z0-9+\/]{80,}(={0,2})?( .+)?$`,
-		`^ecdsa-sha2-nistp521 [A-Za-z0-9+\/]{100,}(={0,2})?( .+)?$`,
-		`^ssh-ed25519 [A-Za-z0-9+\/]{30,}(={0,2})?( .+)?$`,
-	}
-
-	for _, pattern := range patterns {
-		re := regexp.MustCompile(pattern)
-		if re.MatchString(key) {
-			return true
-		}
-	}
-
-	return false
+	_, err := utils.ParseSshPublicKey(key)
+	return err == nil
 }
 
-// SetKeys sets the list of SSH keys to use for the cloud-init configuration.
+// SetKeys sets the list of SSH keys to use for the cloud-init configuration (keys or paths to public key / authorized_keys files).
 func (cloudInit *CloudInit) SetKeys(keys []string) *CloudInit {
+	cloudInit.keys = resolveSshKeys(keys)
+	return cloudInit
+}
+
+// resolveSshKeys reads keys from files, drops invalid keys and removes duplicates (by fingerprint).
+func resolveSshKeys(keys []string) []string {
 	var keysSlice []string
+	fingerprints := make(map[string]bool)
 
 	for _, key := range keys {
-		if strings.HasSuffix(key, ".pub") {
-			path, err := utils.ExpandHomeDir(key)
-			if err != nil {
-				log.Println(fmt.Sprintf("Failed to expand home directory: %s, error: %v", key, err))
+		lines := []string{key}
+
+		if content, ok := readSshKeysFile(key); ok {
+			lines = strings.Split(content, "\n")
+		}
+
+		for _, line := range lines {
+			if !utils.IsSshKeyLine(line) {
 				continue
 			}
 
-			fmt.Println(path, key)
-			pubKey, err := os.ReadFile(path)
+			line = strings.TrimSpace(line)
+
+			parsed, err := utils.ParseSshPublicKey(line)
 			if err != nil {
-				if os.IsNotExist(err) {
-					log.Println(fmt.Sprintf("File does not exist: %s", key))
-				} else {
-					log.Println(fmt.Sprintf("Failed to read file: %s, error: %v", key, err))
-				}
+				log.Println(fmt.Sprintf("Invalid SSH key: %v, ignoring", err))
 				continue
 			}
-			key = strings.TrimSpace(string(pubKey))
-		}
 
-		if !cloudInit.IsValidSshKey(key) {
-			log.Println(fmt.Sprintf("Invalid SSH key: %s, ignoring", key))
-			continue
-		}
+			if fingerprints[parsed.GetFingerprint()] {
+				continue
+			}
+			fingerprints[parsed.GetFingerprint()] = true
 
-		keysSlice = append(keysSlice, key)
+			keysSlice = append(keysSlice, line)
+		}
 	}
-	cloudInit.keys = keysSlice
-	return cloudInit
+
+	return keysSlice
 }
 
 // GetIPv4 returns the IPv4 address to use for the cloud-init configuration.
@@ -200,6 +232,33 @@ func (cloudInit *CloudInit) SetIPv6Gateway(gateway6 string) *CloudInit {
 	return cloudInit
 }
 
+// GetNameservers returns the list of DNS servers to use for the cloud-init configuration.
+func (cloudInit *CloudInit) GetNameservers() []string {
+	return cloudInit.nameservers
+}
+
+// SetNameservers sets the list of DNS servers to use for the cloud-init configuration.
+func (cloudInit *CloudInit) SetNameservers(nameservers []string) *CloudInit {
+	cloudInit.nameservers = normalizeList(nameservers)
+	return cloudInit
+}
+
+// GetSearchDomains returns the list of DNS search domains to use for the cloud-init configuration.
+func (cloudInit *CloudInit) GetSearchDomains() []string {
+	return cloudInit.searchDomains
+}
+
+// SetSearchDomains sets the list of DNS search domains to use for the cloud-init configuration.
+func (cloudInit *CloudInit) SetSearchDomains(searchDomains []string) *CloudInit {
+	cloudInit.searchDomains = normalizeList(searchDomains)
+	return cloudInit
+}
+
+// HasDns returns true if the cloud-init configuration has DNS servers or search domains.
+func (cloudInit *CloudInit) HasDns() bool {
+	return len(cloudInit.nameservers) > 0 || len(cloudInit.searchDomains) > 0
+}
+
 // GetSSHKeysTemporaryFilePath returns the temporary file path for the SSH keys.
 func (cloudInit *CloudInit) GetSSHKeysTemporaryFilePath() string {
 	return cloudInit.sshKeysTemporaryFilePath
@@ -213,12 +272,12 @@ func (cloudInit *CloudInit) SetSSHKeysTemporaryFilePath(sshKeysTemporaryFilePath
 
 // IsGateway4ConfigurationRequired returns true if the IPv4 gateway is required for the cloud-init configuration.
 func (cloudInit *CloudInit) IsGateway4ConfigurationRequired() bool {
-	return cloudInit.ipv4 != "dhcp"
+	return cloudInit.GetIPv4Mode() == AddressModeStatic
 }
 
 // IsGateway6ConfigurationRequired returns true if the IPv6 gateway is required for the cloud-init configuration.
 func (cloudInit *CloudInit) IsGateway6ConfigurationRequired() bool {
-	return cloudInit.ipv6 != "auto"
+	return cloudInit.GetIPv6Mode() == AddressModeStatic
 }
 
 // GetConfigurationSource returns the source of the configuration.
@@ -234,26 +293,98 @@ func (cloudInit *CloudInit) SetConfigurationSource(configurationSource string) *
 
 // IsConfigurationValid returns true if the configuration is valid.
 func (cloudInit *CloudInit) IsConfigurationValid() (bool, error) {
-	if cloudInit.IsGateway4ConfigurationRequired() {
-		if cloudInit.gateway4 == "" && cloudInit.ipv4 != "dhcp" {
-			return false, fmt.Errorf("cloud-init configuration requires IPv4 gateway when IPv4 address is not DHCP")
-		}
+	if err := ValidateIPv4Configuration(cloudInit.GetIPv4Mode(), cloudInit.ipv4, cloudInit.gateway4); err != nil {
+		return false, err
+	}
+
+	if err := ValidateIPv6Configuration(cloudInit.GetIPv6Mode(), cloudInit.ipv6, cloudInit.gateway6); err != nil {
+		return false, err
+	}
+
+	if err := ValidateNameservers(cloudInit.nameservers); err != nil {
+		return false, err
+	}
+
+	if err := ValidateSearchDomains(cloudInit.searchDomains); err != nil {
+		return false, err
+	}
+
+	if err := ValidateDriveType(cloudInit.driveType); err != nil {
+		return false, err
+	}
+
+	if err := ValidateDrive(cloudInit.drive); err != nil {
+		return false, err
+	}
+
+	if err := ValidateUsers(cloudInit.username, cloudInit.users); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
 
-		if cloudInit.ipv4 == "" && cloudInit.gateway4 != "" {
-			return false, fmt.Errorf("cloud-init configuration requires IPv4 address when IPv4 gateway is set")
+// ValidateNameservers validates the list of DNS servers (IPv4 and IPv6 addresses).
+func ValidateNameservers(nameservers []string) error {
+	for _, nameserver := range nameservers {
+		if !utils.IsValidIP(nameserver) {
+			return fmt.Errorf("cloud-init nameserver `%s` is not a valid IP address", nameserver)
 		}
+	}
+
+	return nil
+}
 
+// ValidateSearchDomains validates the list of DNS search domains.
+func ValidateSearchDomains(searchDomains []string) error {
+	for _, searchDomain := range searchDomains {
+		if !utils.IsValidDomainName(searchDomain) {
+			return fmt.Errorf("cloud-init search domain `%s` is not a valid domain name", searchDomain)
+		}
 	}
 
-	if cloudInit.IsGateway6ConfigurationRequired() {
-		if cloudInit.gateway6 == "" && cloudInit.ipv6 != "auto" {
-			return false, fmt.Errorf("cloud-init configuration requires IPv6 gateway when IPv6 address is not auto")
+	return nil
+}
+
+// ParseList parses the comma or space separated list of values.
+func ParseList(value string) []string {
+	return normalizeList([]string{value})
+}
+
+// normalizeList splits the list items on commas and spaces and removes empty items.
+func normalizeList(items []string) []string {
+	normalized := make([]string, 0)
+
+	for _, item := range items {
+		for _, value := range strings.FieldsFunc(item, func(r rune) bool {
+			return r == ',' || r == ' '
+		}) {
+			normalized = append(normalized, value)
 		}
+	}
+
+	return normalized
+}
+
+// readSshKeysFile reads the public key or authorized_keys file if the value is a path to one.
+func readSshKeysFile(value string) (string, bool) {
+	if _, err := utils.ParseSshPublicKey(value); err == nil {
+		return "", false
+	}
+
+	path, err := utils.ExpandHomeDir(strings.TrimSpace(value))
+	if err != nil {
+		log.Println(fmt.Sprintf("Failed to expand home directory: %s, error: %v", value, err))
+		return "", false
+	}
 
-		if cloudInit.ipv6 == "" && cloudInit.gateway6 != "" {
-			return false, fmt.Errorf("cloud-init configuration requires IPv6 address when IPv6 gateway is set")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if strings.HasSuffix(value, ".pub") || strings.HasSuffix(value, "authorized_keys") {
+			log.Println(fmt.Sprintf("Failed to read file: %s, error: %v", value, err))
 		}
+		return "", false
 	}
 
-	return true, nil
+	return string(content), true
 }
@@ -1,6 +1,12 @@
 package cloud_init
 
-import "testing"
+import (
+	"github.com/darki73/ptm/pkg/utils"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
 
 // TestNewCloudInitConfiguration tests the NewCloudInitConfiguration function.
 func TestNewCloudInitConfiguration(t *testing.T) {
@@ -33,10 +39,30 @@ func TestSetAndGetPassword(t *testing.T) {
 	cloudInit := NewCloudInitConfiguration()
 	password := "testpassword"
 
-	cloudInit.SetPassword(password)
+	if err := cloudInit.SetPassword(password); err != nil {
+		t.Fatalf("SetPassword returned error: %v", err)
+	}
+
+	if !utils.IsSha512CryptHash(cloudInit.GetPassword()) {
+		t.Errorf("GetPassword returned %v, want SHA-512 crypt hash", cloudInit.GetPassword())
+	}
+
+	if !utils.VerifySha512Crypt(password, cloudInit.GetPassword()) {
+		t.Errorf("GetPassword returned hash that does not match the password")
+	}
+}
+
+// TestSetPasswordWithHash tests the SetPassword function with pre-hashed and empty values.
+func TestSetPasswordWithHash(t *testing.T) {
+	cloudInit := NewCloudInitConfiguration()
+	hash := "$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1"
+
+	if err := cloudInit.SetPassword(hash); err != nil || cloudInit.GetPassword() != hash {
+		t.Errorf("GetPassword returned %v, want %v", cloudInit.GetPassword(), hash)
+	}
 
-	if cloudInit.GetPassword() != password {
-		t.Errorf("GetPassword returned %v, want %v", cloudInit.GetPassword(), password)
+	if err := cloudInit.SetPassword(""); err != nil || cloudInit.GetPassword() != "" {
+		t.Errorf("GetPassword returned %v, want empty string", cloudInit.GetPassword())
 	}
 }
 
@@ -51,8 +77,9 @@ func TestIsValidSshKey(t *testing.T) {
 		expected bool
 	}{
 		// Valid cases
-		{"Valid RSA", "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQCYID8wzSmmNYIyweQLk4TCJCGCCDAza+wl3PxpKslPV7LdZwdpfxo0Q2vlXsv4SN5wmJXJwwwE6ekGgMUFP4TtsGuyzXKxa1+jnBzc1+e/BtJ1AFdVAGzlUcqbZeHlnXfTlF+pnDrnXqG1jdJDsJZcgrZOK6uUQKMfbU/KoZM56w== user@example.com", true},
-		{"Valid DSA", "ssh-dss AAAAB3NzaC1kc3MAAABBAPymgs6OEsq6Ju/M9xEOUm2weLBe3svNHrSiCPOuFheuAfNbkaR+bfY0E8XhLtCJm80TKs1Q2ZFRvcQ+5zdZLhcAAAAVAJYu3cw2nLqOuyYO5rahJtk0bjjFAAAAQGeEcbJ6nPRO6RpJxRR9samq8kTwWkNNZIaTHS0UJxueNQMLcf1z2heQabMuKTVjDhwgYjVNDaIKbEFuUL55TKQAAABBAN+ERyjUsXa0aO4tjZT+6HlAzW99K8yGEqp7rMGDz70t1+snD7/epOWxGCfasVnRn9qvPXR8clpOOnDinlBzuT4= user@example.com", true},
+		{"Valid RSA", "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCgj57b92l6AnH7aOvq/+6JbGl6JrqjYVCpXUEg7eIqNpU18oCLkn8XN5rCFYmvVTc8yP8DnzlpduN013HGP4Ghqf+33S//PXq97u0LzFLdUiHqZr55/ZysgcjBLDrs71xWun6E3cT8MvO5S/Yh5BTqsuP0mjIqoE4fw//g8pmuMx6u6CwiGDx3KPOpFFAzU74/2pbVzfaouUoCsc5rPdQJqS6YO7hhbFNVgquM1i2l4IFjsgYUkiF1vnQlwqntLt7jBNrfc5T3lhzX47rlcK7AFkrnqjxY6oGUfCoBidPmDkM8A0HvJC5U/oZ0GQourqhx5e1tpWKVzhifueFYDYVB user@example.com", true},
+		{"Weak RSA (1024 bits)", "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQCYID8wzSmmNYIyweQLk4TCJCGCCDAza+wl3PxpKslPV7LdZwdpfxo0Q2vlXsv4SN5wmJXJwwwE6ekGgMUFP4TtsGuyzXKxa1+jnBzc1+e/BtJ1AFdVAGzlUcqbZeHlnXfTlF+pnDrnXqG1jdJDsJZcgrZOK6uUQKMfbU/KoZM56w== user@example.com", false},
+		{"Weak DSA", "ssh-dss AAAAB3NzaC1kc3MAAABBAPymgs6OEsq6Ju/M9xEOUm2weLBe3svNHrSiCPOuFheuAfNbkaR+bfY0E8XhLtCJm80TKs1Q2ZFRvcQ+5zdZLhcAAAAVAJYu3cw2nLqOuyYO5rahJtk0bjjFAAAAQGeEcbJ6nPRO6RpJxRR9samq8kTwWkNNZIaTHS0UJxueNQMLcf1z2heQabMuKTVjDhwgYjVNDaIKbEFuUL55TKQAAABBAN+ERyjUsXa0aO4tjZT+6HlAzW99K8yGEqp7rMGDz70t1+snD7/epOWxGCfasVnRn9qvPXR8clpOOnDinlBzuT4= user@example.com", false},
 		{"Valid ECDSA 256", "ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBGkgZSuqj4Y0iWQP3YJCwR7gBMubTQsBewNMscVxYVJ3EA5rUsOWFDJ1J7MSDf9Iz6isc6YFxclvzCWi1/YE74o= user@example.com", true},
 		{"Valid ECDSA 384", "ecdsa-sha2-nistp384 AAAAE2VjZHNhLXNoYTItbmlzdHAzODQAAAAIbmlzdHAzODQAAABhBGtpp+CYbXFW6HpY0eBJmOqD2bpX9WGZv3yAZ4No9JA616xZ4H5fAtcwxNgrBDrlCk1ajCnjEnNGflSpqsKaki6GJldgAqws9UuXY4KNPmfictdi+YCuv8VQRBaa4VfTsQ== user@example.com", true},
 		{"Valid ECDSA 521", "ecdsa-sha2-nistp521 AAAAE2VjZHNhLXNoYTItbmlzdHA1MjEAAAAIbmlzdHA1MjEAAACFBACH5RBUOVjmexlGTX0hzQs06/kOCDngLuxLaAbyGaD4KCdwKBJX45mdTPQQRkd8YQ7QHlkg28mlvKeUbEM39Y6S8QDnp8y3XYzOXKFtg9jp6sunUWDeL+9S42n6imAxnofVTkFhI35tgAM0QD+MvATnw6ZZe2aRKvU/49yLpLXEKCzoLQ== user@example.com", true},
@@ -86,7 +113,7 @@ func TestHasKeys(t *testing.T) {
 		t.Errorf("HasKeys returned true, want false")
 	}
 
-	cloudInit.SetKeys([]string{"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQCYID8wzSmmNYIyweQLk4TCJCGCCDAza+wl3PxpKslPV7LdZwdpfxo0Q2vlXsv4SN5wmJXJwwwE6ekGgMUFP4TtsGuyzXKxa1+jnBzc1+e/BtJ1AFdVAGzlUcqbZeHlnXfTlF+pnDrnXqG1jdJDsJZcgrZOK6uUQKMfbU/KoZM56w== user@example.com"})
+	cloudInit.SetKeys([]string{"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCgj57b92l6AnH7aOvq/+6JbGl6JrqjYVCpXUEg7eIqNpU18oCLkn8XN5rCFYmvVTc8yP8DnzlpduN013HGP4Ghqf+33S//PXq97u0LzFLdUiHqZr55/ZysgcjBLDrs71xWun6E3cT8MvO5S/Yh5BTqsuP0mjIqoE4fw//g8pmuMx6u6CwiGDx3KPOpFFAzU74/2pbVzfaouUoCsc5rPdQJqS6YO7hhbFNVgquM1i2l4IFjsgYUkiF1vnQlwqntLt7jBNrfc5T3lhzX47rlcK7AFkrnqjxY6oGUfCoBidPmDkM8A0HvJC5U/oZ0GQourqhx5e1tpWKVzhifueFYDYVB user@example.com"})
 	if !cloudInit.HasKeys() {
 		t.Errorf("HasKeys returned false, want true")
 	}
@@ -104,15 +131,36 @@ func TestSetAndGetKeys(t *testing.T) {
 		{
 			name: "Valid RSA and ED25519 keys",
 			inputKeys: []string{
-				"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQCYID8wzSmmNYIyweQLk4TCJCGCCDAza+wl3PxpKslPV7LdZwdpfxo0Q2vlXsv4SN5wmJXJwwwE6ekGgMUFP4TtsGuyzXKxa1+jnBzc1+e/BtJ1AFdVAGzlUcqbZeHlnXfTlF+pnDrnXqG1jdJDsJZcgrZOK6uUQKMfbU/KoZM56w== user@example.com",
+				"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCgj57b92l6AnH7aOvq/+6JbGl6JrqjYVCpXUEg7eIqNpU18oCLkn8XN5rCFYmvVTc8yP8DnzlpduN013HGP4Ghqf+33S//PXq97u0LzFLdUiHqZr55/ZysgcjBLDrs71xWun6E3cT8MvO5S/Yh5BTqsuP0mjIqoE4fw//g8pmuMx6u6CwiGDx3KPOpFFAzU74/2pbVzfaouUoCsc5rPdQJqS6YO7hhbFNVgquM1i2l4IFjsgYUkiF1vnQlwqntLt7jBNrfc5T3lhzX47rlcK7AFkrnqjxY6oGUfCoBidPmDkM8A0HvJC5U/oZ0GQourqhx5e1tpWKVzhifueFYDYVB user@example.com",
 				"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS user@example.com",
 			},
 			expectedKeys: []string{
-				"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQCYID8wzSmmNYIyweQLk4TCJCGCCDAza+wl3PxpKslPV7LdZwdpfxo0Q2vlXsv4SN5wmJXJwwwE6ekGgMUFP4TtsGuyzXKxa1+jnBzc1+e/BtJ1AFdVAGzlUcqbZeHlnXfTlF+pnDrnXqG1jdJDsJZcgrZOK6uUQKMfbU/KoZM56w== user@example.com",
+				"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCgj57b92l6AnH7aOvq/+6JbGl6JrqjYVCpXUEg7eIqNpU18oCLkn8XN5rCFYmvVTc8yP8DnzlpduN013HGP4Ghqf+33S//PXq97u0LzFLdUiHqZr55/ZysgcjBLDrs71xWun6E3cT8MvO5S/Yh5BTqsuP0mjIqoE4fw//g8pmuMx6u6CwiGDx3KPOpFFAzU74/2pbVzfaouUoCsc5rPdQJqS6YO7hhbFNVgquM1i2l4IFjsgYUkiF1vnQlwqntLt7jBNrfc5T3lhzX47rlcK7AFkrnqjxY6oGUfCoBidPmDkM8A0HvJC5U/oZ0GQourqhx5e1tpWKVzhifueFYDYVB user@example.com",
 				"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS user@example.com",
 			},
 			expectedCount: 2,
 		},
+		{
+			name: "Duplicate keys with different comments",
+			inputKeys: []string{
+				"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS user@example.com",
+				"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS other@example.com",
+			},
+			expectedKeys: []string{
+				"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS user@example.com",
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "Key with options",
+			inputKeys: []string{
+				"no-port-forwarding,from=\"10.0.0.0/8\" ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS user@example.com",
+			},
+			expectedKeys: []string{
+				"no-port-forwarding,from=\"10.0.0.0/8\" ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS user@example.com",
+			},
+			expectedCount: 1,
+		},
 		{
 			name: "Invalid key formats",
 			inputKeys: []string{
@@ -143,6 +191,23 @@ func TestSetAndGetKeys(t *testing.T) {
 	}
 }
 
+// TestSetKeysFromAuthorizedKeysFile tests the SetKeys function with a path to an authorized_keys file.
+func TestSetKeysFromAuthorizedKeysFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "authorized_keys")
+	content := "# managed keys\nssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS user@example.com\n\nssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS user@example.com\nssh-rsa invalidkeyformat\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write authorized_keys file: %v", err)
+	}
+
+	cloudInit := NewCloudInitConfiguration()
+	cloudInit.SetKeys([]string{path, "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS user@example.com"})
+
+	expected := []string{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS user@example.com"}
+	if !reflect.DeepEqual(cloudInit.GetKeys(), expected) {
+		t.Errorf("SetKeys returned %v, want %v", cloudInit.GetKeys(), expected)
+	}
+}
+
 // TestSetAndGetIPv4ManualConfiguration tests the SetIPv4 and GetIPv4 functions with manual configuration.
 func TestSetAndGetIPv4ManualConfiguration(t *testing.T) {
 	cloudInit := NewCloudInitConfiguration()
@@ -285,6 +350,50 @@ func TestSetAndGetConfigurationSource(t *testing.T) {
 	}
 }
 
+// TestSetAndGetNameservers tests the SetNameservers and GetNameservers functions.
+func TestSetAndGetNameservers(t *testing.T) {
+	cloudInit := NewCloudInitConfiguration()
+
+	if cloudInit.HasDns() {
+		t.Error("HasDns returned true, want false")
+	}
+
+	cloudInit.SetNameservers([]string{"1.1.1.1,8.8.8.8", " 2606:4700:4700::1111 "})
+	expected := []string{"1.1.1.1", "8.8.8.8", "2606:4700:4700::1111"}
+
+	if !reflect.DeepEqual(cloudInit.GetNameservers(), expected) {
+		t.Errorf("GetNameservers returned %v, want %v", cloudInit.GetNameservers(), expected)
+	}
+
+	if !cloudInit.HasDns() {
+		t.Error("HasDns returned false, want true")
+	}
+}
+
+// TestSetAndGetSearchDomains tests the SetSearchDomains and GetSearchDomains functions.
+func TestSetAndGetSearchDomains(t *testing.T) {
+	cloudInit := NewCloudInitConfiguration()
+	cloudInit.SetSearchDomains([]string{"example.com lab.internal", ""})
+	expected := []string{"example.com", "lab.internal"}
+
+	if !reflect.DeepEqual(cloudInit.GetSearchDomains(), expected) {
+		t.Errorf("GetSearchDomains returned %v, want %v", cloudInit.GetSearchDomains(), expected)
+	}
+}
+
+// TestParseList tests the ParseList function.
+func TestParseList(t *testing.T) {
+	expected := []string{"1.1.1.1", "8.8.8.8", "9.9.9.9"}
+
+	if result := ParseList("1.1.1.1, 8.8.8.8 9.9.9.9"); !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseList returned %v, want %v", result, expected)
+	}
+
+	if result := ParseList(""); len(result) != 0 {
+		t.Errorf("ParseList returned %v, want empty list", result)
+	}
+}
+
 // TestIsConfigurationValid tests the IsConfigurationValid function.
 func TestIsConfigurationValid(t *testing.T) {
 	tests := []struct {
@@ -313,12 +422,53 @@ func TestIsConfigurationValid(t *testing.T) {
 		{
 			name: "Valid manual IPv6 configuration and auto IPv4",
 			setup: func(ci *CloudInit) {
-				ci.SetIPv6("fe80::1/64")
+				ci.SetIPv6("2001:db8::10/64")
 				ci.SetIPv6Gateway("fe80::1")
 				ci.AutoConfigureIPv4()
 			},
 			wantErr: false,
 		},
+		{
+			name: "IPv4 gateway outside of the subnet",
+			setup: func(ci *CloudInit) {
+				ci.SetIPv4("10.10.10.10/24")
+				ci.SetIPv4Gateway("10.10.11.1")
+			},
+			wantErr:  true,
+			errorMsg: "cloud-init IPv4 gateway `10.10.11.1` is not in the `10.10.10.0/24` subnet",
+		},
+		{
+			name: "IPv4 and IPv6 disabled",
+			setup: func(ci *CloudInit) {
+				ci.SetIPv4Mode(AddressModeNone)
+				ci.SetIPv6Mode(AddressModeNone)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Valid nameservers and search domains",
+			setup: func(ci *CloudInit) {
+				ci.SetNameservers([]string{"1.1.1.1", "2606:4700:4700::1111"})
+				ci.SetSearchDomains([]string{"example.com"})
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid nameserver",
+			setup: func(ci *CloudInit) {
+				ci.SetNameservers([]string{"1.1.1"})
+			},
+			wantErr:  true,
+			errorMsg: "cloud-init nameserver `1.1.1` is not a valid IP address",
+		},
+		{
+			name: "Invalid search domain",
+			setup: func(ci *CloudInit) {
+				ci.SetSearchDomains([]string{"-example.com"})
+			},
+			wantErr:  true,
+			errorMsg: "cloud-init search domain `-example.com` is not a valid domain name",
+		},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,193 @@
+package cloud_init
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/utils"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// SnippetTypeUser is the type of the custom user-data snippet.
+	SnippetTypeUser = "user"
+	// SnippetTypeNetwork is the type of the custom network-config snippet.
+	SnippetTypeNetwork = "network"
+	// SnippetTypeMeta is the type of the custom meta-data snippet.
+	SnippetTypeMeta = "meta"
+	// SnippetTypeVendor is the type of the custom vendor-data snippet.
+	SnippetTypeVendor = "vendor"
+)
+
+var (
+	// snippetTypes is the list of custom snippet types in the order they are passed to `--cicustom`.
+	snippetTypes = []string{
+		SnippetTypeUser,
+		SnippetTypeNetwork,
+		SnippetTypeMeta,
+		SnippetTypeVendor,
+	}
+)
+
+// GetSnippetTypes returns the list of custom snippet types.
+func GetSnippetTypes() []string {
+	return snippetTypes
+}
+
+// GetCustomSnippet returns the inline content or file path of the custom snippet of the given type.
+func (cloudInit *CloudInit) GetCustomSnippet(snippetType string) string {
+	return cloudInit.customSnippets[snippetType]
+}
+
+// SetCustomSnippet sets the inline content or file path of the custom snippet of the given type.
+func (cloudInit *CloudInit) SetCustomSnippet(snippetType string, value string) *CloudInit {
+	if strings.TrimSpace(value) == "" {
+		delete(cloudInit.customSnippets, snippetType)
+		return cloudInit
+	}
+
+	cloudInit.customSnippets[snippetType] = value
+	return cloudInit
+}
+
+// HasCustomSnippets returns true if at least one custom snippet is configured.
+func (cloudInit *CloudInit) HasCustomSnippets() bool {
+	return len(cloudInit.customSnippets) > 0
+}
+
+// GetSnippetsStorage returns the name of the storage custom snippets are uploaded to.
+func (cloudInit *CloudInit) GetSnippetsStorage() string {
+	return cloudInit.snippetsStorage
+}
+
+// SetSnippetsStorage sets the name of the storage custom snippets are uploaded to.
+func (cloudInit *CloudInit) SetSnippetsStorage(snippetsStorage string) *CloudInit {
+	cloudInit.snippetsStorage = snippetsStorage
+	return cloudInit
+}
+
+// GetCustomVolume returns the volume identifier of the uploaded snippet of the given type.
+func (cloudInit *CloudInit) GetCustomVolume(snippetType string) string {
+	return cloudInit.customVolumes[snippetType]
+}
+
+// SetCustomVolume sets the volume identifier of the uploaded snippet of the given type.
+func (cloudInit *CloudInit) SetCustomVolume(snippetType string, volume string) *CloudInit {
+	cloudInit.customVolumes[snippetType] = volume
+	return cloudInit
+}
+
+// GetCicustom returns the value for the `--cicustom` option (for example, user=local:snippets/user.yaml).
+func (cloudInit *CloudInit) GetCicustom() string {
+	parts := make([]string, 0)
+
+	for _, snippetType := range snippetTypes {
+		if volume, ok := cloudInit.customVolumes[snippetType]; ok && volume != "" {
+			parts = append(parts, fmt.Sprintf("%s=%s", snippetType, volume))
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// GetCustomSnippetFileName returns the file name of the custom snippet in the snippets storage.
+func GetCustomSnippetFileName(identifier int, snippetType string) string {
+	return fmt.Sprintf("ptm-%d-%s-data.yaml", identifier, snippetType)
+}
+
+// ResolveCustomSnippet returns the content of the custom snippet (reads the file if the value is a path).
+func ResolveCustomSnippet(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+
+	if strings.Contains(trimmed, "\n") || strings.HasPrefix(trimmed, "#") {
+		return value, nil
+	}
+
+	path, err := utils.ExpandHomeDir(trimmed)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(path)
+	if err == nil {
+		return string(content), nil
+	}
+
+	if isPathLike(trimmed) {
+		return "", fmt.Errorf("failed to read custom snippet file `%s`: %v", trimmed, err)
+	}
+
+	return value, nil
+}
+
+// ValidateCustomSnippet validates the content of the custom snippet of the given type.
+func ValidateCustomSnippet(snippetType string, content string) error {
+	switch snippetType {
+	case SnippetTypeUser, SnippetTypeVendor:
+		return validateUserData(snippetType, content)
+	case SnippetTypeNetwork:
+		return validateNetworkData(content)
+	case SnippetTypeMeta:
+		_, err := parseYamlMapping(SnippetTypeMeta, content)
+		return err
+	default:
+		return fmt.Errorf("unsupported custom snippet type `%s`, supported types: %s", snippetType, strings.Join(snippetTypes, ", "))
+	}
+}
+
+// validateUserData validates user-data and vendor-data snippets (cloud-config or script).
+func validateUserData(snippetType string, content string) error {
+	trimmed := strings.TrimLeft(content, " \t\r\n")
+
+	if strings.HasPrefix(trimmed, "#!") {
+		return nil
+	}
+
+	if !strings.HasPrefix(trimmed, "#cloud-config") {
+		return fmt.Errorf("%s-data must start with `#cloud-config` or a `#!` shebang line", snippetType)
+	}
+
+	_, err := parseYamlMapping(snippetType, content)
+	return err
+}
+
+// validateNetworkData validates network-config snippets (version 1 or 2).
+func validateNetworkData(content string) error {
+	document, err := parseYamlMapping(SnippetTypeNetwork, content)
+	if err != nil {
+		return err
+	}
+
+	if nested, ok := document["network"].(map[string]interface{}); ok {
+		document = nested
+	}
+
+	version, ok := document["version"].(int)
+	if !ok || (version != 1 && version != 2) {
+		return fmt.Errorf("network-data must declare `version: 1` or `version: 2`")
+	}
+
+	return nil
+}
+
+// parseYamlMapping parses the snippet content as a YAML mapping.
+func parseYamlMapping(snippetType string, content string) (map[string]interface{}, error) {
+	document := make(map[string]interface{})
+
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		return nil, fmt.Errorf("%s-data is not a valid YAML mapping: %v", snippetType, err)
+	}
+
+	return document, nil
+}
+
+// isPathLike returns true if the value looks like a file path.
+func isPathLike(value string) bool {
+	if strings.HasPrefix(value, "/") || strings.HasPrefix(value, "./") || strings.HasPrefix(value, "~/") {
+		return true
+	}
+
+	extension := filepath.Ext(value)
+	return extension == ".yaml" || extension == ".yml" || extension == ".cfg"
+}
@@ -0,0 +1,121 @@
+package cloud_init
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGetCicustom tests the GetCicustom function.
+func TestGetCicustom(t *testing.T) {
+	cloudInit := NewCloudInitConfiguration()
+
+	if cloudInit.GetCicustom() != "" {
+		t.Errorf("GetCicustom returned %v, want empty string", cloudInit.GetCicustom())
+	}
+
+	cloudInit.SetCustomVolume(SnippetTypeVendor, "local:snippets/vendor.yaml")
+	cloudInit.SetCustomVolume(SnippetTypeUser, "local:snippets/user.yaml")
+
+	expected := "user=local:snippets/user.yaml,vendor=local:snippets/vendor.yaml"
+	if cloudInit.GetCicustom() != expected {
+		t.Errorf("GetCicustom returned %v, want %v", cloudInit.GetCicustom(), expected)
+	}
+}
+
+// TestSetAndGetCustomSnippet tests the SetCustomSnippet and GetCustomSnippet functions.
+func TestSetAndGetCustomSnippet(t *testing.T) {
+	cloudInit := NewCloudInitConfiguration()
+
+	if cloudInit.HasCustomSnippets() {
+		t.Error("HasCustomSnippets returned true, want false")
+	}
+
+	cloudInit.SetCustomSnippet(SnippetTypeMeta, "")
+	if cloudInit.HasCustomSnippets() {
+		t.Error("HasCustomSnippets returned true for an empty snippet, want false")
+	}
+
+	cloudInit.SetCustomSnippet(SnippetTypeNetwork, "/root/network.yaml")
+	if cloudInit.GetCustomSnippet(SnippetTypeNetwork) != "/root/network.yaml" || !cloudInit.HasCustomSnippets() {
+		t.Errorf("GetCustomSnippet returned %v, want %v", cloudInit.GetCustomSnippet(SnippetTypeNetwork), "/root/network.yaml")
+	}
+}
+
+// TestGetCustomSnippetFileName tests the GetCustomSnippetFileName function.
+func TestGetCustomSnippetFileName(t *testing.T) {
+	expected := "ptm-9000-user-data.yaml"
+
+	if result := GetCustomSnippetFileName(9000, SnippetTypeUser); result != expected {
+		t.Errorf("GetCustomSnippetFileName returned %v, want %v", result, expected)
+	}
+}
+
+// TestResolveCustomSnippet tests the ResolveCustomSnippet function.
+func TestResolveCustomSnippet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user.yaml")
+	content := "#cloud-config\npackages:\n  - htop\n"
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write snippet file: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		value     string
+		expected  string
+		expectErr bool
+	}{
+		{"Inline", content, content, false},
+		{"File", path, content, false},
+		{"MissingFile", "/nonexistent/user.yaml", "", true},
+		{"MissingYamlFile", "missing.yaml", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := ResolveCustomSnippet(test.value)
+
+			if (err != nil) != test.expectErr {
+				t.Fatalf("ResolveCustomSnippet returned error %v, expectErr %v", err, test.expectErr)
+			}
+
+			if result != test.expected {
+				t.Errorf("ResolveCustomSnippet returned %v, want %v", result, test.expected)
+			}
+		})
+	}
+}
+
+// TestValidateCustomSnippet tests the ValidateCustomSnippet function.
+func TestValidateCustomSnippet(t *testing.T) {
+	tests := []struct {
+		name        string
+		snippetType string
+		content     string
+		expectErr   bool
+	}{
+		{"UserCloudConfig", SnippetTypeUser, "#cloud-config\npackages:\n  - htop\n", false},
+		{"UserScript", SnippetTypeUser, "#!/bin/bash\necho hello\n", false},
+		{"UserMissingHeader", SnippetTypeUser, "packages:\n  - htop\n", true},
+		{"UserInvalidYaml", SnippetTypeUser, "#cloud-config\npackages: [htop\n", true},
+		{"VendorCloudConfig", SnippetTypeVendor, "#cloud-config\nruncmd:\n  - ls\n", false},
+		{"NetworkV2", SnippetTypeNetwork, "version: 2\nethernets:\n  eth0:\n    dhcp4: true\n", false},
+		{"NetworkNested", SnippetTypeNetwork, "network:\n  version: 1\n  config: []\n", false},
+		{"NetworkMissingVersion", SnippetTypeNetwork, "ethernets:\n  eth0:\n    dhcp4: true\n", true},
+		{"NetworkInvalidVersion", SnippetTypeNetwork, "version: 3\n", true},
+		{"Meta", SnippetTypeMeta, "instance-id: ptm\nlocal-hostname: ptm\n", false},
+		{"MetaNotMapping", SnippetTypeMeta, "- a\n- b\n", true},
+		{"UnsupportedType", "other", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateCustomSnippet(test.snippetType, test.content)
+
+			if (err != nil) != test.expectErr {
+				t.Errorf("ValidateCustomSnippet returned error %v, expectErr %v", err, test.expectErr)
+			}
+		})
+	}
+}
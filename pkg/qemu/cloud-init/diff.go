@@ -0,0 +1,67 @@
+package cloud_init
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"gopkg.in/yaml.v3"
+	"strings"
+)
+
+var (
+	// volatileDataKeys is the list of keys which differ between every virtual machine and are ignored when comparing data.
+	volatileDataKeys = []string{
+		"instance-id",
+		"uuid",
+		"mac_address",
+	}
+)
+
+// DiffData compares the rendered data with the data dumped from an existing virtual machine.
+// YAML and JSON documents are compared structurally (ignoring instance identifiers and MAC addresses), everything else line by line.
+// Passwords are redacted on both sides, so password hashes are never printed.
+// Returns an empty string if there are no differences.
+func DiffData(rendered string, dumped string) string {
+	rendered = RedactPasswords(rendered)
+	dumped = RedactPasswords(dumped)
+
+	var renderedDocument, dumpedDocument interface{}
+
+	renderedError := yaml.Unmarshal([]byte(rendered), &renderedDocument)
+	dumpedError := yaml.Unmarshal([]byte(dumped), &dumpedDocument)
+
+	if renderedError == nil && dumpedError == nil && isStructuredDocument(renderedDocument) && isStructuredDocument(dumpedDocument) {
+		return cmp.Diff(
+			dumpedDocument,
+			renderedDocument,
+			cmpopts.IgnoreMapEntries(func(key string, _ interface{}) bool {
+				for _, volatileKey := range volatileDataKeys {
+					if key == volatileKey {
+						return true
+					}
+				}
+				return false
+			}),
+		)
+	}
+
+	return cmp.Diff(splitDataLines(dumped), splitDataLines(rendered))
+}
+
+// isStructuredDocument returns true if the parsed document is a mapping or a sequence.
+func isStructuredDocument(document interface{}) bool {
+	switch document.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// splitDataLines splits the data into lines, ignoring trailing whitespace and empty lines at the end.
+func splitDataLines(data string) []string {
+	lines := strings.Split(strings.TrimRight(data, "\n"), "\n")
+	for index, line := range lines {
+		lines[index] = strings.TrimRight(line, " \t")
+	}
+	return lines
+}
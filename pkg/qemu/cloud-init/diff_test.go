@@ -0,0 +1,77 @@
+package cloud_init
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDiffData tests the DiffData function.
+func TestDiffData(t *testing.T) {
+	tests := []struct {
+		name        string
+		rendered    string
+		dumped      string
+		expectEqual bool
+	}{
+		{
+			name:        "Equal YAML With Different Formatting",
+			rendered:    "#cloud-config\nhostname: ubuntu\nusers:\n  - default\n",
+			dumped:      "#cloud-config\nhostname: 'ubuntu'\nusers: [default]\n",
+			expectEqual: true,
+		},
+		{
+			name:        "Volatile Keys Are Ignored",
+			rendered:    "version: 1\nconfig:\n    - type: physical\n      name: eth0\n",
+			dumped:      "version: 1\nconfig:\n    - type: physical\n      name: eth0\n      mac_address: 'bc:24:11:00:00:01'\n",
+			expectEqual: true,
+		},
+		{
+			name:        "Instance Identifier Is Ignored",
+			rendered:    "instance-id: 0123\n",
+			dumped:      "instance-id: 4567\n",
+			expectEqual: true,
+		},
+		{
+			name:        "Passwords Are Redacted",
+			rendered:    "#cloud-config\nuser: administrator\npassword: <redacted>\n",
+			dumped:      "#cloud-config\nuser: administrator\npassword: $6$salt$hash\n",
+			expectEqual: true,
+		},
+		{
+			name:        "Different YAML",
+			rendered:    "#cloud-config\nhostname: ubuntu\n",
+			dumped:      "#cloud-config\nhostname: debian\n",
+			expectEqual: false,
+		},
+		{
+			name:        "Equal Text",
+			rendered:    "auto lo\niface lo inet loopback\n",
+			dumped:      "auto lo \niface lo inet loopback\n\n",
+			expectEqual: true,
+		},
+		{
+			name:        "Different Text",
+			rendered:    "auto eth0\niface eth0 inet dhcp\n",
+			dumped:      "auto eth0\niface eth0 inet static\n",
+			expectEqual: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diff := DiffData(test.rendered, test.dumped)
+			if (diff == "") != test.expectEqual {
+				t.Errorf("DiffData returned %q, expectEqual %v", diff, test.expectEqual)
+			}
+		})
+	}
+}
+
+// TestDiffDataOutput tests that the DiffData output shows the template and preview values.
+func TestDiffDataOutput(t *testing.T) {
+	diff := DiffData("#cloud-config\nhostname: ubuntu\n", "#cloud-config\nhostname: debian\n")
+
+	if !strings.Contains(diff, `-`) || !strings.Contains(diff, `"debian"`) || !strings.Contains(diff, `"ubuntu"`) {
+		t.Errorf("DiffData returned unexpected output: %s", diff)
+	}
+}
@@ -0,0 +1,136 @@
+package cloud_init
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/utils"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DriveTypeNoCloud is the NoCloud cloud-init drive type (default for Linux guests).
+	DriveTypeNoCloud = "nocloud"
+	// DriveTypeConfigDrive2 is the OpenStack config-drive v2 cloud-init drive type (BSDs, cloudbase-init).
+	DriveTypeConfigDrive2 = "configdrive2"
+)
+
+var (
+	// driveTypes is the list of supported cloud-init drive types.
+	driveTypes = []string{
+		DriveTypeNoCloud,
+		DriveTypeConfigDrive2,
+	}
+	// driveBusSlots is the map of buses the cloud-init drive can be attached to and the highest slot of each bus.
+	driveBusSlots = map[string]int{
+		"ide":  3,
+		"sata": 5,
+		"scsi": 30,
+	}
+	// drivePattern is the pattern used to split the cloud-init drive into bus and slot.
+	drivePattern = regexp.MustCompile(`^([a-z]+)(\d+)$`)
+)
+
+// GetDriveTypes returns the list of supported cloud-init drive types.
+func GetDriveTypes() []string {
+	return driveTypes
+}
+
+// GetDriveType returns the cloud-init drive type (empty means Proxmox VE default).
+func (cloudInit *CloudInit) GetDriveType() string {
+	return cloudInit.driveType
+}
+
+// SetDriveType sets the cloud-init drive type.
+func (cloudInit *CloudInit) SetDriveType(driveType string) *CloudInit {
+	cloudInit.driveType = strings.ToLower(strings.TrimSpace(driveType))
+	return cloudInit
+}
+
+// GetUpgrade returns the flag that makes cloud-init upgrade packages on the first boot.
+func (cloudInit *CloudInit) GetUpgrade() bool {
+	return cloudInit.upgrade
+}
+
+// SetUpgrade sets the flag that makes cloud-init upgrade packages on the first boot.
+func (cloudInit *CloudInit) SetUpgrade(upgrade bool) *CloudInit {
+	cloudInit.upgrade = upgrade
+	return cloudInit
+}
+
+// GetDrive returns the bus and slot the cloud-init drive is attached to (empty means architecture default).
+func (cloudInit *CloudInit) GetDrive() string {
+	return cloudInit.drive
+}
+
+// SetDrive sets the bus and slot the cloud-init drive is attached to (for example, ide0 / sata1 / scsi2).
+func (cloudInit *CloudInit) SetDrive(drive string) *CloudInit {
+	cloudInit.drive = strings.ToLower(strings.TrimSpace(drive))
+	return cloudInit
+}
+
+// GetRegenerate returns the flag that regenerates the cloud-init drive with `qm cloudinit update` after changes.
+func (cloudInit *CloudInit) GetRegenerate() bool {
+	return cloudInit.regenerate
+}
+
+// SetRegenerate sets the flag that regenerates the cloud-init drive with `qm cloudinit update` after changes.
+func (cloudInit *CloudInit) SetRegenerate(regenerate bool) *CloudInit {
+	cloudInit.regenerate = regenerate
+	return cloudInit
+}
+
+// ValidateDriveType validates the cloud-init drive type.
+func ValidateDriveType(driveType string) error {
+	if driveType == "" || utils.SliceContains(driveTypes, driveType) {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"cloud-init drive type `%s` is not supported, supported drive types: %s",
+		driveType,
+		strings.Join(driveTypes, ", "),
+	)
+}
+
+// ValidateDrive validates the bus and slot of the cloud-init drive.
+func ValidateDrive(drive string) error {
+	if drive == "" {
+		return nil
+	}
+
+	bus, slot, err := ParseDrive(drive)
+	if err != nil {
+		return err
+	}
+
+	maximumSlot, ok := driveBusSlots[bus]
+	if !ok {
+		return fmt.Errorf("cloud-init drive `%s` uses unsupported bus `%s`, supported buses: ide, sata, scsi", drive, bus)
+	}
+
+	if slot > maximumSlot {
+		return fmt.Errorf("cloud-init drive `%s` uses slot %d, but the `%s` bus only supports slots 0-%d", drive, slot, bus, maximumSlot)
+	}
+
+	if drive == "scsi0" {
+		return fmt.Errorf("cloud-init drive cannot be attached to `scsi0` as it is used by the main disk")
+	}
+
+	return nil
+}
+
+// ParseDrive splits the cloud-init drive into bus and slot.
+func ParseDrive(drive string) (string, int, error) {
+	matches := drivePattern.FindStringSubmatch(drive)
+	if matches == nil {
+		return "", 0, fmt.Errorf("cloud-init drive `%s` is not valid, expected bus and slot (for example, ide2 / sata0 / scsi1)", drive)
+	}
+
+	slot, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return "", 0, fmt.Errorf("cloud-init drive `%s` has invalid slot: %v", drive, err)
+	}
+
+	return matches[1], slot, nil
+}
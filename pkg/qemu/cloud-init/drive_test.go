@@ -0,0 +1,95 @@
+package cloud_init
+
+import (
+	"testing"
+)
+
+// TestSetAndGetDriveOptions tests the drive type, drive, upgrade and regenerate setters and getters.
+func TestSetAndGetDriveOptions(t *testing.T) {
+	cloudInit := NewCloudInitConfiguration()
+
+	if cloudInit.GetDriveType() != "" || cloudInit.GetDrive() != "" {
+		t.Errorf("Expected drive type and drive to be empty by default")
+	}
+
+	if !cloudInit.GetUpgrade() || !cloudInit.GetRegenerate() {
+		t.Errorf("Expected upgrade and regenerate to be enabled by default")
+	}
+
+	cloudInit.SetDriveType(" ConfigDrive2 ").SetDrive("SATA0").SetUpgrade(false).SetRegenerate(false)
+
+	if cloudInit.GetDriveType() != DriveTypeConfigDrive2 {
+		t.Errorf("GetDriveType() = %s, want %s", cloudInit.GetDriveType(), DriveTypeConfigDrive2)
+	}
+
+	if cloudInit.GetDrive() != "sata0" {
+		t.Errorf("GetDrive() = %s, want %s", cloudInit.GetDrive(), "sata0")
+	}
+
+	if cloudInit.GetUpgrade() || cloudInit.GetRegenerate() {
+		t.Errorf("Expected upgrade and regenerate to be disabled")
+	}
+}
+
+// TestValidateDriveType tests the ValidateDriveType function.
+func TestValidateDriveType(t *testing.T) {
+	tests := []struct {
+		driveType string
+		expectErr bool
+	}{
+		{"", false},
+		{DriveTypeNoCloud, false},
+		{DriveTypeConfigDrive2, false},
+		{"opennebula", true},
+	}
+
+	for _, test := range tests {
+		if err := ValidateDriveType(test.driveType); (err != nil) != test.expectErr {
+			t.Errorf("ValidateDriveType(%s) error = %v, expectErr %v", test.driveType, err, test.expectErr)
+		}
+	}
+}
+
+// TestValidateDrive tests the ValidateDrive function.
+func TestValidateDrive(t *testing.T) {
+	tests := []struct {
+		drive     string
+		expectErr bool
+	}{
+		{"", false},
+		{"ide0", false},
+		{"ide2", false},
+		{"sata5", false},
+		{"scsi1", false},
+		{"scsi30", false},
+		{"ide4", true},
+		{"sata6", true},
+		{"scsi31", true},
+		{"scsi0", true},
+		{"virtio1", true},
+		{"ide", true},
+		{"2", true},
+	}
+
+	for _, test := range tests {
+		if err := ValidateDrive(test.drive); (err != nil) != test.expectErr {
+			t.Errorf("ValidateDrive(%s) error = %v, expectErr %v", test.drive, err, test.expectErr)
+		}
+	}
+}
+
+// TestParseDrive tests the ParseDrive function.
+func TestParseDrive(t *testing.T) {
+	bus, slot, err := ParseDrive("sata3")
+	if err != nil {
+		t.Fatalf("ParseDrive returned error: %v", err)
+	}
+
+	if bus != "sata" || slot != 3 {
+		t.Errorf("ParseDrive returned %s, %d, want %s, %d", bus, slot, "sata", 3)
+	}
+
+	if _, _, err := ParseDrive("cdrom"); err == nil {
+		t.Errorf("ParseDrive expected error for invalid drive")
+	}
+}
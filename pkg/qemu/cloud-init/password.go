@@ -0,0 +1,45 @@
+package cloud_init
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/utils"
+	"os"
+	"strings"
+)
+
+// ResolvePassword returns the password from the first configured source (value, file or environment variable).
+func ResolvePassword(password string, passwordFile string, passwordEnvironment string) (string, error) {
+	if password != "" {
+		return password, nil
+	}
+
+	if passwordFile != "" {
+		path, err := utils.ExpandHomeDir(passwordFile)
+		if err != nil {
+			return "", err
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read cloud-init password file `%s`: %v", passwordFile, err)
+		}
+
+		value := strings.TrimRight(string(content), "\r\n")
+		if value == "" {
+			return "", fmt.Errorf("cloud-init password file `%s` is empty", passwordFile)
+		}
+
+		return value, nil
+	}
+
+	if passwordEnvironment != "" {
+		value, ok := os.LookupEnv(passwordEnvironment)
+		if !ok || value == "" {
+			return "", fmt.Errorf("cloud-init password environment variable `%s` is not set", passwordEnvironment)
+		}
+
+		return value, nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,55 @@
+package cloud_init
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestResolvePassword tests the ResolvePassword function.
+func TestResolvePassword(t *testing.T) {
+	directory := t.TempDir()
+
+	passwordFile := filepath.Join(directory, "password")
+	if err := os.WriteFile(passwordFile, []byte("from-file\n"), 0600); err != nil {
+		t.Fatalf("failed to write password file: %v", err)
+	}
+
+	emptyFile := filepath.Join(directory, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0600); err != nil {
+		t.Fatalf("failed to write password file: %v", err)
+	}
+
+	t.Setenv("PTM_TEST_CI_PASSWORD", "from-environment")
+
+	tests := []struct {
+		name        string
+		password    string
+		file        string
+		environment string
+		expected    string
+		expectErr   bool
+	}{
+		{"Value", "from-value", passwordFile, "PTM_TEST_CI_PASSWORD", "from-value", false},
+		{"File", "", passwordFile, "PTM_TEST_CI_PASSWORD", "from-file", false},
+		{"Environment", "", "", "PTM_TEST_CI_PASSWORD", "from-environment", false},
+		{"None", "", "", "", "", false},
+		{"MissingFile", "", filepath.Join(directory, "missing"), "", "", true},
+		{"EmptyFile", "", emptyFile, "", "", true},
+		{"MissingEnvironment", "", "", "PTM_TEST_CI_PASSWORD_MISSING", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := ResolvePassword(test.password, test.file, test.environment)
+
+			if (err != nil) != test.expectErr {
+				t.Fatalf("ResolvePassword returned error %v, expectErr %v", err, test.expectErr)
+			}
+
+			if result != test.expected {
+				t.Errorf("ResolvePassword returned %v, want %v", result, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package cloud_init
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	// hostResolvConfPath is the path to the resolv.conf of the Proxmox VE host, used when no DNS settings are configured.
+	hostResolvConfPath = "/etc/resolv.conf"
+	// previewTypes is the list of data types rendered by the preview (in the order they are printed).
+	previewTypes = []string{
+		SnippetTypeUser,
+		SnippetTypeNetwork,
+		SnippetTypeMeta,
+		SnippetTypeVendor,
+	}
+	// passwordLinePattern is the pattern used to find the password keys of cloud-config documents.
+	passwordLinePattern = regexp.MustCompile(`(?m)^(\s*(?:-\s+)?(?:password|passwd|hashed_passwd|plain_text_passwd):)[ \t]*\S.*$`)
+)
+
+const (
+	// RedactedPassword is the value printed instead of passwords and password hashes.
+	RedactedPassword = "<redacted>"
+)
+
+// GetPreviewTypes returns the list of data types rendered by the preview.
+func GetPreviewTypes() []string {
+	return previewTypes
+}
+
+// Preview renders the user-data, network-config and meta-data a clone will receive (as `qm cloudinit dump` would).
+// Custom snippets replace the generated data of the same type, the same way `--cicustom` does.
+// Passwords are redacted, the instance identifier is still computed from the data with the password hash.
+func (cloudInit *CloudInit) Preview(hostname string) (map[string]string, error) {
+	preview := make(map[string]string)
+
+	userData, err := cloudInit.resolvePreviewData(SnippetTypeUser, cloudInit.RenderUserData(hostname))
+	if err != nil {
+		return nil, err
+	}
+
+	networkData, err := cloudInit.resolvePreviewData(SnippetTypeNetwork, cloudInit.RenderNetworkData())
+	if err != nil {
+		return nil, err
+	}
+	preview[SnippetTypeNetwork] = networkData
+
+	metaData, err := cloudInit.resolvePreviewData(SnippetTypeMeta, cloudInit.RenderMetaData(userData, networkData))
+	if err != nil {
+		return nil, err
+	}
+	preview[SnippetTypeMeta] = metaData
+	preview[SnippetTypeUser] = RedactPasswords(userData)
+
+	vendorData, err := cloudInit.resolvePreviewData(SnippetTypeVendor, "")
+	if err != nil {
+		return nil, err
+	}
+	if vendorData != "" {
+		preview[SnippetTypeVendor] = RedactPasswords(vendorData)
+	}
+
+	return preview, nil
+}
+
+// RedactPasswords replaces the values of the password keys of the data with a placeholder.
+func RedactPasswords(data string) string {
+	return passwordLinePattern.ReplaceAllString(data, "${1} "+RedactedPassword)
+}
+
+// RenderUserData renders the user-data generated by Proxmox VE.
+func (cloudInit *CloudInit) RenderUserData(hostname string) string {
+	hostname, fqdn := cloudInit.getHostnameAndFqdn(hostname)
+
+	var content strings.Builder
+	content.WriteString("#cloud-config\n")
+	content.WriteString(fmt.Sprintf("hostname: %s\n", hostname))
+	content.WriteString("manage_etc_hosts: true\n")
+
+	if fqdn != "" {
+		content.WriteString(fmt.Sprintf("fqdn: %s\n", fqdn))
+	}
+
+	if cloudInit.username != "" {
+		content.WriteString(fmt.Sprintf("user: %s\n", cloudInit.username))
+		if cloudInit.username == "root" {
+			content.WriteString("disable_root: False\n")
+		}
+	}
+
+	if cloudInit.password != "" {
+		content.WriteString(fmt.Sprintf("password: %s\n", cloudInit.password))
+	}
+
+	if len(cloudInit.keys) > 0 {
+		content.WriteString("ssh_authorized_keys:\n")
+		for _, key := range cloudInit.keys {
+			content.WriteString(fmt.Sprintf("  - %s\n", key))
+		}
+	}
+
+	content.WriteString("chpasswd:\n")
+	content.WriteString("  expire: False\n")
+
+	if cloudInit.username != "root" {
+		content.WriteString("users:\n")
+		content.WriteString("  - default\n")
+	}
+
+	if cloudInit.upgrade {
+		content.WriteString("package_upgrade: true\n")
+	}
+
+	return content.String()
+}
+
+// RenderNetworkData renders the network-config generated by Proxmox VE for the cloud-init drive type.
+func (cloudInit *CloudInit) RenderNetworkData() string {
+	if cloudInit.driveType == DriveTypeConfigDrive2 {
+		return cloudInit.renderConfigDriveNetworkData()
+	}
+	return cloudInit.renderNoCloudNetworkData()
+}
+
+// RenderMetaData renders the meta-data generated by Proxmox VE for the cloud-init drive type.
+func (cloudInit *CloudInit) RenderMetaData(userData string, networkData string) string {
+	checksum := sha1.Sum([]byte(userData + networkData))
+	instanceId := hex.EncodeToString(checksum[:])
+
+	if cloudInit.driveType == DriveTypeConfigDrive2 {
+		return fmt.Sprintf(
+			"{\n     \"uuid\": \"%s\",\n     \"network_config\": { \"content_path\": \"/content/0000\" }\n}\n",
+			instanceId,
+		)
+	}
+
+	return fmt.Sprintf("instance-id: %s\n", instanceId)
+}
+
+// renderNoCloudNetworkData renders the network-config version 1 used by the NoCloud drive type.
+func (cloudInit *CloudInit) renderNoCloudNetworkData() string {
+	var content strings.Builder
+	content.WriteString("version: 1\n")
+	content.WriteString("config:\n")
+	content.WriteString("    - type: physical\n")
+	content.WriteString("      name: eth0\n")
+	content.WriteString("      subnets:\n")
+
+	indent := "      "
+
+	if cloudInit.ipv4 == "dhcp" {
+		content.WriteString(indent + "- type: dhcp4\n")
+	} else if cloudInit.ipv4 != "" {
+		address, netmask := splitIPv4(cloudInit.ipv4)
+		content.WriteString(indent + "- type: static\n")
+		content.WriteString(fmt.Sprintf("%s  address: '%s'\n", indent, address))
+		content.WriteString(fmt.Sprintf("%s  netmask: '%s'\n", indent, netmask))
+		if cloudInit.gateway4 != "" {
+			content.WriteString(fmt.Sprintf("%s  gateway: '%s'\n", indent, cloudInit.gateway4))
+		}
+	}
+
+	if cloudInit.ipv6 == "dhcp" {
+		content.WriteString(indent + "- type: dhcp6\n")
+	} else if cloudInit.ipv6 == "auto" {
+		content.WriteString(indent + "- type: ipv6_slaac\n")
+	} else if cloudInit.ipv6 != "" {
+		content.WriteString(indent + "- type: static6\n")
+		content.WriteString(fmt.Sprintf("%s  address: '%s'\n", indent, cloudInit.ipv6))
+		if cloudInit.gateway6 != "" {
+			content.WriteString(fmt.Sprintf("%s  gateway: '%s'\n", indent, cloudInit.gateway6))
+		}
+	}
+
+	nameservers, searchDomains := cloudInit.getDnsConfiguration()
+	if len(nameservers) > 0 || len(searchDomains) > 0 {
+		content.WriteString("    - type: nameserver\n")
+		if len(nameservers) > 0 {
+			content.WriteString("      address:\n")
+			for _, nameserver := range nameservers {
+				content.WriteString(fmt.Sprintf("      - '%s'\n", nameserver))
+			}
+		}
+		if len(searchDomains) > 0 {
+			content.WriteString("      search:\n")
+			for _, searchDomain := range searchDomains {
+				content.WriteString(fmt.Sprintf("      - '%s'\n", searchDomain))
+			}
+		}
+	}
+
+	return content.String()
+}
+
+// renderConfigDriveNetworkData renders the Debian interfaces style network data used by the config-drive v2 drive type.
+func (cloudInit *CloudInit) renderConfigDriveNetworkData() string {
+	var content strings.Builder
+	content.WriteString("auto lo\n")
+	content.WriteString("iface lo inet loopback\n\n")
+
+	nameservers, searchDomains := cloudInit.getDnsConfiguration()
+	if len(nameservers) > 0 {
+		content.WriteString(fmt.Sprintf("        dns_nameservers %s\n", strings.Join(nameservers, " ")))
+	}
+	if len(searchDomains) > 0 {
+		content.WriteString(fmt.Sprintf("        dns_search %s\n", strings.Join(searchDomains, " ")))
+	}
+
+	content.WriteString("auto eth0\n")
+
+	if cloudInit.ipv4 == "dhcp" {
+		content.WriteString("iface eth0 inet dhcp\n")
+	} else if cloudInit.ipv4 != "" {
+		address, netmask := splitIPv4(cloudInit.ipv4)
+		content.WriteString("iface eth0 inet static\n")
+		content.WriteString(fmt.Sprintf("        address %s\n", address))
+		content.WriteString(fmt.Sprintf("        netmask %s\n", netmask))
+		if cloudInit.gateway4 != "" {
+			content.WriteString(fmt.Sprintf("        gateway %s\n", cloudInit.gateway4))
+		}
+	}
+
+	if cloudInit.ipv6 == "dhcp" || cloudInit.ipv6 == "auto" {
+		content.WriteString(fmt.Sprintf("iface eth0 inet6 %s\n", cloudInit.ipv6))
+	} else if cloudInit.ipv6 != "" {
+		address, prefix, _ := strings.Cut(cloudInit.ipv6, "/")
+		content.WriteString("iface eth0 inet6 static\n")
+		content.WriteString(fmt.Sprintf("        address %s\n", address))
+		content.WriteString(fmt.Sprintf("        netmask %s\n", prefix))
+		if cloudInit.gateway6 != "" {
+			content.WriteString(fmt.Sprintf("        gateway %s\n", cloudInit.gateway6))
+		}
+	}
+
+	return content.String()
+}
+
+// resolvePreviewData returns the content of the custom snippet of the given type, or the rendered data if there is none.
+func (cloudInit *CloudInit) resolvePreviewData(snippetType string, rendered string) (string, error) {
+	value := cloudInit.GetCustomSnippet(snippetType)
+	if value == "" {
+		return rendered, nil
+	}
+
+	content, err := ResolveCustomSnippet(value)
+	if err != nil {
+		return "", err
+	}
+
+	if err := ValidateCustomSnippet(snippetType, content); err != nil {
+		return "", err
+	}
+
+	return content, nil
+}
+
+// getHostnameAndFqdn returns the short hostname and the fully qualified domain name of the virtual machine.
+func (cloudInit *CloudInit) getHostnameAndFqdn(name string) (string, string) {
+	if strings.Contains(name, ".") {
+		hostname, _, _ := strings.Cut(name, ".")
+		return hostname, name
+	}
+
+	_, searchDomains := cloudInit.getDnsConfiguration()
+	if len(searchDomains) > 0 {
+		return name, fmt.Sprintf("%s.%s", name, searchDomains[0])
+	}
+
+	return name, ""
+}
+
+// getDnsConfiguration returns the configured DNS settings, each falling back to the one of the Proxmox VE host.
+func (cloudInit *CloudInit) getDnsConfiguration() ([]string, []string) {
+	nameservers, searchDomains := cloudInit.nameservers, cloudInit.searchDomains
+	if len(nameservers) > 0 && len(searchDomains) > 0 {
+		return nameservers, searchDomains
+	}
+
+	hostNameservers, hostSearchDomains := readHostDnsConfiguration()
+
+	if len(nameservers) == 0 {
+		nameservers = hostNameservers
+	}
+
+	if len(searchDomains) == 0 {
+		searchDomains = hostSearchDomains
+	}
+
+	return nameservers, searchDomains
+}
+
+// readHostDnsConfiguration reads the nameservers (up to 3) and search domains from the resolv.conf of the Proxmox VE host.
+func readHostDnsConfiguration() ([]string, []string) {
+	content, err := os.ReadFile(hostResolvConfPath)
+	if err != nil {
+		return nil, nil
+	}
+
+	var nameservers, searchDomains []string
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		switch fields[0] {
+		case "nameserver":
+			if len(nameservers) < 3 {
+				nameservers = append(nameservers, fields[1])
+			}
+		case "search", "domain":
+			searchDomains = fields[1:]
+		}
+	}
+
+	return nameservers, searchDomains
+}
+
+// splitIPv4 splits the IPv4 address in CIDR notation into address and dotted netmask.
+func splitIPv4(cidr string) (string, string) {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return cidr, ""
+	}
+
+	return ip.String(), net.IP(network.Mask).String()
+}
@@ -0,0 +1,223 @@
+package cloud_init
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useHostResolvConf points the host resolv.conf to a temporary file with the given content for the duration of the test.
+func useHostResolvConf(t *testing.T, content string) {
+	path := filepath.Join(t.TempDir(), "resolv.conf")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write resolv.conf: %v", err)
+	}
+
+	original := hostResolvConfPath
+	hostResolvConfPath = path
+	t.Cleanup(func() {
+		hostResolvConfPath = original
+	})
+}
+
+// TestRenderUserData tests the RenderUserData function.
+func TestRenderUserData(t *testing.T) {
+	useHostResolvConf(t, "nameserver 192.168.1.1\n")
+
+	cloudInit := NewCloudInitConfiguration()
+	cloudInit.username = "administrator"
+	cloudInit.password = "$6$salt$hash"
+	cloudInit.keys = []string{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS user@example.com"}
+	cloudInit.SetSearchDomains([]string{"example.com"})
+
+	expected := `#cloud-config
+hostname: ubuntu
+manage_etc_hosts: true
+fqdn: ubuntu.example.com
+user: administrator
+password: $6$salt$hash
+ssh_authorized_keys:
+  - ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS user@example.com
+chpasswd:
+  expire: False
+users:
+  - default
+package_upgrade: true
+`
+
+	if result := cloudInit.RenderUserData("ubuntu"); result != expected {
+		t.Errorf("RenderUserData returned:\n%s\nwant:\n%s", result, expected)
+	}
+}
+
+// TestRenderUserDataForRoot tests the RenderUserData function with the root user and upgrades disabled.
+func TestRenderUserDataForRoot(t *testing.T) {
+	useHostResolvConf(t, "")
+
+	cloudInit := NewCloudInitConfiguration().SetUsername("root").SetUpgrade(false)
+	result := cloudInit.RenderUserData("node.example.org")
+
+	for _, expected := range []string{"hostname: node\n", "fqdn: node.example.org\n", "disable_root: False\n"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderUserData result does not contain %q:\n%s", expected, result)
+		}
+	}
+
+	for _, unexpected := range []string{"users:", "package_upgrade"} {
+		if strings.Contains(result, unexpected) {
+			t.Errorf("RenderUserData result contains %q:\n%s", unexpected, result)
+		}
+	}
+}
+
+// TestRenderNetworkData tests the RenderNetworkData function for the NoCloud drive type.
+func TestRenderNetworkData(t *testing.T) {
+	useHostResolvConf(t, "search lan\nnameserver 192.168.1.1\n")
+
+	cloudInit := NewCloudInitConfiguration().
+		SetIPv4("10.0.0.10/24").
+		SetIPv4Gateway("10.0.0.1").
+		SetNameservers([]string{"1.1.1.1"})
+
+	expected := `version: 1
+config:
+    - type: physical
+      name: eth0
+      subnets:
+      - type: static
+        address: '10.0.0.10'
+        netmask: '255.255.255.0'
+        gateway: '10.0.0.1'
+      - type: ipv6_slaac
+    - type: nameserver
+      address:
+      - '1.1.1.1'
+      search:
+      - 'lan'
+`
+
+	if result := cloudInit.RenderNetworkData(); result != expected {
+		t.Errorf("RenderNetworkData returned:\n%s\nwant:\n%s", result, expected)
+	}
+}
+
+// TestRenderNetworkDataConfigDrive tests the RenderNetworkData function for the config-drive v2 drive type.
+func TestRenderNetworkDataConfigDrive(t *testing.T) {
+	useHostResolvConf(t, "")
+
+	cloudInit := NewCloudInitConfiguration().
+		SetDriveType(DriveTypeConfigDrive2).
+		SetIPv6("2001:db8::10/64").
+		SetIPv6Gateway("2001:db8::1").
+		SetNameservers([]string{"1.1.1.1", "1.0.0.1"})
+
+	expected := `auto lo
+iface lo inet loopback
+
+        dns_nameservers 1.1.1.1 1.0.0.1
+auto eth0
+iface eth0 inet dhcp
+iface eth0 inet6 static
+        address 2001:db8::10
+        netmask 64
+        gateway 2001:db8::1
+`
+
+	if result := cloudInit.RenderNetworkData(); result != expected {
+		t.Errorf("RenderNetworkData returned:\n%s\nwant:\n%s", result, expected)
+	}
+}
+
+// TestRenderMetaData tests the RenderMetaData function.
+func TestRenderMetaData(t *testing.T) {
+	noCloud := NewCloudInitConfiguration().RenderMetaData("user", "network")
+	if !strings.HasPrefix(noCloud, "instance-id: ") || len(strings.TrimSpace(noCloud)) != len("instance-id: ")+40 {
+		t.Errorf("RenderMetaData returned unexpected NoCloud meta-data: %s", noCloud)
+	}
+
+	configDrive := NewCloudInitConfiguration().SetDriveType(DriveTypeConfigDrive2).RenderMetaData("user", "network")
+	if !strings.Contains(configDrive, `"uuid": "`) || !strings.Contains(configDrive, `"content_path": "/content/0000"`) {
+		t.Errorf("RenderMetaData returned unexpected config-drive meta-data: %s", configDrive)
+	}
+}
+
+// TestPreview tests the Preview function with custom snippets.
+func TestPreview(t *testing.T) {
+	useHostResolvConf(t, "")
+
+	cloudInit := NewCloudInitConfiguration().
+		SetCustomSnippet(SnippetTypeUser, "#cloud-config\npackages:\n  - htop\n").
+		SetCustomSnippet(SnippetTypeVendor, "#cloud-config\nruncmd:\n  - echo vendor\n")
+
+	preview, err := cloudInit.Preview("ubuntu")
+	if err != nil {
+		t.Fatalf("Preview returned error: %v", err)
+	}
+
+	if preview[SnippetTypeUser] != "#cloud-config\npackages:\n  - htop\n" {
+		t.Errorf("Preview user-data = %q, want custom snippet", preview[SnippetTypeUser])
+	}
+
+	if !strings.HasPrefix(preview[SnippetTypeNetwork], "version: 1\n") {
+		t.Errorf("Preview network-config = %q, want rendered network-config", preview[SnippetTypeNetwork])
+	}
+
+	if !strings.HasPrefix(preview[SnippetTypeMeta], "instance-id: ") {
+		t.Errorf("Preview meta-data = %q, want rendered meta-data", preview[SnippetTypeMeta])
+	}
+
+	if _, ok := preview[SnippetTypeVendor]; !ok {
+		t.Errorf("Preview vendor-data is missing")
+	}
+
+	invalid := NewCloudInitConfiguration().SetCustomSnippet(SnippetTypeNetwork, "ethernets:\n  eth0: {}\n")
+	if _, err := invalid.Preview("ubuntu"); err == nil {
+		t.Errorf("Preview expected error for invalid network-config")
+	}
+}
+
+// TestPreviewRedactsPasswords tests that the Preview function does not print password hashes.
+func TestPreviewRedactsPasswords(t *testing.T) {
+	useHostResolvConf(t, "")
+
+	cloudInit := NewCloudInitConfiguration().SetUsername("administrator")
+	cloudInit.password = "$6$salt$hash"
+
+	preview, err := cloudInit.Preview("ubuntu")
+	if err != nil {
+		t.Fatalf("Preview returned error: %v", err)
+	}
+
+	if strings.Contains(preview[SnippetTypeUser], cloudInit.password) || !strings.Contains(preview[SnippetTypeUser], "password: <redacted>\n") {
+		t.Errorf("Preview user-data does not redact the password:\n%s", preview[SnippetTypeUser])
+	}
+
+	expected := cloudInit.RenderMetaData(cloudInit.RenderUserData("ubuntu"), cloudInit.RenderNetworkData())
+	if preview[SnippetTypeMeta] != expected {
+		t.Errorf("Preview meta-data = %q, want %q computed from the password hash", preview[SnippetTypeMeta], expected)
+	}
+}
+
+// TestRedactPasswords tests the RedactPasswords function.
+func TestRedactPasswords(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{"Default User", "user: ops\npassword: $6$salt$hash\n", "user: ops\npassword: <redacted>\n"},
+		{"Users List", "users:\n  - name: ops\n    hashed_passwd: $6$salt$hash\n", "users:\n  - name: ops\n    hashed_passwd: <redacted>\n"},
+		{"Sequence Entry", "users:\n  - passwd: secret\n", "users:\n  - passwd: <redacted>\n"},
+		{"Nested Mapping Is Kept", "chpasswd:\n  expire: False\n", "chpasswd:\n  expire: False\n"},
+		{"Empty Value Is Kept", "password:\n", "password:\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := RedactPasswords(test.data); result != test.expected {
+				t.Errorf("RedactPasswords returned %q, want %q", result, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,343 @@
+package cloud_init
+
+import (
+	"bytes"
+	"fmt"
+	cic "github.com/darki73/ptm/pkg/configuration/cloud-init"
+	"gopkg.in/yaml.v3"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	// userNamePattern is the pattern used to validate the names of users and groups.
+	userNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_-]{0,31}$`)
+)
+
+// User is a structure that contains an additional user created by cloud-init.
+type User struct {
+	// name is the name of the user.
+	name string
+	// keys is a list of SSH keys of the user.
+	keys []string
+	// groups is a list of supplementary groups of the user.
+	groups []string
+	// shell is the login shell of the user.
+	shell string
+	// sudo is the sudo rule of the user.
+	sudo string
+	// lockPasswd is the flag that disables password login for the user.
+	lockPasswd bool
+}
+
+// vendorDataDocument represents the cloud-config document rendered for additional users.
+// Users are created with commands, as the `users` key of vendor-data is replaced by the one of the user-data generated by Proxmox VE.
+type vendorDataDocument struct {
+	// WriteFiles is the list of files written by cloud-init (the sudo rules of the users).
+	WriteFiles []vendorDataFile `yaml:"write_files,omitempty"`
+	// Runcmd is the list of commands that create the users.
+	Runcmd []string `yaml:"runcmd"`
+}
+
+// vendorDataFile represents a file written by cloud-init.
+type vendorDataFile struct {
+	// Path is the path of the file.
+	Path string `yaml:"path"`
+	// Permissions is the octal mode of the file.
+	Permissions string `yaml:"permissions"`
+	// Content is the content of the file.
+	Content string `yaml:"content"`
+}
+
+// NewUser creates a new additional user.
+func NewUser(name string) *User {
+	return &User{
+		name:       strings.TrimSpace(name),
+		keys:       []string{},
+		groups:     []string{},
+		shell:      "",
+		sudo:       "",
+		lockPasswd: false,
+	}
+}
+
+// NewUsersFromConfiguration creates additional users from the configuration file.
+func NewUsersFromConfiguration(configurations []*cic.CloudInitUser) []*User {
+	users := make([]*User, 0, len(configurations))
+
+	for _, configuration := range configurations {
+		if configuration == nil {
+			continue
+		}
+
+		users = append(users, NewUser(configuration.GetName()).
+			SetKeys(configuration.GetSshAuthorizedKeys()).
+			SetGroups(configuration.GetGroups()).
+			SetShell(configuration.GetShell()).
+			SetSudo(configuration.GetSudo()).
+			SetLockPasswd(configuration.GetLockPasswd()))
+	}
+
+	return users
+}
+
+// GetName returns the name of the user.
+func (user *User) GetName() string {
+	return user.name
+}
+
+// GetKeys returns the list of SSH keys of the user.
+func (user *User) GetKeys() []string {
+	return user.keys
+}
+
+// SetKeys sets the list of SSH keys of the user (reads files, drops invalid keys and removes duplicates).
+func (user *User) SetKeys(keys []string) *User {
+	user.keys = resolveSshKeys(keys)
+	return user
+}
+
+// GetGroups returns the list of supplementary groups of the user.
+func (user *User) GetGroups() []string {
+	return user.groups
+}
+
+// SetGroups sets the list of supplementary groups of the user.
+func (user *User) SetGroups(groups []string) *User {
+	user.groups = make([]string, 0, len(groups))
+	for _, group := range groups {
+		if group = strings.TrimSpace(group); group != "" {
+			user.groups = append(user.groups, group)
+		}
+	}
+	return user
+}
+
+// GetShell returns the login shell of the user.
+func (user *User) GetShell() string {
+	return user.shell
+}
+
+// SetShell sets the login shell of the user.
+func (user *User) SetShell(shell string) *User {
+	user.shell = strings.TrimSpace(shell)
+	return user
+}
+
+// GetSudo returns the sudo rule of the user.
+func (user *User) GetSudo() string {
+	return user.sudo
+}
+
+// SetSudo sets the sudo rule of the user.
+func (user *User) SetSudo(sudo string) *User {
+	user.sudo = strings.TrimSpace(sudo)
+	return user
+}
+
+// GetLockPasswd returns the flag that disables password login for the user.
+func (user *User) GetLockPasswd() bool {
+	return user.lockPasswd
+}
+
+// SetLockPasswd sets the flag that disables password login for the user.
+func (user *User) SetLockPasswd(lockPasswd bool) *User {
+	user.lockPasswd = lockPasswd
+	return user
+}
+
+// GetUsers returns the list of additional users.
+func (cloudInit *CloudInit) GetUsers() []*User {
+	return cloudInit.users
+}
+
+// SetUsers sets the list of additional users.
+func (cloudInit *CloudInit) SetUsers(users []*User) *CloudInit {
+	cloudInit.users = users
+	return cloudInit
+}
+
+// HasUsers returns true if at least one additional user is configured.
+func (cloudInit *CloudInit) HasUsers() bool {
+	return len(cloudInit.users) > 0
+}
+
+// ValidateUsers validates the additional users.
+func ValidateUsers(username string, users []*User) error {
+	names := make(map[string]bool)
+
+	for _, user := range users {
+		name := user.GetName()
+
+		if !userNamePattern.MatchString(name) {
+			return fmt.Errorf("cloud-init user name `%s` is not valid", name)
+		}
+
+		if name == "root" || name == username {
+			return fmt.Errorf("cloud-init user `%s` is already configured as the default user", name)
+		}
+
+		if names[name] {
+			return fmt.Errorf("cloud-init user `%s` is configured more than once", name)
+		}
+		names[name] = true
+
+		for _, group := range user.GetGroups() {
+			if !userNamePattern.MatchString(group) {
+				return fmt.Errorf("cloud-init user `%s` has invalid group `%s`", name, group)
+			}
+		}
+
+		if user.GetShell() != "" && !path.IsAbs(user.GetShell()) {
+			return fmt.Errorf("cloud-init user `%s` shell `%s` must be an absolute path", name, user.GetShell())
+		}
+
+		if strings.ContainsAny(user.GetSudo(), "\r\n") {
+			return fmt.Errorf("cloud-init user `%s` sudo rule must be a single line", name)
+		}
+	}
+
+	return nil
+}
+
+// ApplyUsersVendorData renders the vendor-data for the additional users into the custom vendor-data snippet.
+// The user-data generated by Proxmox VE stays in effect, so the hostname, default user, password and SSH keys can still be changed on clones.
+func (cloudInit *CloudInit) ApplyUsersVendorData() error {
+	if !cloudInit.HasUsers() {
+		return nil
+	}
+
+	if cloudInit.GetCustomSnippet(SnippetTypeVendor) != "" {
+		return fmt.Errorf("cloud-init users cannot be combined with custom vendor-data, add them to the custom vendor-data instead")
+	}
+
+	content, err := cloudInit.RenderUsersVendorData()
+	if err != nil {
+		return err
+	}
+
+	cloudInit.SetCustomSnippet(SnippetTypeVendor, content)
+	return nil
+}
+
+// RenderUsersVendorData renders the vendor-data which creates the additional users.
+func (cloudInit *CloudInit) RenderUsersVendorData() (string, error) {
+	if err := ValidateUsers(cloudInit.username, cloudInit.users); err != nil {
+		return "", err
+	}
+
+	document := vendorDataDocument{
+		WriteFiles: []vendorDataFile{},
+		Runcmd:     []string{},
+	}
+
+	var sudoRules strings.Builder
+
+	for _, user := range cloudInit.users {
+		if user.GetSudo() != "" {
+			sudoRules.WriteString(user.GetName() + " " + user.GetSudo() + "\n")
+		}
+
+		document.Runcmd = append(document.Runcmd, renderUserCommands(user)...)
+	}
+
+	if sudoRules.Len() > 0 {
+		document.WriteFiles = append(document.WriteFiles, vendorDataFile{
+			Path:        "/etc/sudoers.d/90-ptm-users",
+			Permissions: "0440",
+			Content:     sudoRules.String(),
+		})
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("#cloud-config\n")
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(document); err != nil {
+		return "", err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// renderUserCommands renders the commands which create the missing groups and the user, add it to its groups, lock its password and install its SSH keys.
+// `useradd` / `groupadd` are used when available, busybox `adduser` / `addgroup` otherwise (Alpine Linux).
+func renderUserCommands(user *User) []string {
+	name := shellQuote(user.GetName())
+	commands := []string{}
+
+	// NOTE: `useradd -G` fails when a group does not exist (for example, `docker` on a clean image), so missing groups are created first.
+	for _, group := range user.GetGroups() {
+		commands = append(commands, fmt.Sprintf(
+			"getent group %s >/dev/null 2>&1 || if command -v groupadd >/dev/null 2>&1; then groupadd %s; else addgroup %s; fi",
+			shellQuote(group),
+			shellQuote(group),
+			shellQuote(group),
+		))
+	}
+
+	useradd := []string{"useradd", "-m"}
+	adduser := []string{"adduser", "-D"}
+	if user.GetShell() != "" {
+		useradd = append(useradd, "-s", shellQuote(user.GetShell()))
+		adduser = append(adduser, "-s", shellQuote(user.GetShell()))
+	}
+	if len(user.GetGroups()) > 0 {
+		useradd = append(useradd, "-G", shellQuote(strings.Join(user.GetGroups(), ",")))
+	}
+	useradd = append(useradd, name)
+	adduser = append(adduser, name)
+
+	commands = append(commands, fmt.Sprintf(
+		"id -u %s >/dev/null 2>&1 || if command -v useradd >/dev/null 2>&1; then %s; else %s; fi",
+		name,
+		strings.Join(useradd, " "),
+		strings.Join(adduser, " "),
+	))
+
+	if len(user.GetGroups()) > 0 {
+		groups := make([]string, 0, len(user.GetGroups()))
+		for _, group := range user.GetGroups() {
+			groups = append(groups, shellQuote(group))
+		}
+
+		commands = append(commands, fmt.Sprintf(
+			"command -v useradd >/dev/null 2>&1 || for group in %s; do addgroup %s \"$group\"; done",
+			strings.Join(groups, " "),
+			name,
+		))
+	}
+
+	if user.GetLockPasswd() {
+		commands = append(commands, fmt.Sprintf("passwd -l %s", name))
+	}
+
+	if len(user.GetKeys()) > 0 {
+		keys := make([]string, 0, len(user.GetKeys()))
+		for _, key := range user.GetKeys() {
+			keys = append(keys, shellQuote(key))
+		}
+
+		// NOTE: The home directory is empty when the user could not be created, the keys must not be written to `/.ssh` then.
+		commands = append(commands, fmt.Sprintf(
+			"home=$(awk -F: -v user=%s '$1 == user { print $6 }' /etc/passwd) && [ -n \"$home\" ] && [ \"$home\" != / ] && mkdir -p \"$home/.ssh\" && printf '%%s\\n' %s > \"$home/.ssh/authorized_keys\" && chmod 700 \"$home/.ssh\" && chmod 600 \"$home/.ssh/authorized_keys\" && chown -R %s \"$home/.ssh\"",
+			name,
+			strings.Join(keys, " "),
+			name,
+		))
+	}
+
+	return commands
+}
+
+// shellQuote quotes the value for the POSIX shell.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
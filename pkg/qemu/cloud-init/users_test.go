@@ -0,0 +1,247 @@
+package cloud_init
+
+import (
+	"fmt"
+	cic "github.com/darki73/ptm/pkg/configuration/cloud-init"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestNewUsersFromConfiguration tests the NewUsersFromConfiguration function.
+func TestNewUsersFromConfiguration(t *testing.T) {
+	key := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS ops@example.com"
+
+	users := NewUsersFromConfiguration([]*cic.CloudInitUser{
+		{
+			Name:              "ops",
+			SshAuthorizedKeys: []string{key, key, "not a key"},
+			Groups:            []string{"sudo", " adm ", ""},
+			Shell:             "/bin/bash",
+			Sudo:              "ALL=(ALL) NOPASSWD:ALL",
+			LockPasswd:        true,
+		},
+		nil,
+	})
+
+	if len(users) != 1 {
+		t.Fatalf("NewUsersFromConfiguration returned %d users, want 1", len(users))
+	}
+
+	user := users[0]
+	if user.GetName() != "ops" || user.GetShell() != "/bin/bash" || user.GetSudo() != "ALL=(ALL) NOPASSWD:ALL" || !user.GetLockPasswd() {
+		t.Errorf("NewUsersFromConfiguration did not copy the user settings correctly")
+	}
+
+	if !reflect.DeepEqual(user.GetKeys(), []string{key}) {
+		t.Errorf("GetKeys returned %v, want %v", user.GetKeys(), []string{key})
+	}
+
+	if !reflect.DeepEqual(user.GetGroups(), []string{"sudo", "adm"}) {
+		t.Errorf("GetGroups returned %v, want %v", user.GetGroups(), []string{"sudo", "adm"})
+	}
+}
+
+// TestValidateUsers tests the ValidateUsers function.
+func TestValidateUsers(t *testing.T) {
+	tests := []struct {
+		name      string
+		users     []*User
+		expectErr bool
+	}{
+		{"Valid", []*User{NewUser("ops").SetGroups([]string{"sudo"}).SetShell("/bin/bash"), NewUser("app")}, false},
+		{"Invalid Name", []*User{NewUser("Ops Team")}, true},
+		{"Root", []*User{NewUser("root")}, true},
+		{"Default User", []*User{NewUser("administrator")}, true},
+		{"Duplicate", []*User{NewUser("ops"), NewUser("ops")}, true},
+		{"Invalid Group", []*User{NewUser("ops").SetGroups([]string{"wheel;"})}, true},
+		{"Relative Shell", []*User{NewUser("ops").SetShell("bash")}, true},
+		{"Multiline Sudo", []*User{NewUser("ops").SetSudo("ALL=(ALL) ALL\nops ALL=(ALL) NOPASSWD:ALL")}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := ValidateUsers("administrator", test.users); (err != nil) != test.expectErr {
+				t.Errorf("ValidateUsers() error = %v, expectErr %v", err, test.expectErr)
+			}
+		})
+	}
+}
+
+// TestRenderUsersVendorData tests the RenderUsersVendorData function.
+func TestRenderUsersVendorData(t *testing.T) {
+	key := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS ops@example.com"
+
+	cloudInit := NewCloudInitConfiguration()
+	cloudInit.SetUsername("administrator")
+	cloudInit.SetUsers([]*User{
+		NewUser("ops").SetKeys([]string{key}).SetGroups([]string{"sudo", "adm"}).SetShell("/bin/bash").SetSudo("ALL=(ALL) NOPASSWD:ALL").SetLockPasswd(true),
+		NewUser("app"),
+	})
+
+	result, err := cloudInit.RenderUsersVendorData()
+	if err != nil {
+		t.Fatalf("RenderUsersVendorData returned error: %v", err)
+	}
+
+	expected := `#cloud-config
+write_files:
+  - path: /etc/sudoers.d/90-ptm-users
+    permissions: "0440"
+    content: |
+      ops ALL=(ALL) NOPASSWD:ALL
+runcmd:
+  - getent group 'sudo' >/dev/null 2>&1 || if command -v groupadd >/dev/null 2>&1; then groupadd 'sudo'; else addgroup 'sudo'; fi
+  - getent group 'adm' >/dev/null 2>&1 || if command -v groupadd >/dev/null 2>&1; then groupadd 'adm'; else addgroup 'adm'; fi
+  - id -u 'ops' >/dev/null 2>&1 || if command -v useradd >/dev/null 2>&1; then useradd -m -s '/bin/bash' -G 'sudo,adm' 'ops'; else adduser -D -s '/bin/bash' 'ops'; fi
+  - command -v useradd >/dev/null 2>&1 || for group in 'sudo' 'adm'; do addgroup 'ops' "$group"; done
+  - passwd -l 'ops'
+  - 'home=$(awk -F: -v user=''ops'' ''$1 == user { print $6 }'' /etc/passwd) && [ -n "$home" ] && [ "$home" != / ] && mkdir -p "$home/.ssh" && printf ''%s\n'' ''ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS ops@example.com'' > "$home/.ssh/authorized_keys" && chmod 700 "$home/.ssh" && chmod 600 "$home/.ssh/authorized_keys" && chown -R ''ops'' "$home/.ssh"'
+  - id -u 'app' >/dev/null 2>&1 || if command -v useradd >/dev/null 2>&1; then useradd -m 'app'; else adduser -D 'app'; fi
+`
+
+	if result != expected {
+		t.Errorf("RenderUsersVendorData generated incorrect vendor-data.\nExpected:\n%s\n\nActual:\n%s", expected, result)
+	}
+
+	if err := ValidateCustomSnippet(SnippetTypeVendor, result); err != nil {
+		t.Errorf("RenderUsersVendorData generated invalid vendor-data: %v", err)
+	}
+
+	for _, forbidden := range []string{"users:", "hostname:", "password:"} {
+		if strings.Contains(result, forbidden) {
+			t.Errorf("RenderUsersVendorData rendered %q, which replaces the user-data generated by Proxmox VE", forbidden)
+		}
+	}
+}
+
+// TestRenderUserCommands runs the commands rendered for a user against stubbed system tools.
+func TestRenderUserCommands(t *testing.T) {
+	shell, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh is not installed")
+	}
+
+	mkdir, err := exec.LookPath("mkdir")
+	if err != nil {
+		t.Skip("mkdir is not installed")
+	}
+
+	key := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS ops@example.com"
+
+	tests := []struct {
+		name        string
+		useraddExit int
+		createHome  bool
+		expected    []string
+		unexpected  []string
+	}{
+		{
+			"missing group is created before the user",
+			0,
+			true,
+			[]string{"groupadd docker", "useradd -m -G docker ops", "mkdir -p"},
+			[]string{},
+		},
+		{
+			"keys are not installed when the user is not created",
+			6,
+			false,
+			[]string{"groupadd docker", "useradd -m -G docker ops"},
+			[]string{"mkdir", "chmod", "chown"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			directory := t.TempDir()
+			bin := filepath.Join(directory, "bin")
+			home := filepath.Join(directory, "home")
+			log := filepath.Join(directory, "log")
+
+			passwd := ""
+			if test.createHome {
+				passwd = home
+			}
+
+			stubs := map[string]string{
+				"getent":   "exit 2",
+				"id":       "exit 1",
+				"groupadd": "",
+				"useradd":  fmt.Sprintf("exit %d", test.useraddExit),
+				"awk":      fmt.Sprintf("printf '%%s' '%s'", passwd),
+				"mkdir":    fmt.Sprintf("exec %s \"$@\"", mkdir),
+				"chmod":    "",
+				"chown":    "",
+			}
+
+			if err := os.Mkdir(bin, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			for name, body := range stubs {
+				script := fmt.Sprintf("#!%s\necho \"%s $*\" >> %s\n%s\n", shell, name, log, body)
+				if err := os.WriteFile(filepath.Join(bin, name), []byte(script), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for _, command := range renderUserCommands(NewUser("ops").SetGroups([]string{"docker"}).SetKeys([]string{key})) {
+				runner := exec.Command(shell, "-c", command)
+				runner.Env = []string{"PATH=" + bin}
+				_ = runner.Run()
+			}
+
+			content, _ := os.ReadFile(log)
+			calls := string(content)
+
+			position := -1
+			for _, call := range test.expected {
+				index := strings.Index(calls, call)
+				if index <= position {
+					t.Errorf("expected %q to be called after the previous calls, calls:\n%s", call, calls)
+				}
+				position = index
+			}
+
+			for _, call := range test.unexpected {
+				if strings.Contains(calls, call) {
+					t.Errorf("expected %q not to be called, calls:\n%s", call, calls)
+				}
+			}
+
+			keys, err := os.ReadFile(filepath.Join(home, ".ssh", "authorized_keys"))
+			if test.createHome && (err != nil || strings.TrimSpace(string(keys)) != key) {
+				t.Errorf("authorized_keys contains %q (%v), want the key of the user", keys, err)
+			}
+		})
+	}
+}
+
+// TestApplyUsersVendorData tests the ApplyUsersVendorData function.
+func TestApplyUsersVendorData(t *testing.T) {
+	cloudInit := NewCloudInitConfiguration()
+	if err := cloudInit.ApplyUsersVendorData(); err != nil || cloudInit.HasCustomSnippets() {
+		t.Errorf("ApplyUsersVendorData without users returned %v and set custom snippets", err)
+	}
+
+	cloudInit.SetUsers([]*User{NewUser("ops")})
+	if err := cloudInit.ApplyUsersVendorData(); err != nil {
+		t.Fatalf("ApplyUsersVendorData returned error: %v", err)
+	}
+
+	if cloudInit.GetCustomSnippet(SnippetTypeVendor) == "" {
+		t.Errorf("ApplyUsersVendorData did not set the custom vendor-data")
+	}
+
+	if cloudInit.GetCustomSnippet(SnippetTypeUser) != "" {
+		t.Errorf("ApplyUsersVendorData replaced the user-data generated by Proxmox VE")
+	}
+
+	if err := cloudInit.ApplyUsersVendorData(); err == nil {
+		t.Errorf("ApplyUsersVendorData expected error when custom vendor-data is already set")
+	}
+}
@@ -0,0 +1,14 @@
+package command
+
+// NewArchitectureCommand creates a new architecture command (sets machine type and firmware required for aarch64).
+func NewArchitectureCommand(identifier int, architecture string) *Command {
+	return NewSetCommand(
+		identifier,
+		"--arch",
+		architecture,
+		"--machine",
+		"virt",
+		"--bios",
+		"ovmf",
+	)
+}
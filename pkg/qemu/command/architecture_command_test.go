@@ -0,0 +1,24 @@
+package command
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestNewArchitectureCommand tests the NewArchitectureCommand function.
+func TestNewArchitectureCommand(t *testing.T) {
+	identifier := 1
+	cmd := NewArchitectureCommand(identifier, "aarch64")
+
+	if cmd.GetCommand() != qemuCommandSet || cmd.GetIdentifier() != identifier {
+		t.Errorf("TestNewArchitectureCommand did not set command and identifier correctly")
+	}
+
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--arch", "aarch64", "--machine", "virt", "--bios", "ovmf"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
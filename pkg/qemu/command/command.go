@@ -14,6 +14,8 @@ const (
 	qemuCommandDisk = "disk"
 	// qemuCommandTemplate is the command to manage QEMU VM templates.
 	qemuCommandTemplate = "template"
+	// qemuCommandCloudInit is the command to manage QEMU VM cloud-init drives.
+	qemuCommandCloudInit = "cloudinit"
 )
 
 // Command is a structure that holds information for QEMU command.
@@ -67,6 +69,11 @@ func NewTemplateCommand(identifier int, arguments ...interface{}) *Command {
 	return NewCommand(qemuCommandTemplate, identifier, arguments...)
 }
 
+// NewCloudInitCommand creates a new QEMU cloud-init command.
+func NewCloudInitCommand(identifier int, arguments ...interface{}) *Command {
+	return NewCommand(qemuCommandCloudInit, identifier, arguments...)
+}
+
 // SetOrder sets the order of the command.
 func (command *Command) SetOrder(order int) *Command {
 	command.order = order
@@ -99,7 +106,7 @@ func (command *Command) BuildExecutionerCommand() []string {
 		command.GetCommand(),
 	}
 
-	if command.GetCommand() != qemuCommandDisk {
+	if command.GetCommand() != qemuCommandDisk && command.GetCommand() != qemuCommandCloudInit {
 		commandParts = append(commandParts, strconv.Itoa(command.GetIdentifier()))
 
 		for _, argument := range command.GetArguments() {
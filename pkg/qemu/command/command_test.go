@@ -80,6 +80,22 @@ func TestNewTemplateCommand(t *testing.T) {
 	}
 }
 
+// TestNewCloudInitCommand tests the NewCloudInitCommand function.
+func TestNewCloudInitCommand(t *testing.T) {
+	identifier := 1
+	arguments := []interface{}{"dump", "%VM_ID%", "user"}
+	cmd := NewCloudInitCommand(identifier, arguments...)
+
+	if cmd.GetCommand() != qemuCommandCloudInit || cmd.GetIdentifier() != identifier {
+		t.Errorf("NewCloudInitCommand did not set command and identifier correctly")
+	}
+
+	expected := []string{qemuCommandCloudInit, "dump", "1", "user"}
+	if result := cmd.BuildExecutionerCommand(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
+
 // TestGetOrder tests the GetOrder function.
 func TestGetOrder(t *testing.T) {
 	identifier := 1
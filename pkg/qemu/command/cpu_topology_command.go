@@ -0,0 +1,35 @@
+package command
+
+// NewCpuTopologyCommand creates a new cpu topology command (sockets, vcpus, numa, cpulimit, cpuunits, affinity).
+func NewCpuTopologyCommand(identifier int, sockets int, vcpus int, numa bool, cpuLimit float64, cpuUnits int, affinity string) *Command {
+	arguments := make([]interface{}, 0)
+
+	if sockets > 0 {
+		arguments = append(arguments, "--sockets", sockets)
+	}
+
+	if vcpus > 0 {
+		arguments = append(arguments, "--vcpus", vcpus)
+	}
+
+	if numa {
+		arguments = append(arguments, "--numa", 1)
+	}
+
+	if cpuLimit > 0 {
+		arguments = append(arguments, "--cpulimit", cpuLimit)
+	}
+
+	if cpuUnits > 0 {
+		arguments = append(arguments, "--cpuunits", cpuUnits)
+	}
+
+	if affinity != "" {
+		arguments = append(arguments, "--affinity", affinity)
+	}
+
+	return NewSetCommand(
+		identifier,
+		arguments...,
+	)
+}
@@ -0,0 +1,38 @@
+package command
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestNewCpuTopologyCommand tests the NewCpuTopologyCommand function.
+func TestNewCpuTopologyCommand(t *testing.T) {
+	identifier := 1
+	cmd := NewCpuTopologyCommand(identifier, 2, 3, true, 1.5, 2048, "0-3")
+
+	if cmd.GetCommand() != qemuCommandSet || cmd.GetIdentifier() != identifier {
+		t.Errorf("TestNewCpuTopologyCommand did not set command and identifier correctly")
+	}
+
+	arguments := []string{"--sockets", "2", "--vcpus", "3", "--numa", "1", "--cpulimit", "1.5", "--cpuunits", "2048", "--affinity", "0-3"}
+	if !reflect.DeepEqual(cmd.GetArguments(), arguments) {
+		t.Errorf("TestNewCpuTopologyCommand did not set arguments correctly, got %v", cmd.GetArguments())
+	}
+
+	expected := append([]string{qemuCommandSet, strconv.Itoa(identifier)}, arguments...)
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
+
+// TestNewCpuTopologyCommandWithPartialOptions tests the NewCpuTopologyCommand function with only some options set.
+func TestNewCpuTopologyCommandWithPartialOptions(t *testing.T) {
+	cmd := NewCpuTopologyCommand(1, 2, 0, false, 0, 0, "")
+
+	if !reflect.DeepEqual(cmd.GetArguments(), []string{"--sockets", "2"}) {
+		t.Errorf("TestNewCpuTopologyCommandWithPartialOptions did not set arguments correctly, got %v", cmd.GetArguments())
+	}
+}
@@ -0,0 +1,10 @@
+package command
+
+// NewCloudCustomCommand creates a new cloud-init custom snippets command (cicustom).
+func NewCloudCustomCommand(identifier int, cicustom string) *Command {
+	return NewSetCommand(
+		identifier,
+		"--cicustom",
+		cicustom,
+	)
+}
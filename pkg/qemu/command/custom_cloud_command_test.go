@@ -0,0 +1,25 @@
+package command
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestNewCloudCustomCommand tests the NewCloudCustomCommand function.
+func TestNewCloudCustomCommand(t *testing.T) {
+	identifier := 1
+	cicustom := "user=local:snippets/ptm-1-user-data.yaml,network=local:snippets/ptm-1-network-data.yaml"
+	cmd := NewCloudCustomCommand(identifier, cicustom)
+
+	if cmd.GetCommand() != qemuCommandSet || cmd.GetIdentifier() != identifier {
+		t.Errorf("TestNewCloudCustomCommand did not set command and identifier correctly")
+	}
+
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--cicustom", cicustom}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
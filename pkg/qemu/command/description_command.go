@@ -0,0 +1,10 @@
+package command
+
+// NewDescriptionCommand creates a new description command.
+func NewDescriptionCommand(identifier int, description string) *Command {
+	return NewSetCommand(
+		identifier,
+		"--description",
+		description,
+	)
+}
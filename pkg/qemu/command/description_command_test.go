@@ -0,0 +1,25 @@
+package command
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestNewDescriptionCommand tests the NewDescriptionCommand function.
+func TestNewDescriptionCommand(t *testing.T) {
+	identifier := 1
+	description := "Base image: ubuntu noble (amd64, img)\nSerial: 20261001"
+	cmd := NewDescriptionCommand(identifier, description)
+
+	if cmd.GetCommand() != qemuCommandSet || cmd.GetIdentifier() != identifier {
+		t.Errorf("TestNewDescriptionCommand did not set command and identifier correctly")
+	}
+
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--description", description}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
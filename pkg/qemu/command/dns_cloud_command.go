@@ -0,0 +1,24 @@
+package command
+
+import (
+	ci "github.com/darki73/ptm/pkg/qemu/cloud-init"
+	"strings"
+)
+
+// NewDnsCloudCommand creates a new cloud DNS command (nameservers and search domains).
+func NewDnsCloudCommand(identifier int, cloudInit *ci.CloudInit) *Command {
+	arguments := make([]interface{}, 0)
+
+	if len(cloudInit.GetNameservers()) > 0 {
+		arguments = append(arguments, "--nameserver", strings.Join(cloudInit.GetNameservers(), " "))
+	}
+
+	if len(cloudInit.GetSearchDomains()) > 0 {
+		arguments = append(arguments, "--searchdomain", strings.Join(cloudInit.GetSearchDomains(), " "))
+	}
+
+	return NewSetCommand(
+		identifier,
+		arguments...,
+	)
+}
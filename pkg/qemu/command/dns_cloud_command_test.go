@@ -0,0 +1,58 @@
+package command
+
+import (
+	ci "github.com/darki73/ptm/pkg/qemu/cloud-init"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestNewDnsCloudCommand tests the NewDnsCloudCommand function.
+func TestNewDnsCloudCommand(t *testing.T) {
+	identifier := 1
+
+	tests := []struct {
+		name          string
+		nameservers   []string
+		searchDomains []string
+		expected      []string
+	}{
+		{
+			"NameserversAndSearchDomains",
+			[]string{"1.1.1.1", "2606:4700:4700::1111"},
+			[]string{"example.com", "lab.internal"},
+			[]string{qemuCommandSet, strconv.Itoa(identifier), "--nameserver", "1.1.1.1 2606:4700:4700::1111", "--searchdomain", "example.com lab.internal"},
+		},
+		{
+			"NameserversOnly",
+			[]string{"10.0.0.53"},
+			[]string{},
+			[]string{qemuCommandSet, strconv.Itoa(identifier), "--nameserver", "10.0.0.53"},
+		},
+		{
+			"SearchDomainsOnly",
+			[]string{},
+			[]string{"example.com"},
+			[]string{qemuCommandSet, strconv.Itoa(identifier), "--searchdomain", "example.com"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cloudInit := ci.NewCloudInitConfiguration()
+			cloudInit.SetNameservers(test.nameservers).SetSearchDomains(test.searchDomains)
+
+			cmd := NewDnsCloudCommand(identifier, cloudInit)
+
+			if cmd.GetCommand() != qemuCommandSet || cmd.GetIdentifier() != identifier {
+				t.Errorf("TestNewDnsCloudCommand did not set command and identifier correctly")
+			}
+
+			result := cmd.BuildExecutionerCommand()
+
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("BuildExecutionerCommand returned %v, want %v", result, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package command
+
+// NewCloudDumpCommand creates a new command which dumps the cloud-init data of the given type (qm cloudinit dump).
+func NewCloudDumpCommand(identifier int, dataType string) *Command {
+	return NewCloudInitCommand(
+		identifier,
+		"dump",
+		"%VM_ID%",
+		dataType,
+	)
+}
@@ -0,0 +1,24 @@
+package command
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestNewCloudDumpCommand tests the NewCloudDumpCommand function.
+func TestNewCloudDumpCommand(t *testing.T) {
+	identifier := 1
+	cmd := NewCloudDumpCommand(identifier, "network")
+
+	if cmd.GetCommand() != qemuCommandCloudInit || cmd.GetIdentifier() != identifier {
+		t.Errorf("TestNewCloudDumpCommand did not set command and identifier correctly")
+	}
+
+	expected := []string{qemuCommandCloudInit, "dump", strconv.Itoa(identifier), "network"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
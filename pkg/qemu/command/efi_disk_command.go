@@ -0,0 +1,15 @@
+package command
+
+import "fmt"
+
+// NewEfiDiskCommand creates a new EFI disk command (efidisk0).
+func NewEfiDiskCommand(identifier int, storage string) *Command {
+	return NewSetCommand(
+		identifier,
+		"--efidisk0",
+		fmt.Sprintf(
+			"%s:1,efitype=4m",
+			storage,
+		),
+	)
+}
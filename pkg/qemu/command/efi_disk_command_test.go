@@ -0,0 +1,24 @@
+package command
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestNewEfiDiskCommand tests the NewEfiDiskCommand function.
+func TestNewEfiDiskCommand(t *testing.T) {
+	identifier := 1
+	cmd := NewEfiDiskCommand(identifier, "local-lvm")
+
+	if cmd.GetCommand() != qemuCommandSet || cmd.GetIdentifier() != identifier {
+		t.Errorf("TestNewEfiDiskCommand did not set command and identifier correctly")
+	}
+
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--efidisk0", "local-lvm:1,efitype=4m"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
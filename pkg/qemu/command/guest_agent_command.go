@@ -7,12 +7,23 @@ import (
 
 // NewGuestAgentCommand returns a new GuestAgentCommand.
 func NewGuestAgentCommand(identifier int, enabled bool, trim bool) *Command {
-	enabledInt := utils.BooleanToInteger(enabled)
-	trimInt := utils.BooleanToInteger(trim)
+	return NewSetCommand(
+		identifier,
+		"--agent",
+		buildGuestAgentOptions(enabled, trim),
+	)
+}
 
-	guestAgent := fmt.Sprintf("enabled=%d", enabledInt)
-	if trim {
-		guestAgent = fmt.Sprintf("%s,fstrim_cloned_disks=%d", guestAgent, trimInt)
+// NewGuestAgentOptionsCommand returns a new GuestAgentCommand with all guest agent options.
+func NewGuestAgentOptionsCommand(identifier int, enabled bool, trim bool, freezeFs bool, agentType string) *Command {
+	guestAgent := buildGuestAgentOptions(enabled, trim)
+
+	if !freezeFs {
+		guestAgent = fmt.Sprintf("%s,freeze-fs-on-backup=0", guestAgent)
+	}
+
+	if agentType != "" && agentType != "virtio" {
+		guestAgent = fmt.Sprintf("%s,type=%s", guestAgent, agentType)
 	}
 
 	return NewSetCommand(
@@ -21,3 +32,16 @@ func NewGuestAgentCommand(identifier int, enabled bool, trim bool) *Command {
 		guestAgent,
 	)
 }
+
+// buildGuestAgentOptions builds the enabled and fstrim_cloned_disks guest agent options.
+func buildGuestAgentOptions(enabled bool, trim bool) string {
+	enabledInt := utils.BooleanToInteger(enabled)
+	trimInt := utils.BooleanToInteger(trim)
+
+	guestAgent := fmt.Sprintf("enabled=%d", enabledInt)
+	if trim {
+		guestAgent = fmt.Sprintf("%s,fstrim_cloned_disks=%d", guestAgent, trimInt)
+	}
+
+	return guestAgent
+}
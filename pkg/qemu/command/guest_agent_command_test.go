@@ -89,3 +89,34 @@ func TestNewGuestAgentCommandWithAgentDisabledAndTrimEnabled(t *testing.T) {
 		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
 	}
 }
+
+// TestNewGuestAgentOptionsCommand tests the NewGuestAgentOptionsCommand function.
+func TestNewGuestAgentOptionsCommand(t *testing.T) {
+	identifier := 1
+	testCases := []struct {
+		name      string
+		enabled   bool
+		trim      bool
+		freezeFs  bool
+		agentType string
+		expected  string
+	}{
+		{"Defaults", true, true, true, "virtio", "enabled=1,fstrim_cloned_disks=1"},
+		{"Freeze Disabled", true, true, false, "virtio", "enabled=1,fstrim_cloned_disks=1,freeze-fs-on-backup=0"},
+		{"Isa Type", true, false, true, "isa", "enabled=1,type=isa"},
+		{"Disabled", false, false, true, "", "enabled=0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewGuestAgentOptionsCommand(identifier, tc.enabled, tc.trim, tc.freezeFs, tc.agentType)
+
+			expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--agent", tc.expected}
+			result := cmd.BuildExecutionerCommand()
+
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+			}
+		})
+	}
+}
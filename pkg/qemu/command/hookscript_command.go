@@ -0,0 +1,10 @@
+package command
+
+// NewHookscriptCommand creates a new hookscript command.
+func NewHookscriptCommand(identifier int, volume string) *Command {
+	return NewSetCommand(
+		identifier,
+		"--hookscript",
+		volume,
+	)
+}
@@ -0,0 +1,24 @@
+package command
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestNewHookscriptCommand tests the NewHookscriptCommand function.
+func TestNewHookscriptCommand(t *testing.T) {
+	identifier := 1
+	cmd := NewHookscriptCommand(identifier, "local:snippets/ptm-ubuntu.sh")
+
+	if cmd.GetCommand() != qemuCommandSet || cmd.GetIdentifier() != identifier {
+		t.Errorf("TestNewHookscriptCommand did not set command and identifier correctly")
+	}
+
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--hookscript", "local:snippets/ptm-ubuntu.sh"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
@@ -0,0 +1,12 @@
+package command
+
+import "strings"
+
+// NewHotplugCommand creates a new hotplug command.
+func NewHotplugCommand(identifier int, devices []string) *Command {
+	return NewSetCommand(
+		identifier,
+		"--hotplug",
+		strings.Join(devices, ","),
+	)
+}
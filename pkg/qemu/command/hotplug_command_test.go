@@ -0,0 +1,28 @@
+package command
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestNewHotplugCommand tests the NewHotplugCommand function.
+func TestNewHotplugCommand(t *testing.T) {
+	identifier := 1
+	cmd := NewHotplugCommand(identifier, []string{"disk", "network", "memory", "cpu"})
+
+	if cmd.GetCommand() != qemuCommandSet || cmd.GetIdentifier() != identifier {
+		t.Errorf("TestNewHotplugCommand did not set command and identifier correctly")
+	}
+
+	if !reflect.DeepEqual(cmd.GetArguments(), []string{"--hotplug", "disk,network,memory,cpu"}) {
+		t.Errorf("TestNewHotplugCommand did not set arguments correctly")
+	}
+
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--hotplug", "disk,network,memory,cpu"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
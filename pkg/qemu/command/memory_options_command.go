@@ -0,0 +1,21 @@
+package command
+
+// NewMemoryOptionsCommand creates a new memory options command (balloon, hugepages).
+func NewMemoryOptionsCommand(identifier int, ballooning bool, minimumMemory int, hugepages string) *Command {
+	arguments := make([]interface{}, 0)
+
+	if !ballooning {
+		arguments = append(arguments, "--balloon", 0)
+	} else if minimumMemory > 0 {
+		arguments = append(arguments, "--balloon", minimumMemory)
+	}
+
+	if hugepages != "" {
+		arguments = append(arguments, "--hugepages", hugepages)
+	}
+
+	return NewSetCommand(
+		identifier,
+		arguments...,
+	)
+}
@@ -0,0 +1,37 @@
+package command
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestNewMemoryOptionsCommand tests the NewMemoryOptionsCommand function.
+func TestNewMemoryOptionsCommand(t *testing.T) {
+	identifier := 1
+	cmd := NewMemoryOptionsCommand(identifier, true, 1024, "2")
+
+	if cmd.GetCommand() != qemuCommandSet || cmd.GetIdentifier() != identifier {
+		t.Errorf("TestNewMemoryOptionsCommand did not set command and identifier correctly")
+	}
+
+	if !reflect.DeepEqual(cmd.GetArguments(), []string{"--balloon", "1024", "--hugepages", "2"}) {
+		t.Errorf("TestNewMemoryOptionsCommand did not set arguments correctly, got %v", cmd.GetArguments())
+	}
+
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--balloon", "1024", "--hugepages", "2"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
+
+// TestNewMemoryOptionsCommandWithBallooningDisabled tests the NewMemoryOptionsCommand function with ballooning disabled.
+func TestNewMemoryOptionsCommandWithBallooningDisabled(t *testing.T) {
+	cmd := NewMemoryOptionsCommand(1, false, 0, "")
+
+	if !reflect.DeepEqual(cmd.GetArguments(), []string{"--balloon", "0"}) {
+		t.Errorf("TestNewMemoryOptionsCommandWithBallooningDisabled did not set arguments correctly, got %v", cmd.GetArguments())
+	}
+}
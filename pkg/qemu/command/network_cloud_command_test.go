@@ -43,11 +43,11 @@ func TestNewNetworkCloudCommandWithManualConfigurationForIPv4AndAutoConfiguratio
 		t.Errorf("TestNewNetworkCloudCommandWithManualConfigurationForIPv4AndAutoConfigurationForIPv6 did not set command and identifier correctly")
 	}
 
-	if !reflect.DeepEqual(cmd.GetArguments(), []string{"--ipconfig0", "ip6=auto,gw4=127.0.0.1,ip=127.0.0.1/24"}) {
+	if !reflect.DeepEqual(cmd.GetArguments(), []string{"--ipconfig0", "ip6=auto,gw=127.0.0.1,ip=127.0.0.1/24"}) {
 		t.Errorf("TestNewNetworkCloudCommandWithManualConfigurationForIPv4AndAutoConfigurationForIPv6 did not set arguments correctly")
 	}
 
-	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--ipconfig0", "ip6=auto,gw4=127.0.0.1,ip=127.0.0.1/24"}
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--ipconfig0", "ip6=auto,gw=127.0.0.1,ip=127.0.0.1/24"}
 	result := cmd.BuildExecutionerCommand()
 
 	if !reflect.DeepEqual(result, expected) {
@@ -91,14 +91,42 @@ func TestNewNetworkCloudCommandWithManualConfigurationForIPv4AndIPv6(t *testing.
 		t.Errorf("TestNewNetworkCloudCommandWithManualConfigurationForIPv4AndIPv6 did not set command and identifier correctly")
 	}
 
-	if !reflect.DeepEqual(cmd.GetArguments(), []string{"--ipconfig0", "gw6=::1,ip6=::1/64,gw4=127.0.0.1,ip=127.0.0.1/24"}) {
+	if !reflect.DeepEqual(cmd.GetArguments(), []string{"--ipconfig0", "gw6=::1,ip6=::1/64,gw=127.0.0.1,ip=127.0.0.1/24"}) {
 		t.Errorf("TestNewNetworkCloudCommandWithManualConfigurationForIPv4AndIPv6 did not set arguments correctly")
 	}
 
-	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--ipconfig0", "gw6=::1,ip6=::1/64,gw4=127.0.0.1,ip=127.0.0.1/24"}
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--ipconfig0", "gw6=::1,ip6=::1/64,gw=127.0.0.1,ip=127.0.0.1/24"}
 	result := cmd.BuildExecutionerCommand()
 
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
 	}
 }
+
+// TestNewNetworkCloudCommandWithAddressModes tests the NewNetworkCloudCommand function with explicit address modes.
+func TestNewNetworkCloudCommandWithAddressModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		ipv4Mode string
+		ipv6Mode string
+		expected string
+	}{
+		{"DHCP And DHCPv6", ci.AddressModeDhcp, ci.AddressModeDhcpv6, "ip6=dhcp,ip=dhcp"},
+		{"DHCP Only", ci.AddressModeDhcp, ci.AddressModeNone, "ip=dhcp"},
+		{"SLAAC Only", ci.AddressModeNone, ci.AddressModeSlaac, "ip6=auto"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cloudInit := ci.NewCloudInitConfiguration()
+			cloudInit.SetIPv4Mode(test.ipv4Mode).SetIPv6Mode(test.ipv6Mode)
+
+			expected := []string{qemuCommandSet, strconv.Itoa(1), "--ipconfig0", test.expected}
+			result := NewNetworkCloudCommand(1, cloudInit).BuildExecutionerCommand()
+
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	ci "github.com/darki73/ptm/pkg/qemu/cloud-init"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -11,7 +12,8 @@ import (
 func TestNewCloudPasswordCommand(t *testing.T) {
 	identifier := 1
 	cloudInit := ci.NewCloudInitConfiguration()
-	cloudInit.SetPassword("password")
+	hash := "$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1"
+	cloudInit.SetPassword(hash)
 
 	cmd := NewCloudPasswordCommand(identifier, cloudInit)
 
@@ -19,14 +21,32 @@ func TestNewCloudPasswordCommand(t *testing.T) {
 		t.Errorf("TestNewCloudPasswordCommand did not set command and identifier correctly")
 	}
 
-	if !reflect.DeepEqual(cmd.GetArguments(), []string{"--cipassword", "password"}) {
+	if !reflect.DeepEqual(cmd.GetArguments(), []string{"--cipassword", hash}) {
 		t.Errorf("TestNewCloudPasswordCommand did not set arguments correctly")
 	}
 
-	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--cipassword", "password"}
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--cipassword", hash}
 	result := cmd.BuildExecutionerCommand()
 
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
 	}
 }
+
+// TestNewCloudPasswordCommandDoesNotExposePlainTextPassword tests that the NewCloudPasswordCommand function only passes the password hash.
+func TestNewCloudPasswordCommandDoesNotExposePlainTextPassword(t *testing.T) {
+	cloudInit := ci.NewCloudInitConfiguration()
+	cloudInit.SetPassword("password")
+
+	cmd := NewCloudPasswordCommand(1, cloudInit)
+
+	for _, argument := range cmd.BuildExecutionerCommand() {
+		if argument == "password" {
+			t.Errorf("BuildExecutionerCommand exposed the plain text password")
+		}
+	}
+
+	if cmd.GetArguments()[1] != cloudInit.GetPassword() || !strings.HasPrefix(cmd.GetArguments()[1], "$6$") {
+		t.Errorf("NewCloudPasswordCommand did not pass the password hash")
+	}
+}
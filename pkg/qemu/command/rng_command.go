@@ -0,0 +1,22 @@
+package command
+
+import "fmt"
+
+// NewRngCommand creates a new VirtIO RNG device command.
+func NewRngCommand(identifier int, source string, maxBytes int, period int) *Command {
+	rng := fmt.Sprintf("source=%s", source)
+
+	if maxBytes > 0 {
+		rng = fmt.Sprintf("%s,max_bytes=%d", rng, maxBytes)
+	}
+
+	if period > 0 {
+		rng = fmt.Sprintf("%s,period=%d", rng, period)
+	}
+
+	return NewSetCommand(
+		identifier,
+		"--rng0",
+		rng,
+	)
+}
@@ -0,0 +1,41 @@
+package command
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestNewRngCommand tests the NewRngCommand function.
+func TestNewRngCommand(t *testing.T) {
+	identifier := 1
+	cmd := NewRngCommand(identifier, "/dev/urandom", 1024, 1000)
+
+	if cmd.GetCommand() != qemuCommandSet || cmd.GetIdentifier() != identifier {
+		t.Errorf("TestNewRngCommand did not set command and identifier correctly")
+	}
+
+	if !reflect.DeepEqual(cmd.GetArguments(), []string{"--rng0", "source=/dev/urandom,max_bytes=1024,period=1000"}) {
+		t.Errorf("TestNewRngCommand did not set arguments correctly")
+	}
+
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--rng0", "source=/dev/urandom,max_bytes=1024,period=1000"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
+
+// TestNewRngCommandWithSourceOnly tests the NewRngCommand function without limits.
+func TestNewRngCommandWithSourceOnly(t *testing.T) {
+	identifier := 1
+	cmd := NewRngCommand(identifier, "/dev/hwrng", 0, 0)
+
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--rng0", "source=/dev/hwrng"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
@@ -4,9 +4,14 @@ import "fmt"
 
 // NewCloudStorageCommand creates a new cloud storage command (ide2).
 func NewCloudStorageCommand(identifier int, storage string) *Command {
+	return NewCloudStorageDeviceCommand(identifier, "ide2", storage)
+}
+
+// NewCloudStorageDeviceCommand creates a new cloud storage command attached to the given device.
+func NewCloudStorageDeviceCommand(identifier int, device string, storage string) *Command {
 	return NewSetCommand(
 		identifier,
-		"--ide2",
+		fmt.Sprintf("--%s", device),
 		fmt.Sprintf(
 			"%s:cloudinit",
 			storage,
@@ -26,3 +26,16 @@ func TestNewCloudStorageCommand(t *testing.T) {
 		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
 	}
 }
+
+// TestNewCloudStorageDeviceCommand tests the NewCloudStorageDeviceCommand function.
+func TestNewCloudStorageDeviceCommand(t *testing.T) {
+	identifier := 1
+	cmd := NewCloudStorageDeviceCommand(identifier, "scsi1", "local-lvm")
+
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--scsi1", "local-lvm:cloudinit"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
@@ -0,0 +1,10 @@
+package command
+
+// NewCloudTypeCommand creates a new cloud-init drive type command (citype).
+func NewCloudTypeCommand(identifier int, citype string) *Command {
+	return NewSetCommand(
+		identifier,
+		"--citype",
+		citype,
+	)
+}
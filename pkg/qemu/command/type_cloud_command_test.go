@@ -0,0 +1,24 @@
+package command
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestNewCloudTypeCommand tests the NewCloudTypeCommand function.
+func TestNewCloudTypeCommand(t *testing.T) {
+	identifier := 1
+	cmd := NewCloudTypeCommand(identifier, "configdrive2")
+
+	if cmd.GetCommand() != qemuCommandSet || cmd.GetIdentifier() != identifier {
+		t.Errorf("TestNewCloudTypeCommand did not set command and identifier correctly")
+	}
+
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--citype", "configdrive2"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
@@ -0,0 +1,10 @@
+package command
+
+// NewCloudUpdateCommand creates a new command which regenerates the cloud-init drive (qm cloudinit update).
+func NewCloudUpdateCommand(identifier int) *Command {
+	return NewCloudInitCommand(
+		identifier,
+		"update",
+		"%VM_ID%",
+	)
+}
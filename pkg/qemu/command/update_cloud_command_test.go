@@ -0,0 +1,24 @@
+package command
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestNewCloudUpdateCommand tests the NewCloudUpdateCommand function.
+func TestNewCloudUpdateCommand(t *testing.T) {
+	identifier := 1
+	cmd := NewCloudUpdateCommand(identifier)
+
+	if cmd.GetCommand() != qemuCommandCloudInit || cmd.GetIdentifier() != identifier {
+		t.Errorf("TestNewCloudUpdateCommand did not set command and identifier correctly")
+	}
+
+	expected := []string{qemuCommandCloudInit, "update", strconv.Itoa(identifier)}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
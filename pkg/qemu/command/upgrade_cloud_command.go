@@ -0,0 +1,12 @@
+package command
+
+import "github.com/darki73/ptm/pkg/utils"
+
+// NewCloudUpgradeCommand creates a new cloud-init package upgrade command (ciupgrade).
+func NewCloudUpgradeCommand(identifier int, upgrade bool) *Command {
+	return NewSetCommand(
+		identifier,
+		"--ciupgrade",
+		utils.BooleanToInteger(upgrade),
+	)
+}
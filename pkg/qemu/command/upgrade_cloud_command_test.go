@@ -0,0 +1,28 @@
+package command
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestNewCloudUpgradeCommand tests the NewCloudUpgradeCommand function.
+func TestNewCloudUpgradeCommand(t *testing.T) {
+	identifier := 1
+
+	tests := []struct {
+		upgrade  bool
+		expected []string
+	}{
+		{true, []string{qemuCommandSet, strconv.Itoa(identifier), "--ciupgrade", "1"}},
+		{false, []string{qemuCommandSet, strconv.Itoa(identifier), "--ciupgrade", "0"}},
+	}
+
+	for _, test := range tests {
+		result := NewCloudUpgradeCommand(identifier, test.upgrade).BuildExecutionerCommand()
+
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("BuildExecutionerCommand returned %v, want %v", result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package command
+
+import "fmt"
+
+// NewWatchdogCommand creates a new watchdog device command.
+func NewWatchdogCommand(identifier int, model string, action string) *Command {
+	watchdog := fmt.Sprintf("model=%s", model)
+
+	if action != "" {
+		watchdog = fmt.Sprintf("%s,action=%s", watchdog, action)
+	}
+
+	return NewSetCommand(
+		identifier,
+		"--watchdog",
+		watchdog,
+	)
+}
@@ -0,0 +1,41 @@
+package command
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestNewWatchdogCommand tests the NewWatchdogCommand function.
+func TestNewWatchdogCommand(t *testing.T) {
+	identifier := 1
+	cmd := NewWatchdogCommand(identifier, "i6300esb", "reset")
+
+	if cmd.GetCommand() != qemuCommandSet || cmd.GetIdentifier() != identifier {
+		t.Errorf("TestNewWatchdogCommand did not set command and identifier correctly")
+	}
+
+	if !reflect.DeepEqual(cmd.GetArguments(), []string{"--watchdog", "model=i6300esb,action=reset"}) {
+		t.Errorf("TestNewWatchdogCommand did not set arguments correctly")
+	}
+
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--watchdog", "model=i6300esb,action=reset"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
+
+// TestNewWatchdogCommandWithoutAction tests the NewWatchdogCommand function without action.
+func TestNewWatchdogCommandWithoutAction(t *testing.T) {
+	identifier := 1
+	cmd := NewWatchdogCommand(identifier, "ib700", "")
+
+	expected := []string{qemuCommandSet, strconv.Itoa(identifier), "--watchdog", "model=ib700"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("BuildExecutionerCommand returned %v, want %v", result, expected)
+	}
+}
@@ -0,0 +1,93 @@
+package qemu
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/utils"
+	"strings"
+)
+
+var (
+	// supportedRngSources is the list of entropy sources which can be used by the VirtIO RNG device.
+	supportedRngSources = []string{
+		"/dev/urandom",
+		"/dev/random",
+		"/dev/hwrng",
+	}
+	// supportedGuestAgentTypes is the list of supported guest agent communication channel types.
+	supportedGuestAgentTypes = []string{
+		"virtio",
+		"isa",
+	}
+	// supportedWatchdogModels is the list of supported watchdog device models.
+	supportedWatchdogModels = []string{
+		"i6300esb",
+		"ib700",
+	}
+	// supportedWatchdogActions is the list of supported watchdog actions.
+	supportedWatchdogActions = []string{
+		"reset",
+		"shutdown",
+		"poweroff",
+		"pause",
+		"debug",
+		"none",
+	}
+)
+
+// ValidateDevices validates the guest agent, VirtIO RNG and watchdog devices configuration.
+func (qemu *Qemu) ValidateDevices() error {
+	if qemu.guestAgentType != "" && !utils.SliceContains(supportedGuestAgentTypes, qemu.guestAgentType) {
+		return fmt.Errorf(
+			"guest agent type `%s` is not supported, supported types: %s",
+			qemu.guestAgentType,
+			strings.Join(supportedGuestAgentTypes, ", "),
+		)
+	}
+
+	if qemu.HasRng() {
+		if !utils.SliceContains(supportedRngSources, qemu.rngSource) {
+			return fmt.Errorf(
+				"rng source `%s` is not supported, supported sources: %s",
+				qemu.rngSource,
+				strings.Join(supportedRngSources, ", "),
+			)
+		}
+
+		if qemu.rngMaxBytes < 0 {
+			return fmt.Errorf("rng max bytes must not be negative, got %d", qemu.rngMaxBytes)
+		}
+
+		if qemu.rngPeriod < 0 {
+			return fmt.Errorf("rng period must not be negative, got %d", qemu.rngPeriod)
+		}
+	}
+
+	if qemu.HasWatchdog() {
+		if err := ValidateWatchdogModel(qemu.watchdogModel); err != nil {
+			return err
+		}
+
+		if qemu.watchdogAction != "" && !utils.SliceContains(supportedWatchdogActions, qemu.watchdogAction) {
+			return fmt.Errorf(
+				"watchdog action `%s` is not supported, supported actions: %s",
+				qemu.watchdogAction,
+				strings.Join(supportedWatchdogActions, ", "),
+			)
+		}
+	}
+
+	return nil
+}
+
+// ValidateWatchdogModel validates the watchdog device model.
+func ValidateWatchdogModel(watchdogModel string) error {
+	if utils.SliceContains(supportedWatchdogModels, watchdogModel) {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"watchdog model `%s` is not supported, supported models: %s",
+		watchdogModel,
+		strings.Join(supportedWatchdogModels, ", "),
+	)
+}
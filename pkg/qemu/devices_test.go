@@ -0,0 +1,38 @@
+package qemu
+
+import (
+	"testing"
+)
+
+// TestValidateDevices tests the ValidateDevices function.
+func TestValidateDevices(t *testing.T) {
+	testCases := []struct {
+		name      string
+		configure func(qemu *Qemu)
+		expectErr bool
+	}{
+		{"No Devices", func(qemu *Qemu) {}, false},
+		{"Isa Guest Agent", func(qemu *Qemu) { qemu.SetGuestAgentType("isa") }, false},
+		{"Invalid Guest Agent Type", func(qemu *Qemu) { qemu.SetGuestAgentType("serial") }, true},
+		{"Valid Rng", func(qemu *Qemu) { qemu.SetRngSource("/dev/urandom").SetRngMaxBytes(1024).SetRngPeriod(1000) }, false},
+		{"Invalid Rng Source", func(qemu *Qemu) { qemu.SetRngSource("/dev/zero") }, true},
+		{"Negative Rng Max Bytes", func(qemu *Qemu) { qemu.SetRngSource("/dev/urandom").SetRngMaxBytes(-1) }, true},
+		{"Negative Rng Period", func(qemu *Qemu) { qemu.SetRngSource("/dev/urandom").SetRngPeriod(-1) }, true},
+		{"Valid Watchdog", func(qemu *Qemu) { qemu.SetWatchdogModel("i6300esb").SetWatchdogAction("reset") }, false},
+		{"Watchdog Without Action", func(qemu *Qemu) { qemu.SetWatchdogModel("ib700") }, false},
+		{"Invalid Watchdog Model", func(qemu *Qemu) { qemu.SetWatchdogModel("unknown") }, true},
+		{"Invalid Watchdog Action", func(qemu *Qemu) { qemu.SetWatchdogModel("i6300esb").SetWatchdogAction("explode") }, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			qemu := NewQemuConfiguration()
+			tc.configure(qemu)
+
+			err := qemu.ValidateDevices()
+			if (err != nil) != tc.expectErr {
+				t.Errorf("ValidateDevices() error = %v, expectErr %v", err, tc.expectErr)
+			}
+		})
+	}
+}
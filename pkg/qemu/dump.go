@@ -0,0 +1,23 @@
+package qemu
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/darki73/ptm/pkg/qemu/command"
+	"os/exec"
+)
+
+// DumpCloudInit returns the cloud-init data of the given type (user / network / meta) Proxmox VE generates for an existing virtual machine.
+func DumpCloudInit(identifier int, dataType string) (string, error) {
+	cmd := exec.Command(qemuCommand, command.NewCloudDumpCommand(identifier, dataType).BuildExecutionerCommand()...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to dump cloud-init %s data of virtual machine %d: %v, stderr: %s", dataType, identifier, err, stderr.String())
+	}
+
+	return stdout.String(), nil
+}
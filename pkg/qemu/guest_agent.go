@@ -0,0 +1,78 @@
+package qemu
+
+import (
+	"github.com/darki73/ptm/pkg/utils"
+)
+
+var (
+	// guestAgentBinaryPaths is the list of paths where the guest agent binary is installed.
+	guestAgentBinaryPaths = []string{
+		"/usr/bin/qemu-ga",
+		"/usr/sbin/qemu-ga",
+		"/usr/local/bin/qemu-ga",
+	}
+	// guestAgentActivationPaths is the list of paths which indicate that the guest agent is started on boot.
+	// Debian based distributions start the agent from udev, RHEL based ones use systemd device wants,
+	// others use regular systemd or OpenRC enablement.
+	guestAgentActivationPaths = []string{
+		"/lib/udev/rules.d/60-qemu-guest-agent.rules",
+		"/usr/lib/udev/rules.d/60-qemu-guest-agent.rules",
+		"/etc/systemd/system/multi-user.target.wants/qemu-guest-agent.service",
+		"/etc/systemd/system/dev-virtio\\x2dports-org.qemu.guest_agent.0.device.wants/qemu-guest-agent.service",
+		"/etc/runlevels/default/qemu-guest-agent",
+	}
+)
+
+// GuestAgentInspection holds the result of the guest agent inspection of the image.
+type GuestAgentInspection struct {
+	// installed indicates whether the guest agent is installed in the image.
+	installed bool
+	// enabled indicates whether the guest agent is started on boot.
+	enabled bool
+}
+
+// InspectGuestAgent checks (via libguestfs) whether the guest agent is installed and enabled in the image.
+func InspectGuestAgent(image string) (*GuestAgentInspection, error) {
+	paths := append(append([]string{}, guestAgentBinaryPaths...), guestAgentActivationPaths...)
+
+	existingPaths, err := utils.GuestPathsExist(image, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGuestAgentInspection(existingPaths), nil
+}
+
+// NewGuestAgentInspection creates a new guest agent inspection from the map of existing paths.
+func NewGuestAgentInspection(existingPaths map[string]bool) *GuestAgentInspection {
+	inspection := &GuestAgentInspection{}
+
+	for _, path := range guestAgentBinaryPaths {
+		if existingPaths[path] {
+			inspection.installed = true
+		}
+	}
+
+	for _, path := range guestAgentActivationPaths {
+		if existingPaths[path] {
+			inspection.enabled = true
+		}
+	}
+
+	return inspection
+}
+
+// IsInstalled returns true if the guest agent is installed in the image.
+func (inspection *GuestAgentInspection) IsInstalled() bool {
+	return inspection.installed
+}
+
+// IsEnabled returns true if the guest agent is started on boot.
+func (inspection *GuestAgentInspection) IsEnabled() bool {
+	return inspection.enabled
+}
+
+// IsOperational returns true if the guest agent is installed and started on boot.
+func (inspection *GuestAgentInspection) IsOperational() bool {
+	return inspection.installed && inspection.enabled
+}
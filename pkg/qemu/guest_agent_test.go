@@ -0,0 +1,48 @@
+package qemu
+
+import (
+	"testing"
+)
+
+// TestNewGuestAgentInspection tests the NewGuestAgentInspection function.
+func TestNewGuestAgentInspection(t *testing.T) {
+	testCases := []struct {
+		name              string
+		existingPaths     map[string]bool
+		expectedInstalled bool
+		expectedEnabled   bool
+	}{
+		{"Not Installed", map[string]bool{}, false, false},
+		{"Installed Not Enabled", map[string]bool{"/usr/sbin/qemu-ga": true}, true, false},
+		{
+			"Installed And Enabled Via Udev",
+			map[string]bool{"/usr/sbin/qemu-ga": true, "/lib/udev/rules.d/60-qemu-guest-agent.rules": true},
+			true,
+			true,
+		},
+		{
+			"Installed And Enabled Via OpenRC",
+			map[string]bool{"/usr/bin/qemu-ga": true, "/etc/runlevels/default/qemu-guest-agent": true},
+			true,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inspection := NewGuestAgentInspection(tc.existingPaths)
+
+			if inspection.IsInstalled() != tc.expectedInstalled {
+				t.Errorf("IsInstalled() = %v, want %v", inspection.IsInstalled(), tc.expectedInstalled)
+			}
+
+			if inspection.IsEnabled() != tc.expectedEnabled {
+				t.Errorf("IsEnabled() = %v, want %v", inspection.IsEnabled(), tc.expectedEnabled)
+			}
+
+			if inspection.IsOperational() != (tc.expectedInstalled && tc.expectedEnabled) {
+				t.Errorf("IsOperational() = %v, want %v", inspection.IsOperational(), tc.expectedInstalled && tc.expectedEnabled)
+			}
+		})
+	}
+}
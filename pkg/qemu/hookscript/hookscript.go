@@ -0,0 +1,95 @@
+package hookscript
+
+import (
+	"bytes"
+	"fmt"
+	hc "github.com/darki73/ptm/pkg/configuration/hookscript"
+	"regexp"
+	"text/template"
+)
+
+var (
+	// hookscriptNameRegex is the regular expression used to validate the hookscript file name.
+	hookscriptNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+)
+
+// hookscriptTemplate represents the template for the virtual machine hookscript.
+// Proxmox VE calls the hookscript with the virtual machine identifier and the phase name.
+const hookscriptTemplate = `#!/bin/bash
+# This file is managed by ptm, manual changes will be overwritten.
+# Usage: <script> <vmid> <phase>
+
+vmid="$1"
+phase="$2"
+
+case "${phase}" in
+{{- range .}}
+  {{ .Name }})
+	{{- range .Actions }}
+    {{ . }}
+	{{- end }}
+    ;;
+{{- end }}
+esac
+
+exit 0
+`
+
+// phase represents a single hookscript phase with its actions.
+type phase struct {
+	// Name is the name of the phase as passed by Proxmox VE.
+	Name string
+	// Actions is the list of commands executed during the phase.
+	Actions []string
+}
+
+// GetHookscriptTemplate returns the hookscript template.
+func GetHookscriptTemplate() string {
+	return hookscriptTemplate
+}
+
+// GetHookscriptName returns the file name of the hookscript (defaults to ptm-<template name>.sh).
+func GetHookscriptName(configuration *hc.Configuration, templateName string) (string, error) {
+	name := configuration.GetName()
+	if name == "" {
+		name = fmt.Sprintf("ptm-%s.sh", templateName)
+	}
+
+	if !hookscriptNameRegex.MatchString(name) {
+		return "", fmt.Errorf("invalid hookscript name `%s`, only letters, digits, dots, dashes and underscores are allowed", name)
+	}
+
+	return name, nil
+}
+
+// BuildHookscript builds the hookscript from the configuration.
+func BuildHookscript(configuration *hc.Configuration) (string, error) {
+	if !configuration.HasActions() {
+		return "", fmt.Errorf("hookscript has no actions for any of the phases")
+	}
+
+	phases := make([]phase, 0)
+	for _, candidate := range []phase{
+		{Name: "pre-start", Actions: configuration.GetPreStart()},
+		{Name: "post-start", Actions: configuration.GetPostStart()},
+		{Name: "pre-stop", Actions: configuration.GetPreStop()},
+		{Name: "post-stop", Actions: configuration.GetPostStop()},
+	} {
+		if len(candidate.Actions) > 0 {
+			phases = append(phases, candidate)
+		}
+	}
+
+	tmpl, err := template.New("hookscript").Parse(GetHookscriptTemplate())
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+
+	if err := tmpl.Execute(&buffer, phases); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
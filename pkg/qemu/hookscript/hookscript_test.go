@@ -0,0 +1,81 @@
+package hookscript
+
+import (
+	hc "github.com/darki73/ptm/pkg/configuration/hookscript"
+	"testing"
+)
+
+// TestBuildHookscript tests the BuildHookscript function.
+func TestBuildHookscript(t *testing.T) {
+	configuration := &hc.Configuration{
+		Enabled:   true,
+		PreStart:  []string{},
+		PostStart: []string{"/usr/local/bin/register.sh \"${vmid}\"", "logger \"vm ${vmid} started\""},
+		PreStop:   []string{"/usr/local/bin/deregister.sh \"${vmid}\""},
+		PostStop:  []string{},
+	}
+
+	expected := `#!/bin/bash
+# This file is managed by ptm, manual changes will be overwritten.
+# Usage: <script> <vmid> <phase>
+
+vmid="$1"
+phase="$2"
+
+case "${phase}" in
+  post-start)
+    /usr/local/bin/register.sh "${vmid}"
+    logger "vm ${vmid} started"
+    ;;
+  pre-stop)
+    /usr/local/bin/deregister.sh "${vmid}"
+    ;;
+esac
+
+exit 0
+`
+
+	result, err := BuildHookscript(configuration)
+	if err != nil {
+		t.Fatalf("BuildHookscript returned error: %v", err)
+	}
+
+	if result != expected {
+		t.Errorf("BuildHookscript generated incorrect script.\nExpected:\n%s\n\nActual:\n%s", expected, result)
+	}
+}
+
+// TestBuildHookscriptWithoutActions tests the BuildHookscript function without actions.
+func TestBuildHookscriptWithoutActions(t *testing.T) {
+	if _, err := BuildHookscript(hc.InitializeWithDefaults()); err == nil {
+		t.Errorf("BuildHookscript did not return error for configuration without actions")
+	}
+}
+
+// TestGetHookscriptName tests the GetHookscriptName function.
+func TestGetHookscriptName(t *testing.T) {
+	testCases := []struct {
+		name         string
+		scriptName   string
+		templateName string
+		expected     string
+		expectErr    bool
+	}{
+		{"Default Name", "", "ubuntu-cloudinit", "ptm-ubuntu-cloudinit.sh", false},
+		{"Custom Name", "register.sh", "ubuntu-cloudinit", "register.sh", false},
+		{"Invalid Name", "../register.sh", "ubuntu-cloudinit", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := GetHookscriptName(&hc.Configuration{Name: tc.scriptName}, tc.templateName)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("GetHookscriptName() error = %v, expectErr %v", err, tc.expectErr)
+			}
+
+			if result != tc.expected {
+				t.Errorf("GetHookscriptName() = %s, want %s", result, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package qemu
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/utils"
+	"strconv"
+	"strings"
+)
+
+var (
+	// supportedCpuFlags is the list of CPU flags which can be passed to Proxmox VE.
+	supportedCpuFlags = []string{
+		"md-clear",
+		"pcid",
+		"spec-ctrl",
+		"ssbd",
+		"ibpb",
+		"virt-ssbd",
+		"amd-ssbd",
+		"amd-no-ssb",
+		"pdpe1gb",
+		"hv-tlbflush",
+		"hv-evmcs",
+		"aes",
+	}
+	// emulatedCpuFlags is the list of CPU flags which do not require support from the host CPU.
+	emulatedCpuFlags = []string{
+		"virt-ssbd",
+		"amd-no-ssb",
+		"hv-tlbflush",
+		"hv-evmcs",
+	}
+	// supportedHotplugDevices is the list of devices which can be hotplugged.
+	supportedHotplugDevices = []string{
+		"disk",
+		"network",
+		"usb",
+		"memory",
+		"cpu",
+		"cloudinit",
+	}
+	// hugepageSizes is the map of hugepages option values to their size (in kilobytes).
+	hugepageSizes = map[string]int{
+		"2":    2048,
+		"1024": 1048576,
+	}
+)
+
+const (
+	// maximumCpuUnits is the maximum value for the CPU weight.
+	maximumCpuUnits = 262144
+	// maximumCpuLimit is the maximum value for the CPU limit.
+	maximumCpuLimit = 128
+)
+
+// HostCapabilities is a structure that holds information about the capabilities of the host.
+type HostCapabilities struct {
+	// cores is the number of cores available on the host.
+	cores int
+	// memory is the amount of memory available on the host (in MB).
+	memory uint64
+	// cpuFlags is the list of CPU flags supported by the host.
+	cpuFlags []string
+	// hugePageSizes is the list of hugepage sizes supported by the host (in kilobytes).
+	hugePageSizes []int
+}
+
+// NewHostCapabilities creates a new host capabilities instance from the current machine.
+func NewHostCapabilities() *HostCapabilities {
+	return &HostCapabilities{
+		cores:         utils.GetCoreCount(),
+		memory:        utils.GetTotalMemory(),
+		cpuFlags:      utils.GetCpuFlags(),
+		hugePageSizes: utils.GetHugePageSizes(),
+	}
+}
+
+// NewCustomHostCapabilities creates a new host capabilities instance with custom values.
+func NewCustomHostCapabilities(cores int, memory uint64, cpuFlags []string, hugePageSizes []int) *HostCapabilities {
+	return &HostCapabilities{
+		cores:         cores,
+		memory:        memory,
+		cpuFlags:      cpuFlags,
+		hugePageSizes: hugePageSizes,
+	}
+}
+
+// GetCores returns the number of cores available on the host.
+func (host *HostCapabilities) GetCores() int {
+	return host.cores
+}
+
+// GetMemory returns the amount of memory available on the host (in MB).
+func (host *HostCapabilities) GetMemory() uint64 {
+	return host.memory
+}
+
+// GetCpuFlags returns the list of CPU flags supported by the host.
+func (host *HostCapabilities) GetCpuFlags() []string {
+	return host.cpuFlags
+}
+
+// GetHugePageSizes returns the list of hugepage sizes supported by the host (in kilobytes).
+func (host *HostCapabilities) GetHugePageSizes() []int {
+	return host.hugePageSizes
+}
+
+// HasCpuFlag returns true if the host CPU supports the given flag.
+func (host *HostCapabilities) HasCpuFlag(flag string) bool {
+	normalizedFlag := strings.ReplaceAll(flag, "-", "_")
+	for _, hostFlag := range host.cpuFlags {
+		if hostFlag == normalizedFlag {
+			return true
+		}
+	}
+	return false
+}
+
+// HasHugePageSize returns true if the host supports hugepages of the given size (in kilobytes).
+func (host *HostCapabilities) HasHugePageSize(size int) bool {
+	for _, hostSize := range host.hugePageSizes {
+		if hostSize == size {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateAgainstHost validates the CPU and memory configuration against the host capabilities.
+func (qemu *Qemu) ValidateAgainstHost(host *HostCapabilities) error {
+	if err := qemu.validateCpuTopology(host); err != nil {
+		return err
+	}
+
+	if err := qemu.validateCpuFlags(host); err != nil {
+		return err
+	}
+
+	if err := qemu.validateMemoryOptions(host); err != nil {
+		return err
+	}
+
+	return qemu.validateHotplug()
+}
+
+// validateCpuTopology validates sockets, vCPUs, CPU limit, CPU units and affinity.
+func (qemu *Qemu) validateCpuTopology(host *HostCapabilities) error {
+	if qemu.sockets < 0 {
+		return fmt.Errorf("number of sockets must not be negative")
+	}
+
+	totalCores := qemu.GetTotalCores()
+	if totalCores > host.GetCores() {
+		return fmt.Errorf(
+			"Not enough cores available. Requested: %d (%d sockets x %d cores), Available: %d",
+			totalCores,
+			max(qemu.sockets, 1),
+			qemu.cores,
+			host.GetCores(),
+		)
+	}
+
+	if qemu.vcpus < 0 || qemu.vcpus > totalCores {
+		return fmt.Errorf("vcpus must be between 1 and %d (sockets x cores) or 0 to leave it unset, got %d", totalCores, qemu.vcpus)
+	}
+
+	if qemu.cpuLimit < 0 || qemu.cpuLimit > maximumCpuLimit || qemu.cpuLimit > float64(totalCores) {
+		return fmt.Errorf("cpu limit must be between 0 and %d, got %v", min(maximumCpuLimit, totalCores), qemu.cpuLimit)
+	}
+
+	if qemu.cpuUnits < 0 || qemu.cpuUnits > maximumCpuUnits {
+		return fmt.Errorf("cpu units must be between 1 and %d or 0 to leave it unset, got %d", maximumCpuUnits, qemu.cpuUnits)
+	}
+
+	if qemu.affinity != "" {
+		cpus, err := utils.ParseCpuList(qemu.affinity)
+		if err != nil {
+			return err
+		}
+
+		for _, cpu := range cpus {
+			if cpu >= host.GetCores() {
+				return fmt.Errorf("affinity references CPU %d, but the host only has CPUs 0-%d", cpu, host.GetCores()-1)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateCpuFlags validates the CPU flags against the list of supported flags and the host CPU.
+func (qemu *Qemu) validateCpuFlags(host *HostCapabilities) error {
+	for _, flag := range qemu.cpuFlags {
+		name := strings.TrimLeft(flag, "+-")
+
+		if !utils.SliceContains(supportedCpuFlags, name) {
+			return fmt.Errorf(
+				"cpu flag `%s` is not supported, supported flags: %s",
+				name,
+				strings.Join(supportedCpuFlags, ", "),
+			)
+		}
+
+		if strings.HasPrefix(flag, "-") || utils.SliceContains(emulatedCpuFlags, name) {
+			continue
+		}
+
+		if !host.HasCpuFlag(name) {
+			return fmt.Errorf("cpu flag `%s` is not supported by the host CPU", name)
+		}
+	}
+
+	return nil
+}
+
+// validateMemoryOptions validates the memory, ballooning and hugepages options.
+func (qemu *Qemu) validateMemoryOptions(host *HostCapabilities) error {
+	if qemu.memory > int(host.GetMemory()) {
+		return fmt.Errorf(
+			"Not enough memory available. Requested: %d MB, Available: %d MB",
+			qemu.memory,
+			host.GetMemory(),
+		)
+	}
+
+	if qemu.minimumMemory < 0 || qemu.minimumMemory > qemu.memory {
+		return fmt.Errorf(
+			"minimum memory must be between 0 and %d MB, got %d MB",
+			qemu.memory,
+			qemu.minimumMemory,
+		)
+	}
+
+	if !qemu.ballooning && qemu.minimumMemory > 0 {
+		return fmt.Errorf("minimum memory can only be set when ballooning is enabled")
+	}
+
+	if qemu.hugepages == "" {
+		return nil
+	}
+
+	if !qemu.numa {
+		return fmt.Errorf("hugepages require NUMA to be enabled")
+	}
+
+	if qemu.hugepages == "any" {
+		if len(host.GetHugePageSizes()) == 0 {
+			return fmt.Errorf("hugepages were requested but the host does not support hugepages")
+		}
+		return nil
+	}
+
+	size, ok := hugepageSizes[qemu.hugepages]
+	if !ok {
+		return fmt.Errorf("invalid hugepages value `%s`, supported values: any, 2, 1024", qemu.hugepages)
+	}
+
+	if !host.HasHugePageSize(size) {
+		return fmt.Errorf("hugepages of size %s MB are not supported by the host", qemu.hugepages)
+	}
+
+	sizeInMegabytes, _ := strconv.Atoi(qemu.hugepages)
+	if qemu.memory%sizeInMegabytes != 0 {
+		return fmt.Errorf("memory (%d MB) must be a multiple of the hugepage size (%d MB)", qemu.memory, sizeInMegabytes)
+	}
+
+	return nil
+}
+
+// validateHotplug validates the list of hotplug devices.
+func (qemu *Qemu) validateHotplug() error {
+	for _, device := range qemu.hotplug {
+		if !utils.SliceContains(supportedHotplugDevices, device) {
+			return fmt.Errorf(
+				"hotplug device `%s` is not supported, supported devices: %s",
+				device,
+				strings.Join(supportedHotplugDevices, ", "),
+			)
+		}
+
+		if device == "memory" && !qemu.numa {
+			return fmt.Errorf("memory hotplug requires NUMA to be enabled")
+		}
+	}
+
+	return nil
+}
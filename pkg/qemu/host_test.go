@@ -0,0 +1,102 @@
+package qemu
+
+import (
+	"testing"
+)
+
+// newHostForTesting creates host capabilities used for testing.
+func newHostForTesting() *HostCapabilities {
+	return NewCustomHostCapabilities(8, 16384, []string{"fpu", "aes", "pdpe1gb", "md_clear"}, []int{2048})
+}
+
+// newTopologyQemuForTesting creates a QEMU configuration with valid resources used for testing.
+func newTopologyQemuForTesting() *Qemu {
+	return NewQemuConfiguration().SetCores(2).SetMemory(4096).SetCpuType("host")
+}
+
+// TestSetCpuFlagsAndGetCpuDefinition tests the SetCpuFlags and GetCpuDefinition methods.
+func TestSetCpuFlagsAndGetCpuDefinition(t *testing.T) {
+	qemu := newTopologyQemuForTesting()
+
+	if qemu.GetCpuDefinition() != "host" {
+		t.Errorf("GetCpuDefinition returned %v, want %v", qemu.GetCpuDefinition(), "host")
+	}
+
+	qemu.SetCpuFlags([]string{"aes", "+pdpe1gb;-md-clear", ""})
+
+	expected := "host,flags=+aes;+pdpe1gb;-md-clear"
+	if qemu.GetCpuDefinition() != expected {
+		t.Errorf("GetCpuDefinition returned %v, want %v", qemu.GetCpuDefinition(), expected)
+	}
+
+	qemu.SetCpuFlags([]string{"+aes;+pdpe1gb"})
+
+	expected = "host,flags=+aes;+pdpe1gb"
+	if qemu.GetCpuDefinition() != expected {
+		t.Errorf("GetCpuDefinition returned %v, want %v", qemu.GetCpuDefinition(), expected)
+	}
+}
+
+// TestGetTotalCores tests the GetTotalCores method.
+func TestGetTotalCores(t *testing.T) {
+	qemu := newTopologyQemuForTesting()
+
+	if qemu.GetTotalCores() != 2 {
+		t.Errorf("GetTotalCores returned %v, want %v", qemu.GetTotalCores(), 2)
+	}
+
+	qemu.SetSockets(2)
+
+	if qemu.GetTotalCores() != 4 {
+		t.Errorf("GetTotalCores returned %v, want %v", qemu.GetTotalCores(), 4)
+	}
+}
+
+// TestValidateAgainstHost tests the ValidateAgainstHost method.
+func TestValidateAgainstHost(t *testing.T) {
+	testCases := []struct {
+		name      string
+		configure func(qemu *Qemu)
+		expectErr bool
+	}{
+		{"Defaults", func(qemu *Qemu) {}, false},
+		{"Valid Topology", func(qemu *Qemu) {
+			qemu.SetSockets(2).SetVCpus(3).SetNuma(true).SetCpuLimit(2).SetCpuUnits(1024).SetAffinity("0-3")
+		}, false},
+		{"Too Many Sockets", func(qemu *Qemu) { qemu.SetSockets(5) }, true},
+		{"Too Many vCPUs", func(qemu *Qemu) { qemu.SetVCpus(3) }, true},
+		{"CPU Limit Above Cores", func(qemu *Qemu) { qemu.SetCpuLimit(3) }, true},
+		{"CPU Units Out Of Range", func(qemu *Qemu) { qemu.SetCpuUnits(300000) }, true},
+		{"Affinity Outside Host", func(qemu *Qemu) { qemu.SetAffinity("6-8") }, true},
+		{"Invalid Affinity", func(qemu *Qemu) { qemu.SetAffinity("a") }, true},
+		{"Supported CPU Flags", func(qemu *Qemu) { qemu.SetCpuFlags([]string{"+aes", "+pdpe1gb", "+hv-tlbflush", "-pcid"}) }, false},
+		{"Documented CPU Flags Notation", func(qemu *Qemu) { qemu.SetCpuFlags([]string{"+aes;+pdpe1gb"}) }, false},
+		{"Comma Separated CPU Flags", func(qemu *Qemu) { qemu.SetCpuFlags([]string{"+aes,+pdpe1gb;-pcid"}) }, false},
+		{"Unknown CPU Flag", func(qemu *Qemu) { qemu.SetCpuFlags([]string{"+avx512"}) }, true},
+		{"CPU Flag Missing On Host", func(qemu *Qemu) { qemu.SetCpuFlags([]string{"+pcid"}) }, true},
+		{"Too Much Memory", func(qemu *Qemu) { qemu.SetMemory(32768) }, true},
+		{"Valid Minimum Memory", func(qemu *Qemu) { qemu.SetMinimumMemory(1024) }, false},
+		{"Minimum Memory Above Memory", func(qemu *Qemu) { qemu.SetMinimumMemory(8192) }, true},
+		{"Minimum Memory Without Ballooning", func(qemu *Qemu) { qemu.SetBallooning(false).SetMinimumMemory(1024) }, true},
+		{"Valid Hugepages", func(qemu *Qemu) { qemu.SetNuma(true).SetHugepages("2") }, false},
+		{"Hugepages Without NUMA", func(qemu *Qemu) { qemu.SetHugepages("2") }, true},
+		{"Hugepages Unsupported By Host", func(qemu *Qemu) { qemu.SetNuma(true).SetHugepages("1024") }, true},
+		{"Invalid Hugepages", func(qemu *Qemu) { qemu.SetNuma(true).SetHugepages("4") }, true},
+		{"Hugepages Memory Not Aligned", func(qemu *Qemu) { qemu.SetNuma(true).SetMemory(4095).SetHugepages("2") }, true},
+		{"Valid Hotplug", func(qemu *Qemu) { qemu.SetNuma(true).SetHotplug([]string{"disk", "network", "memory", "cpu"}) }, false},
+		{"Memory Hotplug Without NUMA", func(qemu *Qemu) { qemu.SetHotplug([]string{"memory"}) }, true},
+		{"Unknown Hotplug Device", func(qemu *Qemu) { qemu.SetHotplug([]string{"pci"}) }, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			qemu := newTopologyQemuForTesting()
+			tc.configure(qemu)
+
+			err := qemu.ValidateAgainstHost(newHostForTesting())
+			if (err != nil) != tc.expectErr {
+				t.Errorf("ValidateAgainstHost() error = %v, expectErr %v", err, tc.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package network_config
+
+import (
+	"bytes"
+	"fmt"
+	ci "github.com/darki73/ptm/pkg/configuration/cloud-init"
+	"github.com/darki73/ptm/pkg/utils"
+	"gopkg.in/yaml.v3"
+	"net"
+	"strings"
+)
+
+var (
+	// bondModes is the list of supported bonding modes.
+	bondModes = []string{
+		"balance-rr",
+		"active-backup",
+		"balance-xor",
+		"broadcast",
+		"802.3ad",
+		"balance-tlb",
+		"balance-alb",
+	}
+)
+
+// networkConfig represents the cloud-init network-config version 2 document.
+type networkConfig struct {
+	// Version is the version of the network-config format.
+	Version int `yaml:"version"`
+	// Ethernets is the map of physical interfaces.
+	Ethernets map[string]*device `yaml:"ethernets,omitempty"`
+	// Bonds is the map of bonds.
+	Bonds map[string]*device `yaml:"bonds,omitempty"`
+	// Vlans is the map of VLAN subinterfaces.
+	Vlans map[string]*device `yaml:"vlans,omitempty"`
+}
+
+// device represents a single device of the network-config document.
+type device struct {
+	// Match is the match section of the physical interface.
+	Match *match `yaml:"match,omitempty"`
+	// Interfaces is the list of members of the bond.
+	Interfaces []string `yaml:"interfaces,omitempty"`
+	// Parameters is the parameters section of the bond.
+	Parameters *bondParameters `yaml:"parameters,omitempty"`
+	// Id is the VLAN identifier.
+	Id int `yaml:"id,omitempty"`
+	// Link is the parent device of the VLAN.
+	Link string `yaml:"link,omitempty"`
+	// Dhcp4 indicates whether DHCP should be used for IPv4.
+	Dhcp4 bool `yaml:"dhcp4,omitempty"`
+	// Dhcp6 indicates whether DHCP should be used for IPv6.
+	Dhcp6 bool `yaml:"dhcp6,omitempty"`
+	// Addresses is the list of static addresses.
+	Addresses []string `yaml:"addresses,omitempty"`
+	// Routes is the list of routes.
+	Routes []*route `yaml:"routes,omitempty"`
+	// Nameservers is the DNS configuration.
+	Nameservers *nameservers `yaml:"nameservers,omitempty"`
+	// Mtu is the MTU of the device.
+	Mtu int `yaml:"mtu,omitempty"`
+}
+
+// match represents the match section of a physical interface.
+type match struct {
+	// Name is the interface name glob.
+	Name string `yaml:"name"`
+}
+
+// bondParameters represents the parameters section of a bond.
+type bondParameters struct {
+	// Mode is the bonding mode.
+	Mode string `yaml:"mode,omitempty"`
+	// MiiMonitorInterval is the MII link monitoring interval.
+	MiiMonitorInterval int `yaml:"mii-monitor-interval,omitempty"`
+	// LacpRate is the LACP rate.
+	LacpRate string `yaml:"lacp-rate,omitempty"`
+	// TransmitHashPolicy is the transmit hash policy.
+	TransmitHashPolicy string `yaml:"transmit-hash-policy,omitempty"`
+}
+
+// route represents a single route.
+type route struct {
+	// To is the destination of the route.
+	To string `yaml:"to"`
+	// Via is the gateway of the route.
+	Via string `yaml:"via"`
+	// Metric is the metric of the route.
+	Metric int `yaml:"metric,omitempty"`
+}
+
+// nameservers represents the DNS configuration of a device.
+type nameservers struct {
+	// Addresses is the list of DNS servers.
+	Addresses []string `yaml:"addresses,omitempty"`
+	// Search is the list of DNS search domains.
+	Search []string `yaml:"search,omitempty"`
+}
+
+// BuildNetworkConfig validates the network configuration and renders it as cloud-init network-config version 2.
+func BuildNetworkConfig(network *ci.CloudInitNetwork) (string, error) {
+	if err := ValidateNetworkConfig(network); err != nil {
+		return "", err
+	}
+
+	document := &networkConfig{
+		Version:   2,
+		Ethernets: map[string]*device{},
+		Bonds:     map[string]*device{},
+		Vlans:     map[string]*device{},
+	}
+
+	for _, networkInterface := range network.GetInterfaces() {
+		rendered := buildDevice(&networkInterface.CloudInitNetworkDevice, network.GetDns())
+		if networkInterface.GetMatch() != "" {
+			rendered.Match = &match{Name: networkInterface.GetMatch()}
+		}
+		document.Ethernets[networkInterface.GetName()] = rendered
+	}
+
+	for _, bond := range network.GetBonds() {
+		rendered := buildDevice(&bond.CloudInitNetworkDevice, network.GetDns())
+		rendered.Interfaces = bond.GetInterfaces()
+		rendered.Parameters = &bondParameters{
+			Mode:               bond.GetMode(),
+			MiiMonitorInterval: bond.GetMiiMonitorInterval(),
+			LacpRate:           bond.GetLacpRate(),
+			TransmitHashPolicy: bond.GetTransmitHashPolicy(),
+		}
+		document.Bonds[bond.GetName()] = rendered
+	}
+
+	for _, vlan := range network.GetVlans() {
+		rendered := buildDevice(&vlan.CloudInitNetworkDevice, network.GetDns())
+		rendered.Id = vlan.GetId()
+		rendered.Link = vlan.GetLink()
+		document.Vlans[vlan.GetName()] = rendered
+	}
+
+	var buffer bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(document); err != nil {
+		return "", err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// ValidateNetworkConfig validates interfaces, bonds and VLANs of the network configuration.
+func ValidateNetworkConfig(network *ci.CloudInitNetwork) error {
+	if network == nil || !network.HasAdvancedConfiguration() {
+		return fmt.Errorf("network configuration does not define any interfaces, bonds or vlans")
+	}
+
+	names := make(map[string]string)
+	interfaces := make(map[string]bool)
+	bondMembers := make(map[string]string)
+
+	register := func(kind string, name string) error {
+		if name == "" {
+			return fmt.Errorf("network %s is missing a name", kind)
+		}
+		if existing, ok := names[name]; ok {
+			return fmt.Errorf("network %s `%s` conflicts with %s of the same name", kind, name, existing)
+		}
+		names[name] = kind
+		return nil
+	}
+
+	for _, networkInterface := range network.GetInterfaces() {
+		if err := register("interface", networkInterface.GetName()); err != nil {
+			return err
+		}
+		interfaces[networkInterface.GetName()] = true
+
+		if err := validateDevice("interface", networkInterface.GetName(), &networkInterface.CloudInitNetworkDevice); err != nil {
+			return err
+		}
+	}
+
+	for _, bond := range network.GetBonds() {
+		if err := register("bond", bond.GetName()); err != nil {
+			return err
+		}
+
+		if len(bond.GetInterfaces()) == 0 {
+			return fmt.Errorf("network bond `%s` does not have any interfaces", bond.GetName())
+		}
+
+		for _, member := range bond.GetInterfaces() {
+			if !interfaces[member] {
+				return fmt.Errorf("network bond `%s` references unknown interface `%s`", bond.GetName(), member)
+			}
+			if owner, ok := bondMembers[member]; ok {
+				return fmt.Errorf("network interface `%s` is already a member of bond `%s`", member, owner)
+			}
+			bondMembers[member] = bond.GetName()
+		}
+
+		if bond.GetMode() != "" && !utils.SliceContains(bondModes, bond.GetMode()) {
+			return fmt.Errorf("network bond `%s` has unsupported mode `%s`, supported modes: %s", bond.GetName(), bond.GetMode(), strings.Join(bondModes, ", "))
+		}
+
+		if err := validateDevice("bond", bond.GetName(), &bond.CloudInitNetworkDevice); err != nil {
+			return err
+		}
+	}
+
+	for _, vlan := range network.GetVlans() {
+		if err := register("vlan", vlan.GetName()); err != nil {
+			return err
+		}
+
+		if vlan.GetId() < 1 || vlan.GetId() > 4094 {
+			return fmt.Errorf("network vlan `%s` has invalid id %d, must be between 1 and 4094", vlan.GetName(), vlan.GetId())
+		}
+
+		if kind, ok := names[vlan.GetLink()]; !ok || kind == "vlan" {
+			return fmt.Errorf("network vlan `%s` references unknown interface or bond `%s`", vlan.GetName(), vlan.GetLink())
+		}
+
+		if err := validateDevice("vlan", vlan.GetName(), &vlan.CloudInitNetworkDevice); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateDevice validates the addressing configuration of a single device.
+func validateDevice(kind string, name string, networkDevice *ci.CloudInitNetworkDevice) error {
+	for _, address := range networkDevice.GetAddresses() {
+		if !utils.IsValidIPWithSubnet(address) {
+			return fmt.Errorf("network %s `%s` has invalid address `%s` (expected address with subnet)", kind, name, address)
+		}
+	}
+
+	if err := validateGateway(kind, name, networkDevice.GetGateway4(), networkDevice.GetAddresses(), false); err != nil {
+		return err
+	}
+
+	if err := validateGateway(kind, name, networkDevice.GetGateway6(), networkDevice.GetAddresses(), true); err != nil {
+		return err
+	}
+
+	if networkDevice.GetMtu() < 0 {
+		return fmt.Errorf("network %s `%s` has invalid mtu %d", kind, name, networkDevice.GetMtu())
+	}
+
+	for _, networkRoute := range networkDevice.GetRoutes() {
+		if networkRoute.GetTo() != "default" && !utils.IsValidIPWithSubnet(networkRoute.GetTo()) {
+			return fmt.Errorf("network %s `%s` has route with invalid destination `%s`", kind, name, networkRoute.GetTo())
+		}
+		if !utils.IsValidIP(networkRoute.GetVia()) {
+			return fmt.Errorf("network %s `%s` has route with invalid gateway `%s`", kind, name, networkRoute.GetVia())
+		}
+	}
+
+	for _, nameserver := range networkDevice.GetNameservers() {
+		if !utils.IsValidIP(nameserver) {
+			return fmt.Errorf("network %s `%s` has invalid nameserver `%s`", kind, name, nameserver)
+		}
+	}
+
+	for _, searchDomain := range networkDevice.GetSearchDomains() {
+		if !utils.IsValidDomainName(searchDomain) {
+			return fmt.Errorf("network %s `%s` has invalid search domain `%s`", kind, name, searchDomain)
+		}
+	}
+
+	return nil
+}
+
+// validateGateway validates the default gateway of a device against its addresses.
+func validateGateway(kind string, name string, gateway string, addresses []string, ipv6 bool) error {
+	if gateway == "" {
+		return nil
+	}
+
+	parsed := net.ParseIP(gateway)
+	if parsed == nil || (parsed.To4() == nil) != ipv6 {
+		return fmt.Errorf("network %s `%s` has invalid gateway `%s`", kind, name, gateway)
+	}
+
+	for _, address := range addresses {
+		if utils.IsInSameNetwork(gateway, address) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("network %s `%s` gateway `%s` is not in the same network as any of its addresses", kind, name, gateway)
+}
+
+// buildDevice converts the addressing configuration into the network-config device.
+func buildDevice(networkDevice *ci.CloudInitNetworkDevice, dns *ci.CloudInitNetworkDns) *device {
+	rendered := &device{
+		Dhcp4:     networkDevice.GetDhcp4(),
+		Dhcp6:     networkDevice.GetDhcp6(),
+		Addresses: networkDevice.GetAddresses(),
+		Mtu:       networkDevice.GetMtu(),
+	}
+
+	if networkDevice.GetGateway4() != "" {
+		rendered.Routes = append(rendered.Routes, &route{To: "0.0.0.0/0", Via: networkDevice.GetGateway4()})
+	}
+
+	if networkDevice.GetGateway6() != "" {
+		rendered.Routes = append(rendered.Routes, &route{To: "::/0", Via: networkDevice.GetGateway6()})
+	}
+
+	for _, networkRoute := range networkDevice.GetRoutes() {
+		to := networkRoute.GetTo()
+		if to == "default" {
+			to = "0.0.0.0/0"
+			if ip := net.ParseIP(networkRoute.GetVia()); ip != nil && ip.To4() == nil {
+				to = "::/0"
+			}
+		}
+		rendered.Routes = append(rendered.Routes, &route{To: to, Via: networkRoute.GetVia(), Metric: networkRoute.GetMetric()})
+	}
+
+	if networkDevice.HasDns() {
+		rendered.Nameservers = &nameservers{
+			Addresses: networkDevice.GetNameservers(),
+			Search:    networkDevice.GetSearchDomains(),
+		}
+	} else if len(networkDevice.GetAddresses()) > 0 && dns != nil && dns.IsConfigured() {
+		rendered.Nameservers = &nameservers{
+			Addresses: dns.GetNameservers(),
+			Search:    dns.GetSearchDomains(),
+		}
+	}
+
+	return rendered
+}
@@ -0,0 +1,111 @@
+package network_config
+
+import (
+	"flag"
+	ci "github.com/darki73/ptm/pkg/configuration/cloud-init"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// update indicates whether the golden files should be updated.
+var update = flag.Bool("update", false, "update golden files")
+
+// loadNetwork loads the network configuration from the test data.
+func loadNetwork(t *testing.T, name string) *ci.CloudInitNetwork {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join("testdata", name+".yaml"))
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+
+	network := ci.InitializeCloudInitNetworkWithDefaults()
+	if err := yaml.Unmarshal(content, network); err != nil {
+		t.Fatalf("failed to parse test data: %v", err)
+	}
+
+	return network
+}
+
+// TestBuildNetworkConfig tests the BuildNetworkConfig function against the golden files.
+func TestBuildNetworkConfig(t *testing.T) {
+	for _, name := range []string{"static", "bond_vlan", "dhcp"} {
+		t.Run(name, func(t *testing.T) {
+			result, err := BuildNetworkConfig(loadNetwork(t, name))
+			if err != nil {
+				t.Fatalf("BuildNetworkConfig returned error: %v", err)
+			}
+
+			golden := filepath.Join("testdata", name+".golden")
+
+			if *update {
+				if err := os.WriteFile(golden, []byte(result), 0644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+
+			if result != string(expected) {
+				t.Errorf("BuildNetworkConfig returned\n%v\nwant\n%v", result, string(expected))
+			}
+
+			document := make(map[string]interface{})
+			if err := yaml.Unmarshal([]byte(result), &document); err != nil {
+				t.Fatalf("BuildNetworkConfig returned invalid YAML: %v", err)
+			}
+
+			if document["version"] != 2 {
+				t.Errorf("BuildNetworkConfig returned version %v, want 2", document["version"])
+			}
+		})
+	}
+}
+
+// TestValidateNetworkConfig tests the ValidateNetworkConfig function.
+func TestValidateNetworkConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		errorMsg string
+	}{
+		{"Empty", `{}`, "network configuration does not define any interfaces, bonds or vlans"},
+		{"MissingName", "interfaces:\n  - dhcp4: true\n", "network interface is missing a name"},
+		{"DuplicateName", "interfaces:\n  - name: eth0\nbonds:\n  - name: eth0\n    interfaces: [eth0]\n", "network bond `eth0` conflicts with interface of the same name"},
+		{"InvalidAddress", "interfaces:\n  - name: eth0\n    addresses: [10.10.0.10]\n", "network interface `eth0` has invalid address `10.10.0.10` (expected address with subnet)"},
+		{"GatewayOutsideNetwork", "interfaces:\n  - name: eth0\n    addresses: [10.10.0.10/24]\n    gateway4: 10.20.0.1\n", "network interface `eth0` gateway `10.20.0.1` is not in the same network as any of its addresses"},
+		{"Gateway6IsIPv4", "interfaces:\n  - name: eth0\n    addresses: [10.10.0.10/24]\n    gateway6: 10.10.0.1\n", "network interface `eth0` has invalid gateway `10.10.0.1`"},
+		{"InvalidRoute", "interfaces:\n  - name: eth0\n    routes:\n      - to: 10.20.0.0\n        via: 10.10.0.1\n", "network interface `eth0` has route with invalid destination `10.20.0.0`"},
+		{"BondUnknownInterface", "interfaces:\n  - name: eth0\nbonds:\n  - name: bond0\n    interfaces: [eth1]\n", "network bond `bond0` references unknown interface `eth1`"},
+		{"BondWithoutInterfaces", "bonds:\n  - name: bond0\n", "network bond `bond0` does not have any interfaces"},
+		{"BondSharedInterface", "interfaces:\n  - name: eth0\nbonds:\n  - name: bond0\n    interfaces: [eth0]\n  - name: bond1\n    interfaces: [eth0]\n", "network interface `eth0` is already a member of bond `bond0`"},
+		{"BondInvalidMode", "interfaces:\n  - name: eth0\nbonds:\n  - name: bond0\n    interfaces: [eth0]\n    mode: lacp\n", "network bond `bond0` has unsupported mode `lacp`, supported modes: " + strings.Join(bondModes, ", ")},
+		{"VlanInvalidId", "interfaces:\n  - name: eth0\nvlans:\n  - name: vlan0\n    id: 4095\n    link: eth0\n", "network vlan `vlan0` has invalid id 4095, must be between 1 and 4094"},
+		{"VlanUnknownLink", "interfaces:\n  - name: eth0\nvlans:\n  - name: vlan10\n    id: 10\n    link: bond0\n", "network vlan `vlan10` references unknown interface or bond `bond0`"},
+		{"InvalidNameserver", "interfaces:\n  - name: eth0\n    nameservers: [10.10.0]\n", "network interface `eth0` has invalid nameserver `10.10.0`"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			network := ci.InitializeCloudInitNetworkWithDefaults()
+			if err := yaml.Unmarshal([]byte(test.content), network); err != nil {
+				t.Fatalf("failed to parse test data: %v", err)
+			}
+
+			err := ValidateNetworkConfig(network)
+			if err == nil {
+				t.Fatalf("ValidateNetworkConfig returned nil, want error %q", test.errorMsg)
+			}
+
+			if err.Error() != test.errorMsg {
+				t.Errorf("ValidateNetworkConfig returned error %q, want %q", err.Error(), test.errorMsg)
+			}
+		})
+	}
+}
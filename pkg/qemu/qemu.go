@@ -3,6 +3,8 @@ package qemu
 import (
 	"fmt"
 	ci "github.com/darki73/ptm/pkg/qemu/cloud-init"
+	"github.com/darki73/ptm/pkg/utils"
+	"strings"
 )
 
 const (
@@ -26,6 +28,52 @@ type Qemu struct {
 	memory int
 	// cpuType is the CPU type to use.
 	cpuType string
+	// sockets is the number of CPU sockets to use.
+	sockets int
+	// vcpus is the number of hotplugged vCPUs to start with.
+	vcpus int
+	// numa is the flag that enables NUMA.
+	numa bool
+	// cpuFlags is the list of CPU flags to enable (+flag) or disable (-flag).
+	cpuFlags []string
+	// cpuLimit is the limit of CPU usage.
+	cpuLimit float64
+	// cpuUnits is the CPU weight.
+	cpuUnits int
+	// affinity is the list of host CPUs to run on.
+	affinity string
+	// ballooning is the flag that enables the memory balloon device.
+	ballooning bool
+	// minimumMemory is the minimum amount of memory for the balloon device (in MB).
+	minimumMemory int
+	// hugepages is the size of hugepages to use.
+	hugepages string
+	// hotplug is the list of devices which can be hotplugged.
+	hotplug []string
+	// rngSource is the entropy source for the VirtIO RNG device (empty disables the device).
+	rngSource string
+	// rngMaxBytes is the maximum bytes of entropy injected into the guest every period.
+	rngMaxBytes int
+	// rngPeriod is the period (in milliseconds) for the max bytes limit.
+	rngPeriod int
+	// watchdogModel is the watchdog device model (empty disables the device).
+	watchdogModel string
+	// watchdogAction is the action to perform when the guest stops feeding the watchdog.
+	watchdogAction string
+	// architecture is the architecture of the virtual machine (x86_64 / aarch64).
+	architecture string
+	// guestAgentEnabled is the flag that enables the QEMU guest agent.
+	guestAgentEnabled bool
+	// guestAgentTrim is the flag that runs fstrim after moving a disk or migrating the virtual machine.
+	guestAgentTrim bool
+	// guestAgentFreezeFs is the flag that freezes guest filesystems during backups.
+	guestAgentFreezeFs bool
+	// guestAgentType is the guest agent communication channel type (virtio / isa).
+	guestAgentType string
+	// hookscript is the volume identifier of the hookscript (for example, local:snippets/hookscript.sh).
+	hookscript string
+	// description is the description of the virtual machine (shown in the notes of the template).
+	description string
 	// networkDriver is the network driver to use.
 	networkDriver string
 	// networkBridge is the network bridge to use.
@@ -56,6 +104,29 @@ func NewQemuConfiguration() *Qemu {
 		cores:                0,
 		memory:               0,
 		cpuType:              "",
+		sockets:              0,
+		vcpus:                0,
+		numa:                 false,
+		cpuFlags:             []string{},
+		cpuLimit:             0,
+		cpuUnits:             0,
+		affinity:             "",
+		ballooning:           true,
+		minimumMemory:        0,
+		hugepages:            "",
+		hotplug:              []string{},
+		rngSource:            "",
+		rngMaxBytes:          0,
+		rngPeriod:            0,
+		watchdogModel:        "",
+		watchdogAction:       "",
+		architecture:         "",
+		guestAgentEnabled:    true,
+		guestAgentTrim:       true,
+		guestAgentFreezeFs:   true,
+		guestAgentType:       "virtio",
+		hookscript:           "",
+		description:          "",
 		networkDriver:        "",
 		networkBridge:        "",
 		storage:              "",
@@ -146,6 +217,330 @@ func (qemu *Qemu) SetCpuType(cpuType string) *Qemu {
 	return qemu
 }
 
+// GetSockets returns the number of CPU sockets to use.
+func (qemu *Qemu) GetSockets() int {
+	return qemu.sockets
+}
+
+// SetSockets sets the number of CPU sockets to use.
+func (qemu *Qemu) SetSockets(sockets int) *Qemu {
+	qemu.sockets = sockets
+	return qemu
+}
+
+// GetVCpus returns the number of hotplugged vCPUs to start with.
+func (qemu *Qemu) GetVCpus() int {
+	return qemu.vcpus
+}
+
+// SetVCpus sets the number of hotplugged vCPUs to start with.
+func (qemu *Qemu) SetVCpus(vcpus int) *Qemu {
+	qemu.vcpus = vcpus
+	return qemu
+}
+
+// GetNuma returns the flag that enables NUMA.
+func (qemu *Qemu) GetNuma() bool {
+	return qemu.numa
+}
+
+// SetNuma sets the flag that enables NUMA.
+func (qemu *Qemu) SetNuma(numa bool) *Qemu {
+	qemu.numa = numa
+	return qemu
+}
+
+// GetCpuFlags returns the list of CPU flags to enable (+flag) or disable (-flag).
+func (qemu *Qemu) GetCpuFlags() []string {
+	return qemu.cpuFlags
+}
+
+// SetCpuFlags sets the list of CPU flags (accepts `+aes;+pdpe1gb` and `+aes,+pdpe1gb` notation, flags without sign are treated as enabled).
+func (qemu *Qemu) SetCpuFlags(cpuFlags []string) *Qemu {
+	flags := make([]string, 0)
+
+	separators := func(character rune) bool {
+		return character == ';' || character == ','
+	}
+
+	for _, flag := range strings.FieldsFunc(strings.Join(cpuFlags, ";"), separators) {
+		flag = strings.TrimSpace(flag)
+		if flag == "" {
+			continue
+		}
+		if !strings.HasPrefix(flag, "+") && !strings.HasPrefix(flag, "-") {
+			flag = "+" + flag
+		}
+		flags = append(flags, flag)
+	}
+
+	qemu.cpuFlags = flags
+	return qemu
+}
+
+// GetCpuDefinition returns the CPU type with CPU flags appended (host,flags=+aes;+pdpe1gb).
+func (qemu *Qemu) GetCpuDefinition() string {
+	if len(qemu.cpuFlags) == 0 {
+		return qemu.cpuType
+	}
+
+	return fmt.Sprintf("%s,flags=%s", qemu.cpuType, strings.Join(qemu.cpuFlags, ";"))
+}
+
+// GetCpuLimit returns the limit of CPU usage.
+func (qemu *Qemu) GetCpuLimit() float64 {
+	return qemu.cpuLimit
+}
+
+// SetCpuLimit sets the limit of CPU usage.
+func (qemu *Qemu) SetCpuLimit(cpuLimit float64) *Qemu {
+	qemu.cpuLimit = cpuLimit
+	return qemu
+}
+
+// GetCpuUnits returns the CPU weight.
+func (qemu *Qemu) GetCpuUnits() int {
+	return qemu.cpuUnits
+}
+
+// SetCpuUnits sets the CPU weight.
+func (qemu *Qemu) SetCpuUnits(cpuUnits int) *Qemu {
+	qemu.cpuUnits = cpuUnits
+	return qemu
+}
+
+// GetAffinity returns the list of host CPUs to run on.
+func (qemu *Qemu) GetAffinity() string {
+	return qemu.affinity
+}
+
+// SetAffinity sets the list of host CPUs to run on.
+func (qemu *Qemu) SetAffinity(affinity string) *Qemu {
+	qemu.affinity = affinity
+	return qemu
+}
+
+// GetBallooning returns the flag that enables the memory balloon device.
+func (qemu *Qemu) GetBallooning() bool {
+	return qemu.ballooning
+}
+
+// SetBallooning sets the flag that enables the memory balloon device.
+func (qemu *Qemu) SetBallooning(ballooning bool) *Qemu {
+	qemu.ballooning = ballooning
+	return qemu
+}
+
+// GetMinimumMemory returns the minimum amount of memory for the balloon device (in MB).
+func (qemu *Qemu) GetMinimumMemory() int {
+	return qemu.minimumMemory
+}
+
+// SetMinimumMemory sets the minimum amount of memory for the balloon device (in MB).
+func (qemu *Qemu) SetMinimumMemory(minimumMemory int) *Qemu {
+	qemu.minimumMemory = minimumMemory
+	return qemu
+}
+
+// GetHugepages returns the size of hugepages to use.
+func (qemu *Qemu) GetHugepages() string {
+	return qemu.hugepages
+}
+
+// SetHugepages sets the size of hugepages to use.
+func (qemu *Qemu) SetHugepages(hugepages string) *Qemu {
+	qemu.hugepages = hugepages
+	return qemu
+}
+
+// GetHotplug returns the list of devices which can be hotplugged.
+func (qemu *Qemu) GetHotplug() []string {
+	return qemu.hotplug
+}
+
+// SetHotplug sets the list of devices which can be hotplugged.
+func (qemu *Qemu) SetHotplug(hotplug []string) *Qemu {
+	qemu.hotplug = utils.RemoveEmptyStringsFromSlice(hotplug)
+	return qemu
+}
+
+// HasCpuTopology returns true if any of the advanced CPU options is set.
+func (qemu *Qemu) HasCpuTopology() bool {
+	return qemu.sockets > 0 || qemu.vcpus > 0 || qemu.numa || qemu.cpuLimit > 0 || qemu.cpuUnits > 0 || qemu.affinity != ""
+}
+
+// HasMemoryOptions returns true if any of the advanced memory options is set.
+func (qemu *Qemu) HasMemoryOptions() bool {
+	return !qemu.ballooning || qemu.minimumMemory > 0 || qemu.hugepages != ""
+}
+
+// GetTotalCores returns the total number of cores (sockets * cores).
+func (qemu *Qemu) GetTotalCores() int {
+	if qemu.sockets > 1 {
+		return qemu.sockets * qemu.cores
+	}
+	return qemu.cores
+}
+
+// GetRngSource returns the entropy source for the VirtIO RNG device.
+func (qemu *Qemu) GetRngSource() string {
+	return qemu.rngSource
+}
+
+// SetRngSource sets the entropy source for the VirtIO RNG device.
+func (qemu *Qemu) SetRngSource(rngSource string) *Qemu {
+	qemu.rngSource = rngSource
+	return qemu
+}
+
+// GetRngMaxBytes returns the maximum bytes of entropy injected into the guest every period.
+func (qemu *Qemu) GetRngMaxBytes() int {
+	return qemu.rngMaxBytes
+}
+
+// SetRngMaxBytes sets the maximum bytes of entropy injected into the guest every period.
+func (qemu *Qemu) SetRngMaxBytes(rngMaxBytes int) *Qemu {
+	qemu.rngMaxBytes = rngMaxBytes
+	return qemu
+}
+
+// GetRngPeriod returns the period (in milliseconds) for the max bytes limit.
+func (qemu *Qemu) GetRngPeriod() int {
+	return qemu.rngPeriod
+}
+
+// SetRngPeriod sets the period (in milliseconds) for the max bytes limit.
+func (qemu *Qemu) SetRngPeriod(rngPeriod int) *Qemu {
+	qemu.rngPeriod = rngPeriod
+	return qemu
+}
+
+// HasRng returns true if the VirtIO RNG device should be added.
+func (qemu *Qemu) HasRng() bool {
+	return qemu.rngSource != ""
+}
+
+// GetWatchdogModel returns the watchdog device model.
+func (qemu *Qemu) GetWatchdogModel() string {
+	return qemu.watchdogModel
+}
+
+// SetWatchdogModel sets the watchdog device model.
+func (qemu *Qemu) SetWatchdogModel(watchdogModel string) *Qemu {
+	qemu.watchdogModel = watchdogModel
+	return qemu
+}
+
+// GetWatchdogAction returns the action to perform when the guest stops feeding the watchdog.
+func (qemu *Qemu) GetWatchdogAction() string {
+	return qemu.watchdogAction
+}
+
+// SetWatchdogAction sets the action to perform when the guest stops feeding the watchdog.
+func (qemu *Qemu) SetWatchdogAction(watchdogAction string) *Qemu {
+	qemu.watchdogAction = watchdogAction
+	return qemu
+}
+
+// HasWatchdog returns true if the watchdog device should be added.
+func (qemu *Qemu) HasWatchdog() bool {
+	return qemu.watchdogModel != ""
+}
+
+// GetArchitecture returns the architecture of the virtual machine.
+func (qemu *Qemu) GetArchitecture() string {
+	return qemu.architecture
+}
+
+// SetArchitecture sets the architecture of the virtual machine (accepts amd64 / arm64 aliases).
+func (qemu *Qemu) SetArchitecture(architecture string) *Qemu {
+	qemu.architecture = utils.NormalizeArchitecture(architecture)
+	return qemu
+}
+
+// IsArm returns true if the virtual machine uses the aarch64 architecture.
+func (qemu *Qemu) IsArm() bool {
+	return qemu.architecture == utils.ArchitectureArm
+}
+
+// GetCloudInitDevice returns the device to which the cloud-init drive is attached.
+func (qemu *Qemu) GetCloudInitDevice() string {
+	if qemu.cloudInit != nil && qemu.cloudInit.GetDrive() != "" {
+		return qemu.cloudInit.GetDrive()
+	}
+
+	if qemu.IsArm() {
+		return "scsi1"
+	}
+	return "ide2"
+}
+
+// GetGuestAgentEnabled returns the flag that enables the QEMU guest agent.
+func (qemu *Qemu) GetGuestAgentEnabled() bool {
+	return qemu.guestAgentEnabled
+}
+
+// SetGuestAgentEnabled sets the flag that enables the QEMU guest agent.
+func (qemu *Qemu) SetGuestAgentEnabled(guestAgentEnabled bool) *Qemu {
+	qemu.guestAgentEnabled = guestAgentEnabled
+	return qemu
+}
+
+// GetGuestAgentTrim returns the flag that runs fstrim after moving a disk or migrating the virtual machine.
+func (qemu *Qemu) GetGuestAgentTrim() bool {
+	return qemu.guestAgentTrim
+}
+
+// SetGuestAgentTrim sets the flag that runs fstrim after moving a disk or migrating the virtual machine.
+func (qemu *Qemu) SetGuestAgentTrim(guestAgentTrim bool) *Qemu {
+	qemu.guestAgentTrim = guestAgentTrim
+	return qemu
+}
+
+// GetGuestAgentFreezeFs returns the flag that freezes guest filesystems during backups.
+func (qemu *Qemu) GetGuestAgentFreezeFs() bool {
+	return qemu.guestAgentFreezeFs
+}
+
+// SetGuestAgentFreezeFs sets the flag that freezes guest filesystems during backups.
+func (qemu *Qemu) SetGuestAgentFreezeFs(guestAgentFreezeFs bool) *Qemu {
+	qemu.guestAgentFreezeFs = guestAgentFreezeFs
+	return qemu
+}
+
+// GetGuestAgentType returns the guest agent communication channel type.
+func (qemu *Qemu) GetGuestAgentType() string {
+	return qemu.guestAgentType
+}
+
+// SetGuestAgentType sets the guest agent communication channel type.
+func (qemu *Qemu) SetGuestAgentType(guestAgentType string) *Qemu {
+	qemu.guestAgentType = guestAgentType
+	return qemu
+}
+
+// GetHookscript returns the volume identifier of the hookscript.
+func (qemu *Qemu) GetHookscript() string {
+	return qemu.hookscript
+}
+
+// SetHookscript sets the volume identifier of the hookscript.
+func (qemu *Qemu) SetHookscript(hookscript string) *Qemu {
+	qemu.hookscript = hookscript
+	return qemu
+}
+
+// GetDescription returns the description of the virtual machine.
+func (qemu *Qemu) GetDescription() string {
+	return qemu.description
+}
+
+// SetDescription sets the description of the virtual machine.
+func (qemu *Qemu) SetDescription(description string) *Qemu {
+	qemu.description = description
+	return qemu
+}
+
 // GetStorage returns the storage to use.
 func (qemu *Qemu) GetStorage() string {
 	return qemu.storage
@@ -285,6 +680,10 @@ func (qemu *Qemu) IsConfigurationValid() (bool, error) {
 		if !valid {
 			return false, fmt.Errorf("invalid cloud-init configuration")
 		}
+
+		if err := qemu.ValidateCloudInitDevice(); err != nil {
+			return false, err
+		}
 	}
 
 	return true, nil
@@ -2,6 +2,7 @@ package qemu
 
 import (
 	ci "github.com/darki73/ptm/pkg/qemu/cloud-init"
+	"github.com/darki73/ptm/pkg/utils"
 	"testing"
 )
 
@@ -208,7 +209,7 @@ func TestSetAndGetCloudInit(t *testing.T) {
 
 	qemu.SetCloudInit(cloudInit)
 
-	if qemu.GetCloudInit().GetUsername() != "user" || qemu.GetCloudInit().GetPassword() != "pass" {
+	if qemu.GetCloudInit().GetUsername() != "user" || !utils.VerifySha512Crypt("pass", qemu.GetCloudInit().GetPassword()) {
 		t.Errorf("GetCloudInit did not return the expected CloudInit configuration")
 	}
 }
@@ -355,7 +356,7 @@ func TestIsConfigurationValid(t *testing.T) {
 				cloudInit := ci.NewCloudInitConfiguration()
 				cloudInit.SetIPv4("10.10.10.10/24")
 				cloudInit.SetIPv4Gateway("10.10.10.1")
-				cloudInit.SetIPv6("2001:db8::1/64")
+				cloudInit.SetIPv6("2001:db8::10/64")
 				cloudInit.SetIPv6Gateway("2001:db8::1")
 				q.SetCloudInit(cloudInit)
 			},
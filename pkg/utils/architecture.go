@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	// ArchitectureX86 is the normalized name of the x86_64 architecture.
+	ArchitectureX86 = "x86_64"
+	// ArchitectureArm is the normalized name of the aarch64 architecture.
+	ArchitectureArm = "aarch64"
+)
+
+var (
+	// architectureAliases is the map of architecture aliases to their normalized names.
+	architectureAliases = map[string]string{
+		"amd64":   ArchitectureX86,
+		"x86_64":  ArchitectureX86,
+		"x86-64":  ArchitectureX86,
+		"arm64":   ArchitectureArm,
+		"aarch64": ArchitectureArm,
+	}
+)
+
+// inspectorOperatingSystems represents the (partial) output of the virt-inspector command.
+type inspectorOperatingSystems struct {
+	// OperatingSystems is the list of detected operating systems.
+	OperatingSystems []struct {
+		// Architecture is the architecture of the operating system.
+		Architecture string `xml:"arch"`
+	} `xml:"operatingsystem"`
+}
+
+// NormalizeArchitecture converts the architecture name to the name used by QEMU (amd64 -> x86_64, arm64 -> aarch64).
+func NormalizeArchitecture(architecture string) string {
+	architecture = strings.ToLower(strings.TrimSpace(architecture))
+	if normalized, ok := architectureAliases[architecture]; ok {
+		return normalized
+	}
+	return architecture
+}
+
+// GetHostArchitecture returns the normalized architecture of the host.
+func GetHostArchitecture() string {
+	return NormalizeArchitecture(runtime.GOARCH)
+}
+
+// GetImageArchitecture detects the architecture of the image using virt-inspector (falls back to the file name).
+func GetImageArchitecture(image string) (string, error) {
+	output, err := ExecuteCommand("virt-inspector", "--no-applications", "--no-icon", "-a", image)
+	if err == nil {
+		if architecture, err := ParseInspectorArchitecture(output); err == nil {
+			return architecture, nil
+		}
+	}
+
+	if architecture := GuessArchitectureFromFileName(image); architecture != "" {
+		return architecture, nil
+	}
+
+	return "", fmt.Errorf("unable to detect architecture of the image `%s`", image)
+}
+
+// ParseInspectorArchitecture parses the output of the virt-inspector command and returns the normalized architecture.
+func ParseInspectorArchitecture(output string) (string, error) {
+	var inspection inspectorOperatingSystems
+	if err := xml.Unmarshal([]byte(output), &inspection); err != nil {
+		return "", err
+	}
+
+	for _, operatingSystem := range inspection.OperatingSystems {
+		if operatingSystem.Architecture != "" {
+			return NormalizeArchitecture(operatingSystem.Architecture), nil
+		}
+	}
+
+	return "", fmt.Errorf("no operating system architecture found in virt-inspector output")
+}
+
+// GuessArchitectureFromFileName returns the normalized architecture found in the image file name (or empty string).
+func GuessArchitectureFromFileName(image string) string {
+	name := strings.ToLower(filepath.Base(image))
+	separators := func(r rune) bool {
+		return r == '-' || r == '.' || r == '_'
+	}
+
+	parts := strings.FieldsFunc(name, separators)
+	for index, part := range parts {
+		if part == "x86" && index+1 < len(parts) && parts[index+1] == "64" {
+			return ArchitectureX86
+		}
+
+		if normalized, ok := architectureAliases[part]; ok {
+			return normalized
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"testing"
+)
+
+// TestNormalizeArchitecture tests the NormalizeArchitecture function.
+func TestNormalizeArchitecture(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"amd64", ArchitectureX86},
+		{"x86_64", ArchitectureX86},
+		{"arm64", ArchitectureArm},
+		{"AArch64", ArchitectureArm},
+		{"armhf", "armhf"},
+	}
+
+	for _, test := range tests {
+		if result := NormalizeArchitecture(test.input); result != test.expected {
+			t.Errorf("NormalizeArchitecture(%s) returned %s, want %s", test.input, result, test.expected)
+		}
+	}
+}
+
+// TestParseInspectorArchitecture tests the ParseInspectorArchitecture function.
+func TestParseInspectorArchitecture(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "aarch64",
+			output:   "<?xml version=\"1.0\"?>\n<operatingsystems>\n  <operatingsystem>\n    <root>/dev/sda1</root>\n    <name>linux</name>\n    <arch>aarch64</arch>\n  </operatingsystem>\n</operatingsystems>",
+			expected: ArchitectureArm,
+		},
+		{
+			name:     "x86_64",
+			output:   "<operatingsystems><operatingsystem><arch>x86_64</arch></operatingsystem></operatingsystems>",
+			expected: ArchitectureX86,
+		},
+		{
+			name:      "No Operating System",
+			output:    "<operatingsystems></operatingsystems>",
+			expectErr: true,
+		},
+		{
+			name:      "Invalid Output",
+			output:    "not xml",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := ParseInspectorArchitecture(test.output)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("ParseInspectorArchitecture() error = %v, expectErr %v", err, test.expectErr)
+			}
+
+			if result != test.expected {
+				t.Errorf("ParseInspectorArchitecture returned %s, want %s", result, test.expected)
+			}
+		})
+	}
+}
+
+// TestGuessArchitectureFromFileName tests the GuessArchitectureFromFileName function.
+func TestGuessArchitectureFromFileName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"/etc/ptm/images/ubuntu-22.04-minimal-cloudimg-amd64.img", ArchitectureX86},
+		{"/etc/ptm/images/ubuntu-22.04-server-cloudimg-arm64.img", ArchitectureArm},
+		{"Rocky-9-GenericCloud.latest.aarch64.qcow2", ArchitectureArm},
+		{"Rocky-9-GenericCloud.latest.x86_64.qcow2", ArchitectureX86},
+		{"custom-image.qcow2", ""},
+	}
+
+	for _, test := range tests {
+		if result := GuessArchitectureFromFileName(test.input); result != test.expected {
+			t.Errorf("GuessArchitectureFromFileName(%s) returned %s, want %s", test.input, result, test.expected)
+		}
+	}
+}
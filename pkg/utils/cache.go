@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var (
+	// cacheHttpClient is the HTTP client used to fetch cached resources.
+	cacheHttpClient = &http.Client{
+		Timeout: 30 * time.Second,
+	}
+)
+
+// GetCachePath returns the path of the cache file for the given URL.
+func GetCachePath(cacheDirectory string, url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(cacheDirectory, hex.EncodeToString(sum[:]))
+}
+
+// FetchWithCache returns the content of the URL, using the cached copy when it is younger than the TTL.
+// When fetching fails, the stale cached copy is returned if it exists.
+func FetchWithCache(url string, cacheDirectory string, ttl time.Duration) ([]byte, error) {
+	cachePath := GetCachePath(cacheDirectory, url)
+
+	if information, err := os.Stat(cachePath); err == nil && time.Since(information.ModTime()) < ttl {
+		return os.ReadFile(cachePath)
+	}
+
+	content, fetchErr := FetchUrl(url)
+	if fetchErr != nil {
+		if cached, err := os.ReadFile(cachePath); err == nil {
+			return cached, nil
+		}
+		return nil, fetchErr
+	}
+
+	if err := os.MkdirAll(cacheDirectory, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory `%s`: %v", cacheDirectory, err)
+	}
+
+	if err := os.WriteFile(cachePath, content, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write cache file `%s`: %v", cachePath, err)
+	}
+
+	return content, nil
+}
+
+// FetchUrl fetches the content of the URL (without caching).
+func FetchUrl(url string) ([]byte, error) {
+	response, err := cacheHttpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch `%s`: %v", url, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch `%s`: unexpected status %s", url, response.Status)
+	}
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read `%s`: %v", url, err)
+	}
+
+	return content, nil
+}
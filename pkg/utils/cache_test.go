@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestFetchWithCache tests the FetchWithCache function.
+func TestFetchWithCache(t *testing.T) {
+	requests := 0
+	failing := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		if failing {
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = writer.Write([]byte("content"))
+	}))
+	defer server.Close()
+
+	cacheDirectory := t.TempDir()
+
+	content, err := FetchWithCache(server.URL, cacheDirectory, time.Hour)
+	if err != nil || string(content) != "content" {
+		t.Fatalf("FetchWithCache returned %v, %v, want content", string(content), err)
+	}
+
+	content, err = FetchWithCache(server.URL, cacheDirectory, time.Hour)
+	if err != nil || string(content) != "content" || requests != 1 {
+		t.Errorf("FetchWithCache did not use the cached copy, requests = %d", requests)
+	}
+
+	stale := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(GetCachePath(cacheDirectory, server.URL), stale, stale); err != nil {
+		t.Fatalf("failed to age cache file: %v", err)
+	}
+
+	failing = true
+
+	content, err = FetchWithCache(server.URL, cacheDirectory, time.Hour)
+	if err != nil || string(content) != "content" || requests != 2 {
+		t.Errorf("FetchWithCache did not fall back to the stale copy, got %v, %v, requests = %d", string(content), err, requests)
+	}
+
+	if _, err := FetchWithCache(server.URL+"/missing", cacheDirectory, time.Hour); err == nil {
+		t.Errorf("FetchWithCache returned nil error for failing request without cache")
+	}
+}
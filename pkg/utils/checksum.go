@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	// ChecksumAlgorithmSha256 is the SHA-256 checksum algorithm.
+	ChecksumAlgorithmSha256 = "sha256"
+	// ChecksumAlgorithmSha512 is the SHA-512 checksum algorithm.
+	ChecksumAlgorithmSha512 = "sha512"
+)
+
+var (
+	// bsdChecksumPattern is the pattern used to parse lines of BSD style checksum files (for example, `SHA256 (image.qcow2) = <checksum>`).
+	bsdChecksumPattern = regexp.MustCompile(`^SHA(?:256|512) \((.+)\) = ([0-9a-fA-F]+)$`)
+	// gnuChecksumPattern is the pattern used to parse lines of GNU style checksum files (for example, `<checksum>  image.qcow2`).
+	gnuChecksumPattern = regexp.MustCompile(`^([0-9a-fA-F]+)\s+\*?(\S+)$`)
+	// bareChecksumPattern is the pattern used to parse checksum files that only contain the checksum.
+	bareChecksumPattern = regexp.MustCompile(`^([0-9a-fA-F]+)$`)
+)
+
+// FindChecksum returns the algorithm and the checksum of the file from the content of a checksum file.
+// Supports GNU style (sha256sum), BSD style (sha256sum --tag) and bare checksum files, lines of PGP clearsigned files are skipped.
+func FindChecksum(content string, fileName string) (string, string, error) {
+	var bareChecksums []string
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+
+		if matches := bsdChecksumPattern.FindStringSubmatch(line); matches != nil {
+			if path.Base(matches[1]) == fileName {
+				return newChecksum(matches[2], fileName)
+			}
+			continue
+		}
+
+		if matches := gnuChecksumPattern.FindStringSubmatch(line); matches != nil {
+			if path.Base(matches[2]) == fileName {
+				return newChecksum(matches[1], fileName)
+			}
+			continue
+		}
+
+		if matches := bareChecksumPattern.FindStringSubmatch(line); matches != nil {
+			bareChecksums = append(bareChecksums, matches[1])
+		}
+	}
+
+	if len(bareChecksums) == 1 {
+		return newChecksum(bareChecksums[0], fileName)
+	}
+
+	return "", "", fmt.Errorf("checksum of `%s` not found", fileName)
+}
+
+// NewChecksumHash returns a new hash for the checksum algorithm.
+func NewChecksumHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case ChecksumAlgorithmSha256:
+		return sha256.New(), nil
+	case ChecksumAlgorithmSha512:
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("checksum algorithm `%s` is not supported", algorithm)
+	}
+}
+
+// newChecksum returns the algorithm (detected from the length) and the lowercase checksum.
+func newChecksum(checksum string, fileName string) (string, string, error) {
+	checksum = strings.ToLower(checksum)
+
+	switch len(checksum) {
+	case sha256.Size * 2:
+		return ChecksumAlgorithmSha256, checksum, nil
+	case sha512.Size * 2:
+		return ChecksumAlgorithmSha512, checksum, nil
+	default:
+		return "", "", fmt.Errorf("checksum `%s` of `%s` is neither SHA-256 nor SHA-512", checksum, fileName)
+	}
+}
@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// TestFindChecksum tests the FindChecksum function.
+func TestFindChecksum(t *testing.T) {
+	sha256Checksum := strings.Repeat("ab", 32)
+	sha512Checksum := strings.Repeat("cd", 64)
+
+	tests := []struct {
+		name              string
+		content           string
+		fileName          string
+		expectedAlgorithm string
+		expectedChecksum  string
+		expectError       bool
+	}{
+		{
+			"gnu style",
+			"0000000000000000000000000000000000000000000000000000000000000000 *other.img\n" + sha256Checksum + " *ubuntu-24.04-server-cloudimg-amd64.img\n",
+			"ubuntu-24.04-server-cloudimg-amd64.img",
+			ChecksumAlgorithmSha256,
+			sha256Checksum,
+			false,
+		},
+		{
+			"gnu style with sha512 and relative path",
+			sha512Checksum + "  ./debian-12-generic-amd64.qcow2\n",
+			"debian-12-generic-amd64.qcow2",
+			ChecksumAlgorithmSha512,
+			sha512Checksum,
+			false,
+		},
+		{
+			"bsd style in clearsigned file",
+			"-----BEGIN PGP SIGNED MESSAGE-----\nHash: SHA256\n\n# Fedora-Cloud-Base-Generic-41-1.4.x86_64.qcow2: 123 bytes\nSHA256 (Fedora-Cloud-Base-Generic-41-1.4.x86_64.qcow2) = " + strings.ToUpper(sha256Checksum) + "\n-----BEGIN PGP SIGNATURE-----\n\nabc\n-----END PGP SIGNATURE-----\n",
+			"Fedora-Cloud-Base-Generic-41-1.4.x86_64.qcow2",
+			ChecksumAlgorithmSha256,
+			sha256Checksum,
+			false,
+		},
+		{
+			"bare checksum",
+			sha512Checksum + "\n",
+			"nocloud_alpine-3.21.0-x86_64-bios-cloudinit-r0.qcow2",
+			ChecksumAlgorithmSha512,
+			sha512Checksum,
+			false,
+		},
+		{
+			"missing file",
+			sha256Checksum + "  other.img\n",
+			"image.img",
+			"",
+			"",
+			true,
+		},
+		{
+			"unsupported length",
+			"abcd  image.img\n",
+			"image.img",
+			"",
+			"",
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			algorithm, checksum, err := FindChecksum(test.content, test.fileName)
+			if (err != nil) != test.expectError {
+				t.Fatalf("FindChecksum returned error %v, want error: %v", err, test.expectError)
+			}
+			if algorithm != test.expectedAlgorithm || checksum != test.expectedChecksum {
+				t.Errorf("FindChecksum returned %v, %v, want %v, %v", algorithm, checksum, test.expectedAlgorithm, test.expectedChecksum)
+			}
+		})
+	}
+}
+
+// TestNewChecksumHash tests the NewChecksumHash function.
+func TestNewChecksumHash(t *testing.T) {
+	tests := []struct {
+		algorithm   string
+		expected    string
+		expectError bool
+	}{
+		{ChecksumAlgorithmSha256, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", false},
+		{ChecksumAlgorithmSha512, "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f", false},
+		{"md5", "", true},
+	}
+
+	for _, test := range tests {
+		hash, err := NewChecksumHash(test.algorithm)
+		if (err != nil) != test.expectError {
+			t.Fatalf("NewChecksumHash(%s) returned error %v, want error: %v", test.algorithm, err, test.expectError)
+		}
+		if err != nil {
+			continue
+		}
+
+		hash.Write([]byte("abc"))
+		if result := hex.EncodeToString(hash.Sum(nil)); result != test.expected {
+			t.Errorf("NewChecksumHash(%s) computed %v, want %v", test.algorithm, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// sha512CryptPrefix is the prefix of the SHA-512 crypt hashes.
+	sha512CryptPrefix = "$6$"
+	// sha512CryptRoundsPrefix is the prefix of the custom rounds parameter.
+	sha512CryptRoundsPrefix = "rounds="
+	// sha512CryptDefaultRounds is the default number of rounds.
+	sha512CryptDefaultRounds = 5000
+	// sha512CryptMinimumRounds is the minimum number of rounds.
+	sha512CryptMinimumRounds = 1000
+	// sha512CryptMaximumRounds is the maximum number of rounds.
+	sha512CryptMaximumRounds = 999999999
+	// sha512CryptSaltLength is the maximum length of the salt.
+	sha512CryptSaltLength = 16
+	// cryptAlphabet is the alphabet used by the crypt base64 encoding.
+	cryptAlphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+var (
+	// sha512CryptPattern is the pattern used to detect SHA-512 crypt hashes.
+	sha512CryptPattern = regexp.MustCompile(`^\$6\$(rounds=[0-9]+\$)?[./A-Za-z0-9]{1,16}\$[./A-Za-z0-9]{86}$`)
+	// sha512CryptPermutation is the order in which digest bytes are encoded.
+	sha512CryptPermutation = [][3]int{
+		{0, 21, 42}, {22, 43, 1}, {44, 2, 23}, {3, 24, 45}, {25, 46, 4},
+		{47, 5, 26}, {6, 27, 48}, {28, 49, 7}, {50, 8, 29}, {9, 30, 51},
+		{31, 52, 10}, {53, 11, 32}, {12, 33, 54}, {34, 55, 13}, {56, 14, 35},
+		{15, 36, 57}, {37, 58, 16}, {59, 17, 38}, {18, 39, 60}, {40, 61, 19},
+		{62, 20, 41},
+	}
+)
+
+// IsSha512CryptHash checks if the given string is a SHA-512 crypt hash ($6$...).
+func IsSha512CryptHash(value string) bool {
+	return sha512CryptPattern.MatchString(value)
+}
+
+// HashPasswordSha512 hashes the password with SHA-512 crypt using a random salt.
+func HashPasswordSha512(password string) (string, error) {
+	salt, err := generateCryptSalt(sha512CryptSaltLength)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate password salt: %v", err)
+	}
+
+	return Sha512Crypt(password, salt, sha512CryptDefaultRounds), nil
+}
+
+// VerifySha512Crypt checks if the password matches the given SHA-512 crypt hash.
+func VerifySha512Crypt(password string, hash string) bool {
+	if !IsSha512CryptHash(hash) {
+		return false
+	}
+
+	parameters := strings.Split(strings.TrimPrefix(hash, sha512CryptPrefix), "$")
+	rounds := sha512CryptDefaultRounds
+
+	if strings.HasPrefix(parameters[0], sha512CryptRoundsPrefix) {
+		value, err := strconv.Atoi(strings.TrimPrefix(parameters[0], sha512CryptRoundsPrefix))
+		if err != nil {
+			return false
+		}
+		rounds = value
+		parameters = parameters[1:]
+	}
+
+	computed := Sha512Crypt(password, parameters[0], rounds)
+
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(hash)) == 1
+}
+
+// Sha512Crypt computes the SHA-512 crypt hash of the password as described in the "Unix crypt using SHA-256 and SHA-512" specification.
+func Sha512Crypt(password string, salt string, rounds int) string {
+	key := []byte(password)
+
+	if len(salt) > sha512CryptSaltLength {
+		salt = salt[:sha512CryptSaltLength]
+	}
+	saltBytes := []byte(salt)
+
+	customRounds := rounds != sha512CryptDefaultRounds
+	if rounds < sha512CryptMinimumRounds {
+		rounds = sha512CryptMinimumRounds
+	}
+	if rounds > sha512CryptMaximumRounds {
+		rounds = sha512CryptMaximumRounds
+	}
+
+	alternate := sha512.New()
+	alternate.Write(key)
+	alternate.Write(saltBytes)
+	alternate.Write(key)
+	alternateSum := alternate.Sum(nil)
+
+	intermediate := sha512.New()
+	intermediate.Write(key)
+	intermediate.Write(saltBytes)
+	intermediate.Write(repeatBytes(alternateSum, len(key)))
+	for length := len(key); length > 0; length >>= 1 {
+		if length&1 != 0 {
+			intermediate.Write(alternateSum)
+		} else {
+			intermediate.Write(key)
+		}
+	}
+	intermediateSum := intermediate.Sum(nil)
+
+	passwordHash := sha512.New()
+	for i := 0; i < len(key); i++ {
+		passwordHash.Write(key)
+	}
+	passwordSequence := repeatBytes(passwordHash.Sum(nil), len(key))
+
+	saltHash := sha512.New()
+	for i := 0; i < 16+int(intermediateSum[0]); i++ {
+		saltHash.Write(saltBytes)
+	}
+	saltSequence := repeatBytes(saltHash.Sum(nil), len(saltBytes))
+
+	digest := intermediateSum
+	for i := 0; i < rounds; i++ {
+		round := sha512.New()
+
+		if i&1 != 0 {
+			round.Write(passwordSequence)
+		} else {
+			round.Write(digest)
+		}
+		if i%3 != 0 {
+			round.Write(saltSequence)
+		}
+		if i%7 != 0 {
+			round.Write(passwordSequence)
+		}
+		if i&1 != 0 {
+			round.Write(digest)
+		} else {
+			round.Write(passwordSequence)
+		}
+
+		digest = round.Sum(nil)
+	}
+
+	var builder strings.Builder
+	builder.WriteString(sha512CryptPrefix)
+	if customRounds {
+		builder.WriteString(fmt.Sprintf("%s%d$", sha512CryptRoundsPrefix, rounds))
+	}
+	builder.WriteString(salt)
+	builder.WriteString("$")
+
+	for _, indexes := range sha512CryptPermutation {
+		encodeCryptBase64(&builder, digest[indexes[0]], digest[indexes[1]], digest[indexes[2]], 4)
+	}
+	encodeCryptBase64(&builder, 0, 0, digest[63], 2)
+
+	return builder.String()
+}
+
+// repeatBytes repeats the source bytes until the result has the given length.
+func repeatBytes(source []byte, length int) []byte {
+	result := make([]byte, 0, length)
+
+	for len(result) < length {
+		remaining := length - len(result)
+		if remaining > len(source) {
+			remaining = len(source)
+		}
+		result = append(result, source[:remaining]...)
+	}
+
+	return result
+}
+
+// encodeCryptBase64 encodes three bytes into the given number of crypt base64 characters.
+func encodeCryptBase64(builder *strings.Builder, first byte, second byte, third byte, count int) {
+	value := uint(first)<<16 | uint(second)<<8 | uint(third)
+
+	for i := 0; i < count; i++ {
+		builder.WriteByte(cryptAlphabet[value&0x3f])
+		value >>= 6
+	}
+}
+
+// generateCryptSalt generates a random salt of the given length using the crypt alphabet.
+func generateCryptSalt(length int) (string, error) {
+	salt := make([]byte, length)
+	limit := big.NewInt(int64(len(cryptAlphabet)))
+
+	for i := range salt {
+		index, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return "", err
+		}
+		salt[i] = cryptAlphabet[index.Int64()]
+	}
+
+	return string(salt), nil
+}
@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSha512Crypt tests the Sha512Crypt function.
+func TestSha512Crypt(t *testing.T) {
+	testCases := []struct {
+		password string
+		salt     string
+		rounds   int
+		expected string
+	}{
+		{
+			"Hello world!",
+			"saltstring",
+			5000,
+			"$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1",
+		},
+		{
+			"Hello world!",
+			"saltstringsaltstring",
+			10000,
+			"$6$rounds=10000$saltstringsaltst$OW1/O6BYHV6BcXZu8QVeXbDWra3Oeqh0sbHbbMCVNSnCM/UrjmM0Dp8vOuZeHBy/YTBmSK6H9qs/y3RnOaw5v.",
+		},
+		{
+			"a very much longer text to encrypt.  This one even stretches over morethan one line.",
+			"ab",
+			5000,
+			"$6$ab$ey1CbRuO0fE8pwDH3P1TcOrU0SwF.k1bCozLhKs9Bz5FiKo.6.ZBT.hPccQxA2/UATQinF80WYBRdOcldcluV.",
+		},
+	}
+
+	for _, tc := range testCases {
+		result := Sha512Crypt(tc.password, tc.salt, tc.rounds)
+		if result != tc.expected {
+			t.Errorf("Sha512Crypt(%s, %s, %d) = %v, want %v", tc.password, tc.salt, tc.rounds, result, tc.expected)
+		}
+	}
+}
+
+// TestHashPasswordSha512 tests the HashPasswordSha512 function.
+func TestHashPasswordSha512(t *testing.T) {
+	hash, err := HashPasswordSha512("secret")
+	if err != nil {
+		t.Fatalf("HashPasswordSha512 returned error: %v", err)
+	}
+
+	if !strings.HasPrefix(hash, "$6$") || !IsSha512CryptHash(hash) {
+		t.Errorf("HashPasswordSha512 returned %v, want SHA-512 crypt hash", hash)
+	}
+
+	if strings.Contains(hash, "secret") {
+		t.Errorf("HashPasswordSha512 returned hash containing the password")
+	}
+
+	if !VerifySha512Crypt("secret", hash) {
+		t.Errorf("VerifySha512Crypt returned false for the correct password")
+	}
+
+	if VerifySha512Crypt("other", hash) {
+		t.Errorf("VerifySha512Crypt returned true for the wrong password")
+	}
+
+	other, err := HashPasswordSha512("secret")
+	if err != nil {
+		t.Fatalf("HashPasswordSha512 returned error: %v", err)
+	}
+
+	if other == hash {
+		t.Errorf("HashPasswordSha512 returned the same hash twice, salt is not random")
+	}
+}
+
+// TestIsSha512CryptHash tests the IsSha512CryptHash function.
+func TestIsSha512CryptHash(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected bool
+	}{
+		{"$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1", true},
+		{"$6$rounds=10000$saltstringsaltst$OW1/O6BYHV6BcXZu8QVeXbDWra3Oeqh0sbHbbMCVNSnCM/UrjmM0Dp8vOuZeHBy/YTBmSK6H9qs/y3RnOaw5v.", true},
+		{"$5$saltstring$5B8vYYiY.CVt1RlTTf8KbXBH3hsxY/GNooZF4ATKK/1", false},
+		{"$6$saltstring$short", false},
+		{"password", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		result := IsSha512CryptHash(tc.input)
+		if result != tc.expected {
+			t.Errorf("IsSha512CryptHash(%s) = %v, want %v", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestVerifySha512Crypt tests the VerifySha512Crypt function.
+func TestVerifySha512Crypt(t *testing.T) {
+	hash := "$6$rounds=10000$saltstringsaltst$OW1/O6BYHV6BcXZu8QVeXbDWra3Oeqh0sbHbbMCVNSnCM/UrjmM0Dp8vOuZeHBy/YTBmSK6H9qs/y3RnOaw5v."
+
+	if !VerifySha512Crypt("Hello world!", hash) {
+		t.Errorf("VerifySha512Crypt returned false for the correct password")
+	}
+
+	if VerifySha512Crypt("Hello world", hash) {
+		t.Errorf("VerifySha512Crypt returned true for the wrong password")
+	}
+}
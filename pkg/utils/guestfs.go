@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GuestPathsExist checks (read-only, via guestfish) which of the given paths exist inside the image.
+func GuestPathsExist(image string, paths []string) (map[string]bool, error) {
+	arguments := []string{"--ro", "-a", image, "-i"}
+	for index, path := range paths {
+		if index > 0 {
+			arguments = append(arguments, ":")
+		}
+		arguments = append(arguments, "exists", path)
+	}
+
+	output, err := ExecuteCommand("guestfish", arguments...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect image `%s` with guestfish: %v", image, err)
+	}
+
+	return ParseGuestfishExists(paths, output)
+}
+
+// ParseGuestfishExists maps the output of the guestfish `exists` commands to the given paths.
+func ParseGuestfishExists(paths []string, output string) (map[string]bool, error) {
+	lines := RemoveEmptyStringsFromSlice(strings.Split(output, "\n"))
+	if len(lines) != len(paths) {
+		return nil, fmt.Errorf("unexpected guestfish output, expected %d results, got %d", len(paths), len(lines))
+	}
+
+	result := make(map[string]bool, len(paths))
+	for index, path := range paths {
+		switch strings.TrimSpace(lines[index]) {
+		case "true":
+			result[path] = true
+		case "false":
+			result[path] = false
+		default:
+			return nil, fmt.Errorf("unexpected guestfish output for `%s`: %s", path, lines[index])
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseGuestfishExists tests the ParseGuestfishExists function.
+func TestParseGuestfishExists(t *testing.T) {
+	paths := []string{"/usr/bin/qemu-ga", "/usr/sbin/qemu-ga"}
+
+	tests := []struct {
+		name      string
+		output    string
+		expected  map[string]bool
+		expectErr bool
+	}{
+		{
+			name:     "Valid Output",
+			output:   "false\ntrue\n",
+			expected: map[string]bool{"/usr/bin/qemu-ga": false, "/usr/sbin/qemu-ga": true},
+		},
+		{
+			name:      "Missing Results",
+			output:    "true\n",
+			expectErr: true,
+		},
+		{
+			name:      "Unexpected Result",
+			output:    "true\nmaybe\n",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := ParseGuestfishExists(paths, test.output)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("ParseGuestfishExists() error = %v, expectErr %v", err, test.expectErr)
+			}
+
+			if !test.expectErr && !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("ParseGuestfishExists returned %v, want %v", result, test.expected)
+			}
+		})
+	}
+}
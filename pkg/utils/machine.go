@@ -1,8 +1,20 @@
 package utils
 
 import (
+	"fmt"
 	"github.com/pbnjay/memory"
+	"os"
 	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// cpuInformationPath is the path to the CPU information file.
+	cpuInformationPath = "/proc/cpuinfo"
+	// hugePagesPath is the path to the directory with supported hugepage sizes.
+	hugePagesPath = "/sys/kernel/mm/hugepages"
 )
 
 // GetCoreCount returns the number of cores on the machine
@@ -14,3 +26,109 @@ func GetCoreCount() int {
 func GetTotalMemory() uint64 {
 	return memory.TotalMemory() / 1024 / 1024
 }
+
+// GetCpuFlags returns the list of CPU flags supported by the machine.
+func GetCpuFlags() []string {
+	content, err := os.ReadFile(cpuInformationPath)
+	if err != nil {
+		return []string{}
+	}
+
+	return ParseCpuFlags(string(content))
+}
+
+// GetHugePageSizes returns the list of hugepage sizes (in kilobytes) supported by the machine.
+func GetHugePageSizes() []int {
+	items, err := os.ReadDir(hugePagesPath)
+	if err != nil {
+		return []int{}
+	}
+
+	names := make([]string, 0)
+	for _, item := range items {
+		names = append(names, item.Name())
+	}
+
+	return ParseHugePageSizes(names)
+}
+
+// ParseCpuFlags parses the list of CPU flags from the contents of /proc/cpuinfo.
+func ParseCpuFlags(cpuInformation string) []string {
+	for _, line := range strings.Split(cpuInformation, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		if key != "flags" && key != "Features" {
+			continue
+		}
+
+		return strings.Fields(value)
+	}
+
+	return []string{}
+}
+
+// ParseHugePageSizes parses the list of hugepage sizes (in kilobytes) from the hugepages directory entries.
+func ParseHugePageSizes(entries []string) []int {
+	sizes := make([]int, 0)
+
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry, "hugepages-") || !strings.HasSuffix(entry, "kB") {
+			continue
+		}
+
+		size, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(entry, "hugepages-"), "kB"))
+		if err != nil {
+			continue
+		}
+
+		sizes = append(sizes, size)
+	}
+
+	sort.Ints(sizes)
+
+	return sizes
+}
+
+// ParseCpuList parses the list of CPUs (example: 0-3,8,10-11) into a sorted list of CPU indexes.
+func ParseCpuList(list string) ([]int, error) {
+	unique := make(map[int]bool)
+
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("invalid cpu list: %s", list)
+		}
+
+		start, end, isRange := strings.Cut(part, "-")
+
+		first, err := strconv.Atoi(start)
+		if err != nil || first < 0 {
+			return nil, fmt.Errorf("invalid cpu list: %s", list)
+		}
+
+		last := first
+		if isRange {
+			last, err = strconv.Atoi(end)
+			if err != nil || last < first {
+				return nil, fmt.Errorf("invalid cpu list: %s", list)
+			}
+		}
+
+		for cpu := first; cpu <= last; cpu++ {
+			unique[cpu] = true
+		}
+	}
+
+	cpus := make([]int, 0, len(unique))
+	for cpu := range unique {
+		cpus = append(cpus, cpu)
+	}
+
+	sort.Ints(cpus)
+
+	return cpus, nil
+}
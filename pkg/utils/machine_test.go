@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"reflect"
 	"runtime"
 	"testing"
 )
@@ -23,3 +24,55 @@ func TestGetTotalMemory(t *testing.T) {
 		t.Errorf("TestGetTotalMemory failed: expected total memory to be greater than zero")
 	}
 }
+
+// TestParseCpuFlags tests the ParseCpuFlags function
+func TestParseCpuFlags(t *testing.T) {
+	input := "processor\t: 0\nflags\t\t: fpu vme aes pdpe1gb\nprocessor\t: 1\nflags\t\t: fpu vme aes pdpe1gb\n"
+	expected := []string{"fpu", "vme", "aes", "pdpe1gb"}
+
+	if result := ParseCpuFlags(input); !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseCpuFlags() = %v, want %v", result, expected)
+	}
+
+	if result := ParseCpuFlags("processor\t: 0\n"); len(result) != 0 {
+		t.Errorf("ParseCpuFlags() = %v, want empty list", result)
+	}
+}
+
+// TestParseHugePageSizes tests the ParseHugePageSizes function
+func TestParseHugePageSizes(t *testing.T) {
+	input := []string{"hugepages-1048576kB", "hugepages-2048kB", "invalid"}
+	expected := []int{2048, 1048576}
+
+	if result := ParseHugePageSizes(input); !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseHugePageSizes() = %v, want %v", result, expected)
+	}
+}
+
+// TestParseCpuList tests the ParseCpuList function
+func TestParseCpuList(t *testing.T) {
+	testCases := []struct {
+		input     string
+		expected  []int
+		expectErr bool
+	}{
+		{"0", []int{0}, false},
+		{"0-3", []int{0, 1, 2, 3}, false},
+		{"0-1,4,6-7", []int{0, 1, 4, 6, 7}, false},
+		{"3,1-2,2", []int{1, 2, 3}, false},
+		{"3-1", nil, true},
+		{"a-b", nil, true},
+		{"1,,2", nil, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseCpuList(tc.input)
+		if (err != nil) != tc.expectErr {
+			t.Errorf("ParseCpuList(%s) error = %v, expectErr %v", tc.input, err, tc.expectErr)
+			continue
+		}
+		if !tc.expectErr && !reflect.DeepEqual(result, tc.expected) {
+			t.Errorf("ParseCpuList(%s) = %v, want %v", tc.input, result, tc.expected)
+		}
+	}
+}
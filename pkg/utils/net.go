@@ -1,6 +1,14 @@
 package utils
 
-import "net"
+import (
+	"net"
+	"regexp"
+)
+
+var (
+	// domainNamePattern is the pattern used to validate domain names.
+	domainNamePattern = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*\.?$`)
+)
 
 // IsValidIPWithSubnet checks if the given string is a valid IP with subnet.
 func IsValidIPWithSubnet(address string) bool {
@@ -23,3 +31,11 @@ func IsInSameNetwork(ip string, network string) bool {
 	}
 	return parsedNetwork.Contains(parsedIP)
 }
+
+// IsValidDomainName checks if the given string is a valid domain name.
+func IsValidDomainName(domain string) bool {
+	if len(domain) == 0 || len(domain) > 253 {
+		return false
+	}
+	return domainNamePattern.MatchString(domain)
+}
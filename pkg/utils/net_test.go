@@ -62,3 +62,26 @@ func TestIsInSameNetwork(t *testing.T) {
 		}
 	}
 }
+
+func TestIsValidDomainName(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected bool
+	}{
+		{"example.com", true},
+		{"lab.internal", true},
+		{"localdomain", true},
+		{"example.com.", true},
+		{"", false},
+		{"-example.com", false},
+		{"exa mple.com", false},
+		{"example..com", false},
+	}
+
+	for _, tc := range testCases {
+		result := IsValidDomainName(tc.input)
+		if result != tc.expected {
+			t.Errorf("IsValidDomainName(%s) = %v, want %v", tc.input, result, tc.expected)
+		}
+	}
+}
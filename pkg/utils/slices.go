@@ -12,3 +12,14 @@ func RemoveEmptyStringsFromSlice(slice []string) []string {
 
 	return newSlice
 }
+
+// SliceContains returns true if the slice contains the given value.
+func SliceContains(slice []string, value string) bool {
+	for _, item := range slice {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
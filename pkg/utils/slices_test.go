@@ -31,3 +31,20 @@ func TestRemoveEmptyStringsFromSlice(t *testing.T) {
 		t.Errorf("Test case 3 failed: expected %v, got %v", expected3, output3)
 	}
 }
+
+// TestSliceContains tests the SliceContains function.
+func TestSliceContains(t *testing.T) {
+	slice := []string{"disk", "network", "cpu"}
+
+	if !SliceContains(slice, "network") {
+		t.Errorf("SliceContains(%v, network) = false, want true", slice)
+	}
+
+	if SliceContains(slice, "memory") {
+		t.Errorf("SliceContains(%v, memory) = true, want false", slice)
+	}
+
+	if SliceContains([]string{}, "disk") {
+		t.Errorf("SliceContains([], disk) = true, want false")
+	}
+}
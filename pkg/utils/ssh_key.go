@@ -0,0 +1,248 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	// SshKeyMinimumRsaBits is the minimum accepted size of RSA keys.
+	SshKeyMinimumRsaBits = 2048
+)
+
+var (
+	// sshKeyEcdsaCurves maps the ECDSA key types to their curve names and point sizes.
+	sshKeyEcdsaCurves = map[string]struct {
+		curve string
+		bits  int
+		size  int
+	}{
+		"ecdsa-sha2-nistp256":                {"nistp256", 256, 65},
+		"ecdsa-sha2-nistp384":                {"nistp384", 384, 97},
+		"ecdsa-sha2-nistp521":                {"nistp521", 521, 133},
+		"sk-ecdsa-sha2-nistp256@openssh.com": {"nistp256", 256, 65},
+	}
+	// sshKeyWeakAlgorithms is the list of algorithms that are rejected.
+	sshKeyWeakAlgorithms = []string{
+		"ssh-dss",
+	}
+	// sshKeyAlgorithms is the list of supported algorithms.
+	sshKeyAlgorithms = []string{
+		"ssh-rsa",
+		"ssh-ed25519",
+		"sk-ssh-ed25519@openssh.com",
+		"ecdsa-sha2-nistp256",
+		"ecdsa-sha2-nistp384",
+		"ecdsa-sha2-nistp521",
+		"sk-ecdsa-sha2-nistp256@openssh.com",
+	}
+)
+
+// SshPublicKey represents the parsed SSH public key.
+type SshPublicKey struct {
+	// algorithm is the algorithm of the key (for example, ssh-ed25519).
+	algorithm string
+	// bits is the size of the key in bits.
+	bits int
+	// blob is the decoded wire format of the key.
+	blob []byte
+	// comment is the comment of the key.
+	comment string
+}
+
+// GetAlgorithm returns the algorithm of the key.
+func (key *SshPublicKey) GetAlgorithm() string {
+	return key.algorithm
+}
+
+// GetBits returns the size of the key in bits.
+func (key *SshPublicKey) GetBits() int {
+	return key.bits
+}
+
+// GetComment returns the comment of the key.
+func (key *SshPublicKey) GetComment() string {
+	return key.comment
+}
+
+// GetFingerprint returns the SHA256 fingerprint of the key (as printed by ssh-keygen -l).
+func (key *SshPublicKey) GetFingerprint() string {
+	sum := sha256.Sum256(key.blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// String returns the key in the authorized_keys format (without options).
+func (key *SshPublicKey) String() string {
+	line := key.algorithm + " " + base64.StdEncoding.EncodeToString(key.blob)
+	if key.comment != "" {
+		line += " " + key.comment
+	}
+	return line
+}
+
+// IsSshKeyLine checks if the line contains a key (skips empty lines and comments).
+func IsSshKeyLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed != "" && !strings.HasPrefix(trimmed, "#")
+}
+
+// ParseSshPublicKey parses the public key in the authorized_keys format (options before the key are skipped).
+func ParseSshPublicKey(line string) (*SshPublicKey, error) {
+	fields := strings.Fields(strings.TrimSpace(line))
+
+	for index, field := range fields {
+		if SliceContains(sshKeyWeakAlgorithms, field) {
+			return nil, fmt.Errorf("ssh key algorithm `%s` is not allowed", field)
+		}
+
+		if strings.HasSuffix(field, "-cert-v01@openssh.com") {
+			return nil, fmt.Errorf("ssh certificates (`%s`) are not supported", field)
+		}
+
+		if !SliceContains(sshKeyAlgorithms, field) {
+			continue
+		}
+
+		if index+1 >= len(fields) {
+			return nil, fmt.Errorf("ssh key of type `%s` is missing the key data", field)
+		}
+
+		blob, err := base64.StdEncoding.DecodeString(fields[index+1])
+		if err != nil {
+			return nil, fmt.Errorf("ssh key of type `%s` has invalid base64 data: %v", field, err)
+		}
+
+		bits, err := parseSshKeyBlob(field, blob)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SshPublicKey{
+			algorithm: field,
+			bits:      bits,
+			blob:      blob,
+			comment:   strings.Join(fields[index+2:], " "),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("ssh key has unsupported or missing key type")
+}
+
+// ParseAuthorizedKeys parses all keys from the authorized_keys content, returning valid keys and errors for invalid lines.
+func ParseAuthorizedKeys(content string) ([]*SshPublicKey, []error) {
+	keys := make([]*SshPublicKey, 0)
+	errors := make([]error, 0)
+
+	for number, line := range strings.Split(content, "\n") {
+		if !IsSshKeyLine(line) {
+			continue
+		}
+
+		key, err := ParseSshPublicKey(line)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("line %d: %v", number+1, err))
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, errors
+}
+
+// parseSshKeyBlob validates the wire format of the key and returns its size in bits.
+func parseSshKeyBlob(algorithm string, blob []byte) (int, error) {
+	reader := &sshWireReader{data: blob}
+
+	declared, err := reader.readString()
+	if err != nil {
+		return 0, fmt.Errorf("ssh key of type `%s` is malformed: %v", algorithm, err)
+	}
+
+	if string(declared) != algorithm {
+		return 0, fmt.Errorf("ssh key type `%s` does not match the encoded type `%s`", algorithm, string(declared))
+	}
+
+	bits := 0
+
+	switch {
+	case algorithm == "ssh-rsa":
+		exponent, err := reader.readString()
+		if err != nil || len(exponent) == 0 {
+			return 0, fmt.Errorf("ssh key of type `%s` has malformed exponent", algorithm)
+		}
+
+		modulus, err := reader.readString()
+		if err != nil || len(modulus) == 0 {
+			return 0, fmt.Errorf("ssh key of type `%s` has malformed modulus", algorithm)
+		}
+
+		bits = new(big.Int).SetBytes(modulus).BitLen()
+		if bits < SshKeyMinimumRsaBits {
+			return 0, fmt.Errorf("ssh rsa key is %d bits, minimum allowed is %d bits", bits, SshKeyMinimumRsaBits)
+		}
+	case algorithm == "ssh-ed25519" || algorithm == "sk-ssh-ed25519@openssh.com":
+		point, err := reader.readString()
+		if err != nil || len(point) != 32 {
+			return 0, fmt.Errorf("ssh key of type `%s` has malformed public key", algorithm)
+		}
+		bits = 256
+	default:
+		curve := sshKeyEcdsaCurves[algorithm]
+
+		name, err := reader.readString()
+		if err != nil || string(name) != curve.curve {
+			return 0, fmt.Errorf("ssh key of type `%s` has mismatched curve", algorithm)
+		}
+
+		point, err := reader.readString()
+		if err != nil || len(point) != curve.size || point[0] != 0x04 {
+			return 0, fmt.Errorf("ssh key of type `%s` has malformed public point", algorithm)
+		}
+		bits = curve.bits
+	}
+
+	if strings.HasPrefix(algorithm, "sk-") {
+		if _, err := reader.readString(); err != nil {
+			return 0, fmt.Errorf("ssh key of type `%s` is missing the application", algorithm)
+		}
+	}
+
+	if !reader.isEmpty() {
+		return 0, fmt.Errorf("ssh key of type `%s` has trailing data", algorithm)
+	}
+
+	return bits, nil
+}
+
+// sshWireReader reads length-prefixed strings from the SSH wire format.
+type sshWireReader struct {
+	// data is the remaining data.
+	data []byte
+}
+
+// readString reads the next length-prefixed string.
+func (reader *sshWireReader) readString() ([]byte, error) {
+	if len(reader.data) < 4 {
+		return nil, fmt.Errorf("unexpected end of data")
+	}
+
+	length := binary.BigEndian.Uint32(reader.data[:4])
+	if uint64(length) > uint64(len(reader.data)-4) {
+		return nil, fmt.Errorf("string length %d exceeds remaining data", length)
+	}
+
+	value := reader.data[4 : 4+length]
+	reader.data = reader.data[4+length:]
+
+	return value, nil
+}
+
+// isEmpty returns true if all data was read.
+func (reader *sshWireReader) isEmpty() bool {
+	return len(reader.data) == 0
+}
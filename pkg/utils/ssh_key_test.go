@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	// testSshKeyRsa2048 is the 2048 bit RSA key used in tests.
+	testSshKeyRsa2048 = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCgj57b92l6AnH7aOvq/+6JbGl6JrqjYVCpXUEg7eIqNpU18oCLkn8XN5rCFYmvVTc8yP8DnzlpduN013HGP4Ghqf+33S//PXq97u0LzFLdUiHqZr55/ZysgcjBLDrs71xWun6E3cT8MvO5S/Yh5BTqsuP0mjIqoE4fw//g8pmuMx6u6CwiGDx3KPOpFFAzU74/2pbVzfaouUoCsc5rPdQJqS6YO7hhbFNVgquM1i2l4IFjsgYUkiF1vnQlwqntLt7jBNrfc5T3lhzX47rlcK7AFkrnqjxY6oGUfCoBidPmDkM8A0HvJC5U/oZ0GQourqhx5e1tpWKVzhifueFYDYVB user@example.com"
+	// testSshKeyRsa1024 is the 1024 bit RSA key used in tests.
+	testSshKeyRsa1024 = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQCYID8wzSmmNYIyweQLk4TCJCGCCDAza+wl3PxpKslPV7LdZwdpfxo0Q2vlXsv4SN5wmJXJwwwE6ekGgMUFP4TtsGuyzXKxa1+jnBzc1+e/BtJ1AFdVAGzlUcqbZeHlnXfTlF+pnDrnXqG1jdJDsJZcgrZOK6uUQKMfbU/KoZM56w== user@example.com"
+	// testSshKeyDss is the DSA key used in tests.
+	testSshKeyDss = "ssh-dss AAAAB3NzaC1kc3MAAABBAPymgs6OEsq6Ju/M9xEOUm2weLBe3svNHrSiCPOuFheuAfNbkaR+bfY0E8XhLtCJm80TKs1Q2ZFRvcQ+5zdZLhcAAAAVAJYu3cw2nLqOuyYO5rahJtk0bjjFAAAAQGeEcbJ6nPRO6RpJxRR9samq8kTwWkNNZIaTHS0UJxueNQMLcf1z2heQabMuKTVjDhwgYjVNDaIKbEFuUL55TKQAAABBAN+ERyjUsXa0aO4tjZT+6HlAzW99K8yGEqp7rMGDz70t1+snD7/epOWxGCfasVnRn9qvPXR8clpOOnDinlBzuT4= user@example.com"
+	// testSshKeyEcdsa256 is the ECDSA P-256 key used in tests.
+	testSshKeyEcdsa256 = "ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBGkgZSuqj4Y0iWQP3YJCwR7gBMubTQsBewNMscVxYVJ3EA5rUsOWFDJ1J7MSDf9Iz6isc6YFxclvzCWi1/YE74o= user@example.com"
+	// testSshKeyEcdsa384 is the ECDSA P-384 key used in tests.
+	testSshKeyEcdsa384 = "ecdsa-sha2-nistp384 AAAAE2VjZHNhLXNoYTItbmlzdHAzODQAAAAIbmlzdHAzODQAAABhBGtpp+CYbXFW6HpY0eBJmOqD2bpX9WGZv3yAZ4No9JA616xZ4H5fAtcwxNgrBDrlCk1ajCnjEnNGflSpqsKaki6GJldgAqws9UuXY4KNPmfictdi+YCuv8VQRBaa4VfTsQ== user@example.com"
+	// testSshKeyEcdsa521 is the ECDSA P-521 key used in tests.
+	testSshKeyEcdsa521 = "ecdsa-sha2-nistp521 AAAAE2VjZHNhLXNoYTItbmlzdHA1MjEAAAAIbmlzdHA1MjEAAACFBACH5RBUOVjmexlGTX0hzQs06/kOCDngLuxLaAbyGaD4KCdwKBJX45mdTPQQRkd8YQ7QHlkg28mlvKeUbEM39Y6S8QDnp8y3XYzOXKFtg9jp6sunUWDeL+9S42n6imAxnofVTkFhI35tgAM0QD+MvATnw6ZZe2aRKvU/49yLpLXEKCzoLQ== user@example.com"
+	// testSshKeyEd25519 is the Ed25519 key used in tests.
+	testSshKeyEd25519 = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4F/3yw1Jgok9b52nCDrtVffYtVNK4yqegGzeQ/NgS user@example.com"
+)
+
+// TestParseSshPublicKey tests the ParseSshPublicKey function.
+func TestParseSshPublicKey(t *testing.T) {
+	testCases := []struct {
+		name      string
+		line      string
+		algorithm string
+		bits      int
+		expectErr bool
+	}{
+		{"Rsa2048", testSshKeyRsa2048, "ssh-rsa", 2048, false},
+		{"Rsa1024", testSshKeyRsa1024, "", 0, true},
+		{"Dss", testSshKeyDss, "", 0, true},
+		{"Ecdsa256", testSshKeyEcdsa256, "ecdsa-sha2-nistp256", 256, false},
+		{"Ecdsa384", testSshKeyEcdsa384, "ecdsa-sha2-nistp384", 384, false},
+		{"Ecdsa521", testSshKeyEcdsa521, "ecdsa-sha2-nistp521", 521, false},
+		{"Ed25519", testSshKeyEd25519, "ssh-ed25519", 256, false},
+		{"Ed25519WithOptions", `from="10.0.0.0/8",no-pty ` + testSshKeyEd25519, "ssh-ed25519", 256, false},
+		{"MismatchedType", strings.Replace(testSshKeyEd25519, "ssh-ed25519", "ecdsa-sha2-nistp256", 1), "", 0, true},
+		{"InvalidBase64", "ssh-ed25519 invalidkeydata user@example.com", "", 0, true},
+		{"TruncatedKey", "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEI4 user@example.com", "", 0, true},
+		{"MissingData", "ssh-ed25519", "", 0, true},
+		{"UnknownType", "ssh-invalid AAAAB3NzaC1yc2EAAAADAQABAAABAQ user@example.com", "", 0, true},
+		{"Certificate", "ssh-ed25519-cert-v01@openssh.com AAAA user@example.com", "", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := ParseSshPublicKey(tc.line)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("ParseSshPublicKey returned error %v, expectErr %v", err, tc.expectErr)
+			}
+
+			if tc.expectErr {
+				return
+			}
+
+			if key.GetAlgorithm() != tc.algorithm || key.GetBits() != tc.bits {
+				t.Errorf("ParseSshPublicKey returned %s (%d bits), want %s (%d bits)", key.GetAlgorithm(), key.GetBits(), tc.algorithm, tc.bits)
+			}
+
+			if key.GetComment() != "user@example.com" {
+				t.Errorf("GetComment returned %v, want %v", key.GetComment(), "user@example.com")
+			}
+		})
+	}
+}
+
+// TestSshPublicKeyGetFingerprint tests the GetFingerprint function.
+func TestSshPublicKeyGetFingerprint(t *testing.T) {
+	testCases := []struct {
+		line     string
+		expected string
+	}{
+		{testSshKeyEd25519, "SHA256:QrdBIUZvmt31xGdXsVfFyaVZud2AesmVZ7iJxZGXtMg"},
+		{testSshKeyEcdsa256, "SHA256:tU7/TXg79kSvj3UNDI6i2bYcLFxIftbfAQmsCkaJR4U"},
+		{testSshKeyRsa2048, "SHA256:9UOZYyCt7/wX1y9T5R+y7a79t6O/EOoVknUsOJs33gI"},
+	}
+
+	for _, tc := range testCases {
+		key, err := ParseSshPublicKey(tc.line)
+		if err != nil {
+			t.Fatalf("ParseSshPublicKey returned error: %v", err)
+		}
+
+		if key.GetFingerprint() != tc.expected {
+			t.Errorf("GetFingerprint returned %v, want %v", key.GetFingerprint(), tc.expected)
+		}
+	}
+}
+
+// TestSshPublicKeyString tests the String function.
+func TestSshPublicKeyString(t *testing.T) {
+	key, err := ParseSshPublicKey(`no-pty ` + testSshKeyEd25519)
+	if err != nil {
+		t.Fatalf("ParseSshPublicKey returned error: %v", err)
+	}
+
+	if key.String() != testSshKeyEd25519 {
+		t.Errorf("String returned %v, want %v", key.String(), testSshKeyEd25519)
+	}
+}
+
+// TestParseAuthorizedKeys tests the ParseAuthorizedKeys function.
+func TestParseAuthorizedKeys(t *testing.T) {
+	content := strings.Join([]string{
+		"# administrators",
+		testSshKeyEd25519,
+		"",
+		testSshKeyDss,
+		testSshKeyRsa2048,
+		"   ",
+	}, "\n")
+
+	keys, errors := ParseAuthorizedKeys(content)
+
+	if len(keys) != 2 {
+		t.Errorf("ParseAuthorizedKeys returned %d keys, want %d", len(keys), 2)
+	}
+
+	if len(errors) != 1 || !strings.HasPrefix(errors[0].Error(), "line 4:") {
+		t.Errorf("ParseAuthorizedKeys returned errors %v, want single error for line 4", errors)
+	}
+}
@@ -3,9 +3,15 @@ package virt_customize
 import (
 	"bytes"
 	"fmt"
+	cic "github.com/darki73/ptm/pkg/configuration/cloud-init"
+	"github.com/darki73/ptm/pkg/configuration/repositories"
 	uuc "github.com/darki73/ptm/pkg/configuration/unattended-upgrades"
+	"github.com/darki73/ptm/pkg/distributions"
+	"github.com/darki73/ptm/pkg/utils"
+	ci "github.com/darki73/ptm/pkg/virt-customize/cloud-init"
 	"github.com/darki73/ptm/pkg/virt-customize/command"
 	uu "github.com/darki73/ptm/pkg/virt-customize/unattended-upgrades"
+	"github.com/darki73/ptm/pkg/virt-customize/watchdog"
 	"os"
 	"os/exec"
 	"sort"
@@ -14,6 +20,8 @@ import (
 const (
 	// virtCustomizeCommand is the command to run virt-customize.
 	virtCustomizeCommand = "virt-customize"
+	// guestAgentService is the name of the package and service of the QEMU guest agent.
+	guestAgentService = "qemu-guest-agent"
 )
 
 // CommandLineInterface is a structure that holds information for virt-customize CLI.
@@ -67,16 +75,22 @@ func (cli *CommandLineInterface) buildCommandsList() error {
 	configuration := cli.configuration
 	image := configuration.GetImage()
 
-	cli.addCommand(command.NewUpdateCommand(image))
-	cli.addCommand(command.NewInstallCommand(image, configuration.GetPackages()))
+	cli.updatePackages(image)
+	cli.installPackages(image, configuration.GetPackages())
+
+	// NOTE: Packages do not enable their services on OpenRC, so the guest agent would never start.
+	if configuration.GetInitSystem() == distributions.InitSystemOpenRC && utils.SliceContains(configuration.GetPackages(), guestAgentService) {
+		cli.enableService(image, guestAgentService)
+	}
 
 	for _, repository := range configuration.GetRepositoriesConfiguration() {
-		cli.addCommand(command.NewAddGPGCommand(image, repository))
-		cli.addCommand(command.NewAddRepositoryCommand(image, repository))
+		cli.addRepository(image, repository)
 	}
 
 	unattendedUpgradesConfiguration := configuration.GetUnattendedUpgradesConfiguration()
-	if unattendedUpgradesConfiguration.GetEnabled() {
+	if unattendedUpgradesConfiguration.GetEnabled() && configuration.GetPackageManager() != distributions.PackageManagerApt {
+		fmt.Println("Unattended upgrades are only supported for apt based distributions, skipping.")
+	} else if unattendedUpgradesConfiguration.GetEnabled() {
 		if err := cli.uploadUnattendedUpgradesConfiguration(image, unattendedUpgradesConfiguration); err != nil {
 			return err
 		}
@@ -86,7 +100,34 @@ func (cli *CommandLineInterface) buildCommandsList() error {
 		}
 	}
 
-	cli.addCommand(command.NewUpdateCommand(image))
+	if configuration.IsWatchdogEnabled() {
+		if err := cli.enableWatchdogDaemon(image, configuration.GetWatchdogModel()); err != nil {
+			return err
+		}
+	}
+
+	if configuration.IsCloudInitTuningEnabled() {
+		if err := cli.uploadCloudInitConfiguration(image, configuration.GetCloudInitImageConfiguration()); err != nil {
+			return err
+		}
+	}
+
+	cli.updatePackages(image)
+
+	// NOTE: The keyring is only needed during customization, clones must not share its local master key.
+	if configuration.GetPackageManager() == distributions.PackageManagerPacman {
+		cli.addCommand(command.NewPacmanKeyringCleanupCommand(image))
+	}
+
+	// NOTE: State is cleaned last, so nothing executed during customization leaves traces for the first boot of clones.
+	if configuration.IsCloudInitTuningEnabled() && configuration.GetCloudInitImageConfiguration().GetCleanState() {
+		cli.addCommand(command.NewRunCommand(image, ci.GetCleanStateCommand()))
+	}
+
+	// NOTE: Relabeling must be the last step, otherwise files changed after it would have wrong SELinux contexts and the image may fail to boot.
+	if configuration.GetSELinuxRelabel() {
+		cli.addCommand(command.NewSELinuxRelabelCommand(image))
+	}
 
 	return nil
 }
@@ -100,6 +141,73 @@ func (cli *CommandLineInterface) addCommand(command *command.Command) *CommandLi
 	return cli
 }
 
+// updatePackages adds the command which updates the installed packages with the package manager.
+func (cli *CommandLineInterface) updatePackages(image string) {
+	switch cli.configuration.GetPackageManager() {
+	case distributions.PackageManagerZypper:
+		cli.addCommand(command.NewZypperUpdateCommand(image))
+	case distributions.PackageManagerApk:
+		cli.addCommand(command.NewApkUpgradeCommand(image))
+	case distributions.PackageManagerPacman:
+		cli.addCommand(command.NewPacmanUpgradeCommand(image))
+	default:
+		cli.addCommand(command.NewUpdateCommand(image))
+	}
+}
+
+// installPackages adds the command which installs the packages with the package manager.
+func (cli *CommandLineInterface) installPackages(image string, packages []string) {
+	switch cli.configuration.GetPackageManager() {
+	case distributions.PackageManagerZypper:
+		cli.addCommand(command.NewZypperInstallCommand(image, packages))
+	case distributions.PackageManagerApk:
+		cli.addCommand(command.NewApkInstallCommand(image, packages))
+	case distributions.PackageManagerPacman:
+		cli.addCommand(command.NewPacmanInstallCommand(image, packages))
+	default:
+		cli.addCommand(command.NewInstallCommand(image, packages))
+	}
+}
+
+// addRepository adds the commands which configure the repository in the format of the package manager.
+func (cli *CommandLineInterface) addRepository(image string, repository *repositories.Configuration) {
+	switch cli.configuration.GetPackageManager() {
+	case distributions.PackageManagerDnf:
+		if repository.GetGPG() != "" {
+			cli.addCommand(command.NewImportGPGCommand(image, repository))
+		}
+		cli.addCommand(command.NewAddYumRepositoryCommand(image, repository))
+	case distributions.PackageManagerZypper:
+		if repository.GetGPG() != "" {
+			cli.addCommand(command.NewImportGPGCommand(image, repository))
+		}
+		cli.addCommand(command.NewAddZypperRepositoryCommand(image, repository))
+	case distributions.PackageManagerApk:
+		if repository.GetGPG() != "" {
+			cli.addCommand(command.NewAddApkKeyCommand(image, repository))
+		}
+		cli.addCommand(command.NewAddApkRepositoryCommand(image, repository))
+	case distributions.PackageManagerPacman:
+		if repository.GetGPG() != "" {
+			cli.addCommand(command.NewAddPacmanKeyCommand(image, repository))
+		}
+		cli.addCommand(command.NewAddPacmanRepositoryCommand(image, repository))
+	default:
+		cli.addCommand(command.NewAddGPGCommand(image, repository))
+		cli.addCommand(command.NewAddRepositoryCommand(image, repository))
+	}
+}
+
+// enableService adds the command which enables the service with the init system.
+func (cli *CommandLineInterface) enableService(image string, service string) {
+	if cli.configuration.GetInitSystem() == distributions.InitSystemOpenRC {
+		cli.addCommand(command.NewOpenRCEnableCommand(image, service))
+		return
+	}
+
+	cli.addCommand(command.NewSystemdEnableCommand(image, service))
+}
+
 // addCleanupFunction adds a cleanup function to the list of cleanup functions.
 func (cli *CommandLineInterface) addCleanupFunction(cleanupFunction func() error) *CommandLineInterface {
 	cli.cleanupFunctions = append(cli.cleanupFunctions, cleanupFunction)
@@ -177,6 +285,121 @@ func (cli *CommandLineInterface) uploadAutoUpgradesConfiguration(image string) e
 	return nil
 }
 
+// enableWatchdogDaemon installs, configures and enables the in-guest watchdog daemon.
+func (cli *CommandLineInterface) enableWatchdogDaemon(image string, model string) error {
+	if cli.configuration.GetInitSystem() == distributions.InitSystemOpenRC {
+		return cli.enableOpenRCWatchdogDaemon(image, model)
+	}
+
+	// NOTE: Only Debian based distributions read the kernel module from the defaults file, others load it with systemd.
+	watchdogDefaultsPath := watchdog.GetWatchdogDefaultsPath()
+	watchdogDefaultsTemporaryPath := watchdog.GetWatchdogDefaultsTemporaryPath()
+	watchdogDefaults, err := watchdog.BuildWatchdogDefaults(model)
+	if cli.configuration.GetPackageManager() != distributions.PackageManagerApt {
+		watchdogDefaultsPath = watchdog.GetWatchdogModulesLoadPath()
+		watchdogDefaultsTemporaryPath = watchdog.GetWatchdogModulesLoadTemporaryPath()
+		watchdogDefaults, err = watchdog.BuildWatchdogModulesLoad(model)
+	}
+	if err != nil {
+		return err
+	}
+
+	watchdogConfigurationFileHandle, err := cli.createTemporaryFile(
+		"watchdog",
+		watchdog.GetWatchdogConfigurationTemporaryPath(),
+		watchdog.GetWatchdogConfigurationTemplate(),
+	)
+	if err != nil {
+		return err
+	}
+
+	cli.addCleanupFunction(func() error {
+		return os.Remove(watchdogConfigurationFileHandle.Name())
+	})
+
+	watchdogDefaultsFileHandle, err := cli.createTemporaryFile(
+		"watchdog defaults",
+		watchdogDefaultsTemporaryPath,
+		watchdogDefaults,
+	)
+	if err != nil {
+		return err
+	}
+
+	cli.addCleanupFunction(func() error {
+		return os.Remove(watchdogDefaultsFileHandle.Name())
+	})
+
+	cli.installPackages(image, []string{watchdog.GetWatchdogPackage()})
+	cli.addCommand(command.NewUploadCommand(image, watchdogConfigurationFileHandle.Name(), watchdog.GetWatchdogConfigurationPath()))
+	cli.addCommand(command.NewUploadCommand(image, watchdogDefaultsFileHandle.Name(), watchdogDefaultsPath))
+	cli.enableService(image, watchdog.GetWatchdogService())
+
+	return nil
+}
+
+// enableOpenRCWatchdogDaemon installs and enables the busybox watchdog daemon on OpenRC based distributions.
+func (cli *CommandLineInterface) enableOpenRCWatchdogDaemon(image string, model string) error {
+	loadModuleCommand, err := watchdog.BuildWatchdogOpenRCModulesCommand(model)
+	if err != nil {
+		return err
+	}
+
+	cli.installPackages(image, []string{watchdog.GetWatchdogOpenRCPackage()})
+	cli.addCommand(command.NewRunCommand(image, loadModuleCommand))
+	cli.enableService(image, watchdog.GetWatchdogService())
+
+	return nil
+}
+
+// uploadCloudInitConfiguration uploads the cloud-init drop-ins and disables the unneeded cloud-init modules.
+func (cli *CommandLineInterface) uploadCloudInitConfiguration(image string, configuration *cic.CloudInitImage) error {
+	datasourceConfiguration, err := ci.BuildDatasourceConfiguration(configuration.GetDatasourceList())
+	if err != nil {
+		return err
+	}
+
+	datasourceConfigurationFileHandle, err := cli.createTemporaryFile(
+		"cloud-init datasource",
+		ci.GetDatasourceConfigurationTemporaryPath(),
+		datasourceConfiguration,
+	)
+	if err != nil {
+		return err
+	}
+
+	cli.addCleanupFunction(func() error {
+		return os.Remove(datasourceConfigurationFileHandle.Name())
+	})
+
+	hostnameConfigurationFileHandle, err := cli.createTemporaryFile(
+		"cloud-init hostname",
+		ci.GetHostnameConfigurationTemporaryPath(),
+		ci.BuildHostnameConfiguration(configuration.GetPreserveHostname()),
+	)
+	if err != nil {
+		return err
+	}
+
+	cli.addCleanupFunction(func() error {
+		return os.Remove(hostnameConfigurationFileHandle.Name())
+	})
+
+	cli.addCommand(command.NewUploadCommand(image, datasourceConfigurationFileHandle.Name(), ci.GetDatasourceConfigurationPath()))
+	cli.addCommand(command.NewUploadCommand(image, hostnameConfigurationFileHandle.Name(), ci.GetHostnameConfigurationPath()))
+
+	if len(configuration.GetDisabledModules()) > 0 {
+		disableModulesCommand, err := ci.BuildDisableModulesCommand(configuration.GetDisabledModules())
+		if err != nil {
+			return err
+		}
+
+		cli.addCommand(command.NewRunCommand(image, disableModulesCommand))
+	}
+
+	return nil
+}
+
 // createTemporaryFile creates a temporary file.
 func (cli *CommandLineInterface) createTemporaryFile(actor string, temporaryPath string, configuration string) (*os.File, error) {
 	if _, err := os.Stat(temporaryPath); err == nil {
@@ -0,0 +1,106 @@
+package cloud_init
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// datasourcePattern is the pattern used to validate the names of cloud-init datasources.
+	datasourcePattern = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+	// modulePattern is the pattern used to validate the names of cloud-init modules.
+	modulePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+)
+
+// datasourceConfigurationTemplate represents the template for the datasource configuration.
+// /etc/cloud/cloud.cfg.d/99_ptm_datasource.cfg
+const datasourceConfigurationTemplate = `
+datasource_list: [ %s ]
+`
+
+// hostnameConfigurationTemplate represents the template for the hostname configuration.
+// /etc/cloud/cloud.cfg.d/99_ptm_hostname.cfg
+const hostnameConfigurationTemplate = `
+preserve_hostname: %t
+`
+
+// GetCloudConfigurationPath returns the path to the main cloud-init configuration.
+func GetCloudConfigurationPath() string {
+	return "/etc/cloud/cloud.cfg"
+}
+
+// GetDatasourceConfigurationPath returns the path to the datasource configuration drop-in.
+func GetDatasourceConfigurationPath() string {
+	return "/etc/cloud/cloud.cfg.d/99_ptm_datasource.cfg"
+}
+
+// GetDatasourceConfigurationTemporaryPath returns the path to the datasource configuration temporary file.
+func GetDatasourceConfigurationTemporaryPath() string {
+	return "/tmp/ptm-99_ptm_datasource.cfg"
+}
+
+// GetHostnameConfigurationPath returns the path to the hostname configuration drop-in.
+func GetHostnameConfigurationPath() string {
+	return "/etc/cloud/cloud.cfg.d/99_ptm_hostname.cfg"
+}
+
+// GetHostnameConfigurationTemporaryPath returns the path to the hostname configuration temporary file.
+func GetHostnameConfigurationTemporaryPath() string {
+	return "/tmp/ptm-99_ptm_hostname.cfg"
+}
+
+// GetCleanStateCommand returns the command which removes the cloud-init state and logs from the image.
+func GetCleanStateCommand() string {
+	return "cloud-init clean --logs"
+}
+
+// BuildDatasourceConfiguration builds the datasource configuration restricting the datasources cloud-init probes.
+func BuildDatasourceConfiguration(datasources []string) (string, error) {
+	if len(datasources) == 0 {
+		return "", fmt.Errorf("cloud-init datasource list cannot be empty")
+	}
+
+	for _, datasource := range datasources {
+		if !datasourcePattern.MatchString(datasource) {
+			return "", fmt.Errorf("cloud-init datasource `%s` is not valid", datasource)
+		}
+	}
+
+	return fmt.Sprintf(datasourceConfigurationTemplate, strings.Join(datasources, ", ")), nil
+}
+
+// BuildHostnameConfiguration builds the hostname configuration.
+func BuildHostnameConfiguration(preserveHostname bool) string {
+	return fmt.Sprintf(hostnameConfigurationTemplate, preserveHostname)
+}
+
+// BuildDisableModulesCommand builds the command which removes the modules from the module lists of the main cloud-init configuration.
+// NOTE: Drop-ins can only replace module lists as a whole, which would require knowing the lists shipped with the image.
+func BuildDisableModulesCommand(modules []string) (string, error) {
+	if len(modules) == 0 {
+		return "", fmt.Errorf("no cloud-init modules to disable")
+	}
+
+	normalizedModules := make([]string, 0, len(modules))
+	for _, module := range modules {
+		normalizedModule := NormalizeModuleName(module)
+		if !modulePattern.MatchString(normalizedModule) {
+			return "", fmt.Errorf("cloud-init module `%s` is not valid", module)
+		}
+		normalizedModules = append(normalizedModules, normalizedModule)
+	}
+
+	return fmt.Sprintf(
+		`sed -i -E '/^[[:space:]]*-[[:space:]]*\[?[[:space:]]*(%s)[[:space:]]*(,.*)?\]?[[:space:]]*$/d' %s`,
+		strings.Join(normalizedModules, "|"),
+		GetCloudConfigurationPath(),
+	), nil
+}
+
+// NormalizeModuleName normalizes the name of the cloud-init module (cloud-init accepts `cc_` prefix and dashes).
+func NormalizeModuleName(module string) string {
+	module = strings.ToLower(strings.TrimSpace(module))
+	module = strings.TrimPrefix(module, "cc_")
+	return strings.ReplaceAll(module, "-", "_")
+}
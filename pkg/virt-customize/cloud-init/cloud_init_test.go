@@ -0,0 +1,134 @@
+package cloud_init
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestGetDatasourceConfigurationPath tests the GetDatasourceConfigurationPath function.
+func TestGetDatasourceConfigurationPath(t *testing.T) {
+	expectedPath := "/etc/cloud/cloud.cfg.d/99_ptm_datasource.cfg"
+	result := GetDatasourceConfigurationPath()
+	if result != expectedPath {
+		t.Errorf("GetDatasourceConfigurationPath generated incorrect path.\nExpected:\n%s\n\nActual:\n%s", expectedPath, result)
+	}
+}
+
+// TestGetHostnameConfigurationPath tests the GetHostnameConfigurationPath function.
+func TestGetHostnameConfigurationPath(t *testing.T) {
+	expectedPath := "/etc/cloud/cloud.cfg.d/99_ptm_hostname.cfg"
+	result := GetHostnameConfigurationPath()
+	if result != expectedPath {
+		t.Errorf("GetHostnameConfigurationPath generated incorrect path.\nExpected:\n%s\n\nActual:\n%s", expectedPath, result)
+	}
+}
+
+// TestBuildDatasourceConfiguration tests the BuildDatasourceConfiguration function.
+func TestBuildDatasourceConfiguration(t *testing.T) {
+	testCases := []struct {
+		name        string
+		datasources []string
+		expected    string
+		expectErr   bool
+	}{
+		{"Default", []string{"NoCloud", "ConfigDrive"}, "\ndatasource_list: [ NoCloud, ConfigDrive ]\n", false},
+		{"Single", []string{"NoCloud"}, "\ndatasource_list: [ NoCloud ]\n", false},
+		{"Empty", []string{}, "", true},
+		{"Invalid", []string{"NoCloud, Ec2"}, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := BuildDatasourceConfiguration(tc.datasources)
+
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("BuildDatasourceConfiguration() error = %v, expectErr %v", err, tc.expectErr)
+			}
+
+			if result != tc.expected {
+				t.Errorf("BuildDatasourceConfiguration generated incorrect configuration.\nExpected:\n%s\n\nActual:\n%s", tc.expected, result)
+			}
+		})
+	}
+}
+
+// TestBuildHostnameConfiguration tests the BuildHostnameConfiguration function.
+func TestBuildHostnameConfiguration(t *testing.T) {
+	expected := "\npreserve_hostname: true\n"
+	result := BuildHostnameConfiguration(true)
+	if result != expected {
+		t.Errorf("BuildHostnameConfiguration generated incorrect configuration.\nExpected:\n%s\n\nActual:\n%s", expected, result)
+	}
+}
+
+// TestNormalizeModuleName tests the NormalizeModuleName function.
+func TestNormalizeModuleName(t *testing.T) {
+	testCases := map[string]string{
+		"snap":              "snap",
+		"cc_landscape":      "landscape",
+		"ssh-import-id":     "ssh_import_id",
+		" Ubuntu_Advantage": "ubuntu_advantage",
+	}
+
+	for module, expected := range testCases {
+		if result := NormalizeModuleName(module); result != expected {
+			t.Errorf("NormalizeModuleName(%q) = %s, want %s", module, result, expected)
+		}
+	}
+}
+
+// TestBuildDisableModulesCommand tests the BuildDisableModulesCommand function.
+func TestBuildDisableModulesCommand(t *testing.T) {
+	if _, err := BuildDisableModulesCommand([]string{}); err == nil {
+		t.Errorf("BuildDisableModulesCommand expected error for empty list")
+	}
+
+	if _, err := BuildDisableModulesCommand([]string{"snap; rm -rf /"}); err == nil {
+		t.Errorf("BuildDisableModulesCommand expected error for invalid module name")
+	}
+
+	command, err := BuildDisableModulesCommand([]string{"snap", "cc_ubuntu-advantage"})
+	if err != nil {
+		t.Fatalf("BuildDisableModulesCommand returned error: %v", err)
+	}
+
+	expected := `sed -i -E '/^[[:space:]]*-[[:space:]]*\[?[[:space:]]*(snap|ubuntu_advantage)[[:space:]]*(,.*)?\]?[[:space:]]*$/d' /etc/cloud/cloud.cfg`
+	if command != expected {
+		t.Errorf("BuildDisableModulesCommand generated incorrect command.\nExpected:\n%s\n\nActual:\n%s", expected, command)
+	}
+}
+
+// TestBuildDisableModulesCommandRemovesModules tests that the command built by BuildDisableModulesCommand removes the modules.
+func TestBuildDisableModulesCommandRemovesModules(t *testing.T) {
+	if _, err := exec.LookPath("sed"); err != nil {
+		t.Skip("sed is not available")
+	}
+
+	path := filepath.Join(t.TempDir(), "cloud.cfg")
+	content := "cloud_config_modules:\n  - snap\n  - snap_config\n  - [ubuntu_advantage, always]\n  - ssh_import_id\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test configuration: %v", err)
+	}
+
+	command, err := BuildDisableModulesCommand([]string{"snap", "ubuntu_advantage"})
+	if err != nil {
+		t.Fatalf("BuildDisableModulesCommand returned error: %v", err)
+	}
+
+	command = command[:len(command)-len(GetCloudConfigurationPath())] + path
+	if output, err := exec.Command("sh", "-c", command).CombinedOutput(); err != nil {
+		t.Fatalf("Failed to run command: %v, output: %s", err, output)
+	}
+
+	result, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test configuration: %v", err)
+	}
+
+	expected := "cloud_config_modules:\n  - snap_config\n  - ssh_import_id\n"
+	if string(result) != expected {
+		t.Errorf("Command left incorrect configuration.\nExpected:\n%s\n\nActual:\n%s", expected, result)
+	}
+}
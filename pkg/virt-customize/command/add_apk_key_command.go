@@ -0,0 +1,19 @@
+package command
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/configuration/repositories"
+)
+
+// NewAddApkKeyCommand creates a new add apk key command (Alpine Linux).
+func NewAddApkKeyCommand(image string, repository *repositories.Configuration) *Command {
+	return NewCommand(
+		image,
+		"--run-command",
+		fmt.Sprintf(
+			"wget -q -O %s %s",
+			repository.GetApkKeyFullPath(),
+			repository.GetGPG(),
+		),
+	)
+}
@@ -0,0 +1,24 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNewAddApkKeyCommand tests the NewAddApkKeyCommand function.
+func TestNewAddApkKeyCommand(t *testing.T) {
+	cmd := NewAddApkKeyCommand(imagePathForTesting, repositoryConfigurationForTesting)
+
+	expectedCommand := []string{
+		"-a",
+		imagePathForTesting,
+		"--run-command",
+		"wget -q -O /etc/apk/keys/docker-archive-keyring.rsa.pub https://download.docker.com/linux/ubuntu/gpg",
+	}
+
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
@@ -0,0 +1,19 @@
+package command
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/configuration/repositories"
+)
+
+// NewAddApkRepositoryCommand creates a new add apk repository command (Alpine Linux).
+func NewAddApkRepositoryCommand(image string, repository *repositories.Configuration) *Command {
+	return NewCommand(
+		image,
+		"--run-command",
+		fmt.Sprintf(
+			"echo \"%s\" >> %s",
+			repository.GetURL(),
+			repository.GetApkConfigurationFullPath(),
+		),
+	)
+}
@@ -0,0 +1,24 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNewAddApkRepositoryCommand tests the NewAddApkRepositoryCommand function.
+func TestNewAddApkRepositoryCommand(t *testing.T) {
+	cmd := NewAddApkRepositoryCommand(imagePathForTesting, repositoryConfigurationForTesting)
+
+	expectedCommand := []string{
+		"-a",
+		imagePathForTesting,
+		"--run-command",
+		"echo \"https://download.docker.com/linux/ubuntu\" >> /etc/apk/repositories",
+	}
+
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
@@ -0,0 +1,31 @@
+package command
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/configuration/repositories"
+	"path/filepath"
+)
+
+// NewAddPacmanKeyCommand creates a new add pacman key command (Arch Linux).
+// The key is imported for the customization and stored in the image, so the pacman-init service imports it again on the first boot (the keyring is not kept in the image).
+func NewAddPacmanKeyCommand(image string, repository *repositories.Configuration) *Command {
+	return NewCommand(
+		image,
+		"--run-command",
+		fmt.Sprintf(
+			"mkdir -p %s %s && curl -fsSL %s -o %s && pacman-key --add %s && pacman-key --lsign-key %s && printf '%%s\\n' %s > %s",
+			filepath.Dir(repository.GetPacmanKeyFullPath()),
+			filepath.Dir(repository.GetPacmanKeyDropInFullPath()),
+			repository.GetGPG(),
+			repository.GetPacmanKeyFullPath(),
+			repository.GetPacmanKeyFullPath(),
+			repository.GetKeyName(),
+			quoteShellArguments([]string{
+				"[Service]",
+				fmt.Sprintf("ExecStartPost=/usr/bin/pacman-key --add %s", repository.GetPacmanKeyFullPath()),
+				fmt.Sprintf("ExecStartPost=/usr/bin/pacman-key --lsign-key %s", repository.GetKeyName()),
+			}),
+			repository.GetPacmanKeyDropInFullPath(),
+		),
+	)
+}
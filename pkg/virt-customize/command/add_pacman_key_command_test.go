@@ -0,0 +1,28 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNewAddPacmanKeyCommand tests the NewAddPacmanKeyCommand function.
+func TestNewAddPacmanKeyCommand(t *testing.T) {
+	cmd := NewAddPacmanKeyCommand(imagePathForTesting, repositoryConfigurationForTesting)
+
+	expectedCommand := []string{
+		"-a",
+		imagePathForTesting,
+		"--run-command",
+		"mkdir -p /etc/pacman.d/ptm-keys /etc/systemd/system/pacman-init.service.d" +
+			" && curl -fsSL https://download.docker.com/linux/ubuntu/gpg -o /etc/pacman.d/ptm-keys/docker-archive-keyring.gpg" +
+			" && pacman-key --add /etc/pacman.d/ptm-keys/docker-archive-keyring.gpg && pacman-key --lsign-key docker-archive-keyring" +
+			" && printf '%s\\n' '[Service]' 'ExecStartPost=/usr/bin/pacman-key --add /etc/pacman.d/ptm-keys/docker-archive-keyring.gpg' 'ExecStartPost=/usr/bin/pacman-key --lsign-key docker-archive-keyring'" +
+			" > /etc/systemd/system/pacman-init.service.d/ptm-docker.conf",
+	}
+
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
@@ -0,0 +1,29 @@
+package command
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/configuration/repositories"
+	"strings"
+)
+
+// NewAddPacmanRepositoryCommand creates a new add pacman repository command (Arch Linux).
+func NewAddPacmanRepositoryCommand(image string, repository *repositories.Configuration) *Command {
+	return NewCommand(
+		image,
+		"--run-command",
+		fmt.Sprintf(
+			"printf '%%s\\n' %s >> %s",
+			quoteShellArguments(repository.GetPacmanConfigurationLines()),
+			repository.GetPacmanConfigurationFullPath(),
+		),
+	)
+}
+
+// quoteShellArguments quotes the values, so they are passed to the shell as separate literal arguments.
+func quoteShellArguments(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", `'\''`)))
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestNewAddPacmanRepositoryCommand tests the NewAddPacmanRepositoryCommand function.
+func TestNewAddPacmanRepositoryCommand(t *testing.T) {
+	cmd := NewAddPacmanRepositoryCommand(imagePathForTesting, repositoryConfigurationForTesting)
+
+	expectedCommand := []string{
+		"-a",
+		imagePathForTesting,
+		"--run-command",
+		"printf '%s\\n' '' '[docker]' 'SigLevel = Required' 'Server = https://download.docker.com/linux/ubuntu' >> /etc/pacman.conf",
+	}
+
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
+
+// TestQuoteShellArguments tests the quoteShellArguments function by running the quoted arguments in a shell.
+func TestQuoteShellArguments(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	values := []string{"", "[repo]", "Server = https://example.com/$repo/os/$arch", "it's"}
+	target := filepath.Join(t.TempDir(), "output")
+
+	if err := exec.Command("sh", "-c", "printf '%s\\n' "+quoteShellArguments(values)+" > "+target).Run(); err != nil {
+		t.Fatalf("failed to run the quoted arguments: %v", err)
+	}
+
+	content, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatalf("failed to read the output: %v", err)
+	}
+
+	expected := strings.Join(values, "\n") + "\n"
+	if string(content) != expected {
+		t.Errorf("quoteShellArguments produced %q, want %q", string(content), expected)
+	}
+}
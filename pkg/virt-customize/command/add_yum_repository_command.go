@@ -0,0 +1,19 @@
+package command
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/configuration/repositories"
+)
+
+// NewAddYumRepositoryCommand creates a new add yum repository command (dnf based distributions).
+func NewAddYumRepositoryCommand(image string, repository *repositories.Configuration) *Command {
+	return NewCommand(
+		image,
+		"--write",
+		fmt.Sprintf(
+			"%s:%s",
+			repository.GetYumConfigurationFullPath(),
+			repository.GetYumConfigurationContents(),
+		),
+	)
+}
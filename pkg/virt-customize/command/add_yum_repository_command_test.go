@@ -0,0 +1,25 @@
+package command
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// TestNewAddYumRepositoryCommand tests the NewAddYumRepositoryCommand function.
+func TestNewAddYumRepositoryCommand(t *testing.T) {
+	cmd := NewAddYumRepositoryCommand(imagePathForTesting, repositoryConfigurationForTesting)
+
+	expectedCommand := []string{
+		"-a",
+		imagePathForTesting,
+		"--write",
+		fmt.Sprintf("%s:%s", repositoryConfigurationForTesting.GetYumConfigurationFullPath(), repositoryConfigurationForTesting.GetYumConfigurationContents()),
+	}
+
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
@@ -0,0 +1,25 @@
+package command
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/configuration/repositories"
+)
+
+// NewAddZypperRepositoryCommand creates a new add zypper repository command (openSUSE based distributions).
+func NewAddZypperRepositoryCommand(image string, repository *repositories.Configuration) *Command {
+	gpgCheck := "--no-gpgcheck"
+	if repository.GetGPG() != "" {
+		gpgCheck = "--gpgcheck"
+	}
+
+	return NewCommand(
+		image,
+		"--run-command",
+		fmt.Sprintf(
+			"zypper --non-interactive addrepo --refresh %s '%s' %s",
+			gpgCheck,
+			repository.GetURL(),
+			repository.GetName(),
+		),
+	)
+}
@@ -0,0 +1,40 @@
+package command
+
+import (
+	"github.com/darki73/ptm/pkg/configuration/repositories"
+	"reflect"
+	"testing"
+)
+
+// TestNewAddZypperRepositoryCommand tests the NewAddZypperRepositoryCommand function.
+func TestNewAddZypperRepositoryCommand(t *testing.T) {
+	testCases := []struct {
+		name       string
+		repository *repositories.Configuration
+		expected   string
+	}{
+		{
+			"With GPG key",
+			repositoryConfigurationForTesting,
+			"zypper --non-interactive addrepo --refresh --gpgcheck 'https://download.docker.com/linux/ubuntu' docker",
+		},
+		{
+			"Without GPG key",
+			&repositories.Configuration{Name: "local", URL: "https://mirror.example.com/$releasever"},
+			"zypper --non-interactive addrepo --refresh --no-gpgcheck 'https://mirror.example.com/$releasever' local",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := NewAddZypperRepositoryCommand(imagePathForTesting, tc.repository)
+
+			expectedCommand := []string{"-a", imagePathForTesting, "--run-command", tc.expected}
+			result := cmd.BuildExecutionerCommand()
+
+			if !reflect.DeepEqual(result, expectedCommand) {
+				t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NewApkInstallCommand creates a new install command which uses apk (Alpine Linux).
+func NewApkInstallCommand(image string, packages []string) *Command {
+	return NewCommand(
+		image,
+		"--run-command",
+		fmt.Sprintf(
+			"apk add --no-cache %s",
+			strings.Join(packages, " "),
+		),
+	)
+}
+
+// NewApkUpgradeCommand creates a new upgrade command which uses apk (Alpine Linux).
+func NewApkUpgradeCommand(image string) *Command {
+	return NewCommand(
+		image,
+		"--run-command",
+		"apk upgrade --no-cache",
+	)
+}
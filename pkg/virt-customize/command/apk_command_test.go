@@ -0,0 +1,30 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNewApkInstallCommand tests the NewApkInstallCommand function.
+func TestNewApkInstallCommand(t *testing.T) {
+	cmd := NewApkInstallCommand(imagePathForTesting, []string{"curl", "qemu-guest-agent"})
+
+	expectedCommand := []string{"-a", imagePathForTesting, "--run-command", "apk add --no-cache curl qemu-guest-agent"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
+
+// TestNewApkUpgradeCommand tests the NewApkUpgradeCommand function.
+func TestNewApkUpgradeCommand(t *testing.T) {
+	cmd := NewApkUpgradeCommand(imagePathForTesting)
+
+	expectedCommand := []string{"-a", imagePathForTesting, "--run-command", "apk upgrade --no-cache"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
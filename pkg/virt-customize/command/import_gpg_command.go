@@ -0,0 +1,18 @@
+package command
+
+import (
+	"fmt"
+	"github.com/darki73/ptm/pkg/configuration/repositories"
+)
+
+// NewImportGPGCommand creates a new import gpg command (rpm based distributions).
+func NewImportGPGCommand(image string, repository *repositories.Configuration) *Command {
+	return NewCommand(
+		image,
+		"--run-command",
+		fmt.Sprintf(
+			"rpm --import %s",
+			repository.GetGPG(),
+		),
+	)
+}
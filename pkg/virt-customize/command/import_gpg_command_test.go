@@ -0,0 +1,25 @@
+package command
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// TestNewImportGPGCommand tests the NewImportGPGCommand function.
+func TestNewImportGPGCommand(t *testing.T) {
+	cmd := NewImportGPGCommand(imagePathForTesting, repositoryConfigurationForTesting)
+
+	expectedCommand := []string{
+		"-a",
+		imagePathForTesting,
+		"--run-command",
+		fmt.Sprintf("rpm --import %s", repositoryConfigurationForTesting.GetGPG()),
+	}
+
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
@@ -0,0 +1,38 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NewPacmanInstallCommand creates a new install command which uses pacman (Arch Linux).
+func NewPacmanInstallCommand(image string, packages []string) *Command {
+	return NewCommand(
+		image,
+		"--run-command",
+		fmt.Sprintf(
+			"pacman -S --noconfirm --needed %s",
+			strings.Join(packages, " "),
+		),
+	)
+}
+
+// NewPacmanUpgradeCommand creates a new upgrade command which uses pacman (Arch Linux).
+// The keyring is initialized first, as the image only initializes it on the first boot.
+func NewPacmanUpgradeCommand(image string) *Command {
+	return NewCommand(
+		image,
+		"--run-command",
+		"(test -d /etc/pacman.d/gnupg || (pacman-key --init && pacman-key --populate)) && pacman -Syu --noconfirm",
+	)
+}
+
+// NewPacmanKeyringCleanupCommand creates a new command which removes the keyring initialized during customization.
+// Every clone then generates its own keyring (and local master key) on the first boot.
+func NewPacmanKeyringCleanupCommand(image string) *Command {
+	return NewCommand(
+		image,
+		"--run-command",
+		"rm -rf /etc/pacman.d/gnupg",
+	)
+}
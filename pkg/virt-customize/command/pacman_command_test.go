@@ -0,0 +1,47 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNewPacmanInstallCommand tests the NewPacmanInstallCommand function.
+func TestNewPacmanInstallCommand(t *testing.T) {
+	cmd := NewPacmanInstallCommand(imagePathForTesting, []string{"curl", "qemu-guest-agent"})
+
+	expectedCommand := []string{"-a", imagePathForTesting, "--run-command", "pacman -S --noconfirm --needed curl qemu-guest-agent"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
+
+// TestNewPacmanUpgradeCommand tests the NewPacmanUpgradeCommand function.
+func TestNewPacmanUpgradeCommand(t *testing.T) {
+	cmd := NewPacmanUpgradeCommand(imagePathForTesting)
+
+	expectedCommand := []string{
+		"-a",
+		imagePathForTesting,
+		"--run-command",
+		"(test -d /etc/pacman.d/gnupg || (pacman-key --init && pacman-key --populate)) && pacman -Syu --noconfirm",
+	}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
+
+// TestNewPacmanKeyringCleanupCommand tests the NewPacmanKeyringCleanupCommand function.
+func TestNewPacmanKeyringCleanupCommand(t *testing.T) {
+	cmd := NewPacmanKeyringCleanupCommand(imagePathForTesting)
+
+	expectedCommand := []string{"-a", imagePathForTesting, "--run-command", "rm -rf /etc/pacman.d/gnupg"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
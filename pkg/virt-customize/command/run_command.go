@@ -0,0 +1,10 @@
+package command
+
+// NewRunCommand creates a new run command.
+func NewRunCommand(image string, commandToRun string) *Command {
+	return NewCommand(
+		image,
+		"--run-command",
+		commandToRun,
+	)
+}
@@ -0,0 +1,18 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNewRunCommand tests the NewRunCommand function.
+func TestNewRunCommand(t *testing.T) {
+	cmd := NewRunCommand(imagePathForTesting, "systemctl enable watchdog")
+
+	expectedCommand := []string{"-a", imagePathForTesting, "--run-command", "systemctl enable watchdog"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
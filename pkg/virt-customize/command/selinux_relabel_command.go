@@ -0,0 +1,9 @@
+package command
+
+// NewSELinuxRelabelCommand creates a new SELinux relabel command.
+func NewSELinuxRelabelCommand(image string) *Command {
+	return NewCommand(
+		image,
+		"--selinux-relabel",
+	)
+}
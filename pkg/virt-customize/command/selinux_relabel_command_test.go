@@ -0,0 +1,18 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNewSELinuxRelabelCommand tests the NewSELinuxRelabelCommand function.
+func TestNewSELinuxRelabelCommand(t *testing.T) {
+	cmd := NewSELinuxRelabelCommand(imagePathForTesting)
+
+	expectedCommand := []string{"-a", imagePathForTesting, "--selinux-relabel"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
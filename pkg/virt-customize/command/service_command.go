@@ -0,0 +1,19 @@
+package command
+
+import "fmt"
+
+// NewSystemdEnableCommand creates a new command which enables the systemd service.
+func NewSystemdEnableCommand(image string, service string) *Command {
+	return NewRunCommand(
+		image,
+		fmt.Sprintf("systemctl enable %s", service),
+	)
+}
+
+// NewOpenRCEnableCommand creates a new command which adds the OpenRC service to the default runlevel.
+func NewOpenRCEnableCommand(image string, service string) *Command {
+	return NewRunCommand(
+		image,
+		fmt.Sprintf("rc-update add %s default", service),
+	)
+}
@@ -0,0 +1,30 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNewSystemdEnableCommand tests the NewSystemdEnableCommand function.
+func TestNewSystemdEnableCommand(t *testing.T) {
+	cmd := NewSystemdEnableCommand(imagePathForTesting, "watchdog")
+
+	expectedCommand := []string{"-a", imagePathForTesting, "--run-command", "systemctl enable watchdog"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
+
+// TestNewOpenRCEnableCommand tests the NewOpenRCEnableCommand function.
+func TestNewOpenRCEnableCommand(t *testing.T) {
+	cmd := NewOpenRCEnableCommand(imagePathForTesting, "qemu-guest-agent")
+
+	expectedCommand := []string{"-a", imagePathForTesting, "--run-command", "rc-update add qemu-guest-agent default"}
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
@@ -0,0 +1,27 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NewZypperInstallCommand creates a new install command which uses zypper (openSUSE based distributions).
+func NewZypperInstallCommand(image string, packages []string) *Command {
+	return NewCommand(
+		image,
+		"--run-command",
+		fmt.Sprintf(
+			"zypper --non-interactive install --auto-agree-with-licenses %s",
+			strings.Join(packages, " "),
+		),
+	)
+}
+
+// NewZypperUpdateCommand creates a new update command which uses zypper (openSUSE based distributions).
+func NewZypperUpdateCommand(image string) *Command {
+	return NewCommand(
+		image,
+		"--run-command",
+		"zypper --non-interactive refresh && zypper --non-interactive update --auto-agree-with-licenses",
+	)
+}
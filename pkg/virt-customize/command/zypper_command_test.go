@@ -0,0 +1,42 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNewZypperInstallCommand tests the NewZypperInstallCommand function.
+func TestNewZypperInstallCommand(t *testing.T) {
+	cmd := NewZypperInstallCommand(imagePathForTesting, []string{"curl", "qemu-guest-agent"})
+
+	expectedCommand := []string{
+		"-a",
+		imagePathForTesting,
+		"--run-command",
+		"zypper --non-interactive install --auto-agree-with-licenses curl qemu-guest-agent",
+	}
+
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
+
+// TestNewZypperUpdateCommand tests the NewZypperUpdateCommand function.
+func TestNewZypperUpdateCommand(t *testing.T) {
+	cmd := NewZypperUpdateCommand(imagePathForTesting)
+
+	expectedCommand := []string{
+		"-a",
+		imagePathForTesting,
+		"--run-command",
+		"zypper --non-interactive refresh && zypper --non-interactive update --auto-agree-with-licenses",
+	}
+
+	result := cmd.BuildExecutionerCommand()
+
+	if !reflect.DeepEqual(result, expectedCommand) {
+		t.Errorf("Expected command to be %v, but got %v", expectedCommand, result)
+	}
+}
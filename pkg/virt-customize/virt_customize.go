@@ -1,8 +1,10 @@
 package virt_customize
 
 import (
+	cic "github.com/darki73/ptm/pkg/configuration/cloud-init"
 	"github.com/darki73/ptm/pkg/configuration/repositories"
 	uu "github.com/darki73/ptm/pkg/configuration/unattended-upgrades"
+	"github.com/darki73/ptm/pkg/distributions"
 )
 
 // VirtCustomize is a structure that holds information for virt-customize configurator.
@@ -17,6 +19,16 @@ type VirtCustomize struct {
 	repositoriesConfiguration []*repositories.Configuration
 	// unattendedUpgradesConfiguration is a reference to unattended upgrades configuration.
 	unattendedUpgradesConfiguration *uu.Configuration
+	// watchdogModel is the watchdog device model the in-guest watchdog daemon should be configured for.
+	watchdogModel string
+	// cloudInitImageConfiguration is a reference to the cloud-init tuning baked into the image.
+	cloudInitImageConfiguration *cic.CloudInitImage
+	// packageManager is the package manager of the distribution the image is built from.
+	packageManager string
+	// initSystem is the init system of the distribution the image is built from.
+	initSystem string
+	// selinuxRelabel is the flag that relabels the image files for SELinux after customization.
+	selinuxRelabel bool
 }
 
 // NewVirtCustomizeConfiguration creates a new virt-customize configuration.
@@ -65,3 +77,74 @@ func (vc *VirtCustomize) GetRepositoriesConfiguration() []*repositories.Configur
 func (vc *VirtCustomize) GetUnattendedUpgradesConfiguration() *uu.Configuration {
 	return vc.unattendedUpgradesConfiguration
 }
+
+// GetWatchdogModel returns the watchdog device model the in-guest watchdog daemon should be configured for.
+func (vc *VirtCustomize) GetWatchdogModel() string {
+	return vc.watchdogModel
+}
+
+// SetWatchdogModel sets the watchdog device model the in-guest watchdog daemon should be configured for.
+func (vc *VirtCustomize) SetWatchdogModel(watchdogModel string) *VirtCustomize {
+	vc.watchdogModel = watchdogModel
+	return vc
+}
+
+// IsWatchdogEnabled returns true if the in-guest watchdog daemon should be enabled.
+func (vc *VirtCustomize) IsWatchdogEnabled() bool {
+	return vc.watchdogModel != ""
+}
+
+// GetCloudInitImageConfiguration returns a reference to the cloud-init tuning baked into the image.
+func (vc *VirtCustomize) GetCloudInitImageConfiguration() *cic.CloudInitImage {
+	return vc.cloudInitImageConfiguration
+}
+
+// SetCloudInitImageConfiguration sets a reference to the cloud-init tuning baked into the image.
+func (vc *VirtCustomize) SetCloudInitImageConfiguration(cloudInitImageConfiguration *cic.CloudInitImage) *VirtCustomize {
+	vc.cloudInitImageConfiguration = cloudInitImageConfiguration
+	return vc
+}
+
+// IsCloudInitTuningEnabled returns true if the cloud-init tuning should be baked into the image.
+func (vc *VirtCustomize) IsCloudInitTuningEnabled() bool {
+	return vc.cloudInitImageConfiguration != nil && vc.cloudInitImageConfiguration.GetEnabled()
+}
+
+// GetPackageManager returns the package manager of the distribution the image is built from (apt if not set).
+func (vc *VirtCustomize) GetPackageManager() string {
+	if vc.packageManager == "" {
+		return distributions.PackageManagerApt
+	}
+	return vc.packageManager
+}
+
+// SetPackageManager sets the package manager of the distribution the image is built from.
+func (vc *VirtCustomize) SetPackageManager(packageManager string) *VirtCustomize {
+	vc.packageManager = packageManager
+	return vc
+}
+
+// GetInitSystem returns the init system of the distribution the image is built from (systemd if not set).
+func (vc *VirtCustomize) GetInitSystem() string {
+	if vc.initSystem == "" {
+		return distributions.InitSystemSystemd
+	}
+	return vc.initSystem
+}
+
+// SetInitSystem sets the init system of the distribution the image is built from.
+func (vc *VirtCustomize) SetInitSystem(initSystem string) *VirtCustomize {
+	vc.initSystem = initSystem
+	return vc
+}
+
+// GetSELinuxRelabel returns the flag that relabels the image files for SELinux after customization.
+func (vc *VirtCustomize) GetSELinuxRelabel() bool {
+	return vc.selinuxRelabel
+}
+
+// SetSELinuxRelabel sets the flag that relabels the image files for SELinux after customization.
+func (vc *VirtCustomize) SetSELinuxRelabel(selinuxRelabel bool) *VirtCustomize {
+	vc.selinuxRelabel = selinuxRelabel
+	return vc
+}
@@ -0,0 +1,119 @@
+package watchdog
+
+import (
+	"fmt"
+)
+
+var (
+	// watchdogModules is the map of watchdog device models to the kernel modules driving them.
+	watchdogModules = map[string]string{
+		"i6300esb": "i6300esb",
+		"ib700":    "ib700wdt",
+	}
+)
+
+// watchdogConfigurationTemplate represents the template for the watchdog daemon configuration.
+// /etc/watchdog.conf
+const watchdogConfigurationTemplate = `
+watchdog-device = /dev/watchdog
+watchdog-timeout = 60
+interval = 10
+realtime = yes
+priority = 1
+`
+
+// watchdogDefaultsTemplate represents the template for the watchdog daemon defaults.
+// /etc/default/watchdog
+const watchdogDefaultsTemplate = `
+run_watchdog=1
+run_wd_keepalive=0
+watchdog_module="%s"
+watchdog_options=""
+`
+
+// GetWatchdogPackage returns the name of the package which provides the watchdog daemon.
+func GetWatchdogPackage() string {
+	return "watchdog"
+}
+
+// GetWatchdogService returns the name of the watchdog daemon service.
+func GetWatchdogService() string {
+	return "watchdog"
+}
+
+// GetWatchdogConfigurationTemplate returns the watchdog daemon configuration template.
+func GetWatchdogConfigurationTemplate() string {
+	return watchdogConfigurationTemplate
+}
+
+// GetWatchdogConfigurationPath returns the path to the watchdog daemon configuration.
+func GetWatchdogConfigurationPath() string {
+	return "/etc/watchdog.conf"
+}
+
+// GetWatchdogConfigurationTemporaryPath returns the path to the watchdog daemon configuration temporary file.
+func GetWatchdogConfigurationTemporaryPath() string {
+	return "/tmp/ptm-watchdog.conf"
+}
+
+// GetWatchdogDefaultsPath returns the path to the watchdog daemon defaults.
+func GetWatchdogDefaultsPath() string {
+	return "/etc/default/watchdog"
+}
+
+// GetWatchdogDefaultsTemporaryPath returns the path to the watchdog daemon defaults temporary file.
+func GetWatchdogDefaultsTemporaryPath() string {
+	return "/tmp/ptm-default-watchdog"
+}
+
+// BuildWatchdogDefaults builds the watchdog daemon defaults for the given watchdog device model.
+func BuildWatchdogDefaults(model string) (string, error) {
+	module, ok := watchdogModules[model]
+	if !ok {
+		return "", fmt.Errorf("unsupported watchdog model: %s", model)
+	}
+
+	return fmt.Sprintf(watchdogDefaultsTemplate, module), nil
+}
+
+// GetWatchdogModulesLoadPath returns the path to the file which loads the watchdog kernel module on boot.
+// Used on distributions which do not read /etc/default/watchdog (RHEL compatible).
+func GetWatchdogModulesLoadPath() string {
+	return "/etc/modules-load.d/watchdog.conf"
+}
+
+// GetWatchdogModulesLoadTemporaryPath returns the path to the watchdog modules load temporary file.
+func GetWatchdogModulesLoadTemporaryPath() string {
+	return "/tmp/ptm-modules-load-watchdog.conf"
+}
+
+// BuildWatchdogModulesLoad builds the modules load file for the given watchdog device model.
+func BuildWatchdogModulesLoad(model string) (string, error) {
+	module, ok := watchdogModules[model]
+	if !ok {
+		return "", fmt.Errorf("unsupported watchdog model: %s", model)
+	}
+
+	return fmt.Sprintf("%s\n", module), nil
+}
+
+// GetWatchdogOpenRCPackage returns the name of the package which provides the watchdog service on OpenRC based distributions (busybox watchdog).
+func GetWatchdogOpenRCPackage() string {
+	return "busybox-openrc"
+}
+
+// GetWatchdogOpenRCModulesPath returns the path to the file which lists kernel modules loaded on boot by OpenRC.
+func GetWatchdogOpenRCModulesPath() string {
+	return "/etc/modules"
+}
+
+// BuildWatchdogOpenRCModulesCommand builds the command which appends the watchdog kernel module to the modules loaded on boot by OpenRC.
+// The file is appended to, as it already lists modules required by the distribution.
+func BuildWatchdogOpenRCModulesCommand(model string) (string, error) {
+	module, ok := watchdogModules[model]
+	if !ok {
+		return "", fmt.Errorf("unsupported watchdog model: %s", model)
+	}
+
+	return fmt.Sprintf("echo %s >> %s", module, GetWatchdogOpenRCModulesPath()), nil
+}
@@ -0,0 +1,114 @@
+package watchdog
+
+import "testing"
+
+// TestGetWatchdogConfigurationPath tests the GetWatchdogConfigurationPath function.
+func TestGetWatchdogConfigurationPath(t *testing.T) {
+	expectedPath := "/etc/watchdog.conf"
+	result := GetWatchdogConfigurationPath()
+	if result != expectedPath {
+		t.Errorf("GetWatchdogConfigurationPath generated incorrect path.\nExpected:\n%s\n\nActual:\n%s", expectedPath, result)
+	}
+}
+
+// TestGetWatchdogDefaultsPath tests the GetWatchdogDefaultsPath function.
+func TestGetWatchdogDefaultsPath(t *testing.T) {
+	expectedPath := "/etc/default/watchdog"
+	result := GetWatchdogDefaultsPath()
+	if result != expectedPath {
+		t.Errorf("GetWatchdogDefaultsPath generated incorrect path.\nExpected:\n%s\n\nActual:\n%s", expectedPath, result)
+	}
+}
+
+// TestBuildWatchdogDefaults tests the BuildWatchdogDefaults function.
+func TestBuildWatchdogDefaults(t *testing.T) {
+	testCases := []struct {
+		name      string
+		model     string
+		expected  string
+		expectErr bool
+	}{
+		{"i6300esb", "i6300esb", "\nrun_watchdog=1\nrun_wd_keepalive=0\nwatchdog_module=\"i6300esb\"\nwatchdog_options=\"\"\n", false},
+		{"ib700", "ib700", "\nrun_watchdog=1\nrun_wd_keepalive=0\nwatchdog_module=\"ib700wdt\"\nwatchdog_options=\"\"\n", false},
+		{"Unsupported", "unknown", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := BuildWatchdogDefaults(tc.model)
+
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("BuildWatchdogDefaults() error = %v, expectErr %v", err, tc.expectErr)
+			}
+
+			if result != tc.expected {
+				t.Errorf("BuildWatchdogDefaults generated incorrect defaults.\nExpected:\n%s\n\nActual:\n%s", tc.expected, result)
+			}
+		})
+	}
+}
+
+// TestGetWatchdogModulesLoadPath tests the GetWatchdogModulesLoadPath function.
+func TestGetWatchdogModulesLoadPath(t *testing.T) {
+	expectedPath := "/etc/modules-load.d/watchdog.conf"
+	result := GetWatchdogModulesLoadPath()
+	if result != expectedPath {
+		t.Errorf("GetWatchdogModulesLoadPath generated incorrect path.\nExpected:\n%s\n\nActual:\n%s", expectedPath, result)
+	}
+}
+
+// TestBuildWatchdogModulesLoad tests the BuildWatchdogModulesLoad function.
+func TestBuildWatchdogModulesLoad(t *testing.T) {
+	testCases := []struct {
+		name      string
+		model     string
+		expected  string
+		expectErr bool
+	}{
+		{"i6300esb", "i6300esb", "i6300esb\n", false},
+		{"ib700", "ib700", "ib700wdt\n", false},
+		{"Unsupported", "unknown", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := BuildWatchdogModulesLoad(tc.model)
+
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("BuildWatchdogModulesLoad() error = %v, expectErr %v", err, tc.expectErr)
+			}
+
+			if result != tc.expected {
+				t.Errorf("BuildWatchdogModulesLoad generated incorrect file.\nExpected:\n%s\n\nActual:\n%s", tc.expected, result)
+			}
+		})
+	}
+}
+
+// TestBuildWatchdogOpenRCModulesCommand tests the BuildWatchdogOpenRCModulesCommand function.
+func TestBuildWatchdogOpenRCModulesCommand(t *testing.T) {
+	testCases := []struct {
+		name      string
+		model     string
+		expected  string
+		expectErr bool
+	}{
+		{"i6300esb", "i6300esb", "echo i6300esb >> /etc/modules", false},
+		{"ib700", "ib700", "echo ib700wdt >> /etc/modules", false},
+		{"Unsupported", "unknown", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := BuildWatchdogOpenRCModulesCommand(tc.model)
+
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("BuildWatchdogOpenRCModulesCommand() error = %v, expectErr %v", err, tc.expectErr)
+			}
+
+			if result != tc.expected {
+				t.Errorf("BuildWatchdogOpenRCModulesCommand generated incorrect command.\nExpected:\n%s\n\nActual:\n%s", tc.expected, result)
+			}
+		})
+	}
+}